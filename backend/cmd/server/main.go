@@ -2,22 +2,46 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/blob"
+	"github.com/Elmar006/todo_grpc/internal/calendar"
+	"github.com/Elmar006/todo_grpc/internal/certs"
 	"github.com/Elmar006/todo_grpc/internal/config"
+	"github.com/Elmar006/todo_grpc/internal/cors"
 	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/gateway"
 	"github.com/Elmar006/todo_grpc/internal/grpc/handler"
+	"github.com/Elmar006/todo_grpc/internal/grpc/interceptor"
 	"github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/monitor"
+	"github.com/Elmar006/todo_grpc/internal/outbox"
+	"github.com/Elmar006/todo_grpc/internal/ratelimit"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
 	"github.com/Elmar006/todo_grpc/internal/repository"
 	"github.com/Elmar006/todo_grpc/internal/service"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+	"github.com/Elmar006/todo_grpc/internal/webhook"
+	"github.com/Elmar006/todo_grpc/internal/webrpc"
 	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 func main() {
@@ -28,17 +52,226 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	if err := db.Init(cfg.DBPath); err != nil {
-		log.Fatalf("Failed to init db: %v", err)
+	if len(os.Args) > 1 && os.Args[1] == "restore" {
+		if err := restore(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Restore failed: %v", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "create-tenant" {
+		if err := createTenant(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Create tenant failed: %v", err)
+		}
+		return
+	}
+
+	repo := &repository.RepositoryDB{}
+	if cfg.TenantDir == "" {
+		if err := db.Init(cfg.DBPath, cfg.DB); err != nil {
+			log.Fatalf("Failed to init db: %v", err)
+		}
+		defer db.DB.Close()
+		repo.Pool = db.DB
+	}
+
+	validator := validation.New(validation.Limits{
+		TitleMaxLength:       cfg.TitleMaxLength,
+		DescriptionMaxLength: cfg.DescriptionMaxLength,
+		FilterMaxLength:      cfg.FilterMaxLength,
+		CommentMaxLength:     cfg.CommentMaxLength,
+	})
+
+	policy := rbac.Default()
+	if cfg.RBACPolicyFile != "" {
+		if policy, err = rbac.Load(cfg.RBACPolicyFile); err != nil {
+			log.Fatalf("Failed to load RBAC policy: %v", err)
+		}
+	}
+
+	blobStore, err := newBlobStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up attachment store: %v", err)
+	}
+	attachmentOpts := []service.AttachmentOption{service.WithMaxAttachmentSize(cfg.AttachmentMaxSize)}
+	if len(cfg.AttachmentContentTypes) > 0 {
+		attachmentOpts = append(attachmentOpts, service.WithContentTypes(cfg.AttachmentContentTypes))
+	}
+	taskService := service.NewTaskService(repo,
+		service.WithValidator(validator),
+		service.WithTaskQuota(cfg.TaskQuota),
+		service.WithOwnerCounter(repo),
+		service.WithFeedRepository(repo),
+		service.WithRevisionRepository(repo),
+		service.WithWorkspaceRepository(repo),
+		service.WithShareRepository(repo),
+		service.WithPolicy(policy),
+		service.WithAdmins(cfg.AdminUsers),
+	)
+	attachmentService := service.NewAttachmentService(repo, taskService, blobStore, attachmentOpts...)
+	backupService := service.NewBackupService(repo, cfg.BackupDir, cfg.BackupKeep, cfg.AdminUsers)
+	taskHandler := handler.NewTaskHandler(taskService,
+		handler.WithWebhookService(service.NewWebhookService(repo, cfg.AdminUsers)),
+		handler.WithHistoryService(service.NewHistoryService(repo, taskService, cfg.AdminUsers)),
+		handler.WithCommentService(service.NewCommentService(repo, taskService, validator)),
+		handler.WithAttachmentService(attachmentService),
+		handler.WithWorkspaceService(service.NewWorkspaceService(repo, policy)),
+		handler.WithBackupService(backupService),
+	)
+
+	connMonitor := monitor.New()
+	adminHandler := handler.NewAdminHandler(service.NewAdminService(repo, connMonitor, cfg.AdminUsers))
+
+	authenticator := auth.NewTokenAuthenticator(cfg.AuthTokens)
+	if len(cfg.AuthTokens) == 0 && cfg.TLSClientCAFile == "" {
+		log.Warn("AUTH_TOKENS and TLS_CLIENT_CA_FILE are empty: every call is anonymous and cannot create or read tasks")
+	}
+	limiter := ratelimit.New(cfg.RateLimit, cfg.MethodRateLimits)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dispatcher := webhook.NewDispatcher(repo,
+		webhook.WithTimeout(cfg.WebhookTimeout),
+		webhook.WithMaxAttempts(cfg.WebhookMaxAttempts),
+		webhook.WithBackoff(cfg.WebhookBackoffBase, cfg.WebhookBackoffMax),
+	)
+
+	publisher, err := newEventPublisher(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up event publisher: %v", err)
+	}
+	defer publisher.Close()
+	relay := outbox.NewRelay(repo, outbox.Fanout{webhook.NewPublisher(repo), publisher})
+
+	// Background workers run against one database. With tenants, each open
+	// tenant database gets its own set, stopped when the database closes,
+	// and closed ones are woken now and then to make one pass of each.
+	workers := []func(context.Context){dispatcher.Run, attachmentService.Run, relay.Run}
+
+	// The relay goes first, so events it publishes to webhooks are
+	// dispatched in the same pass.
+	type pass struct {
+		name string
+		run  func(context.Context) error
+	}
+	passes := []pass{
+		{"outbox relay", func(ctx context.Context) error { _, err := relay.RelayPending(ctx); return err }},
+		{"webhook dispatch", func(ctx context.Context) error { _, err := dispatcher.DispatchDue(ctx); return err }},
+		{"attachment sweep", attachmentService.SweepDeleted},
+	}
+	if cfg.BackupInterval > 0 {
+		workers = append(workers, func(ctx context.Context) { backupService.Run(ctx, cfg.BackupInterval) })
+		passes = append(passes, pass{"scheduled backup", func(ctx context.Context) error {
+			_, err := backupService.BackupIfDue(ctx, cfg.BackupInterval)
+			return err
+		}})
+	}
+	var idempotencyService *service.IdempotencyService
+	if cfg.IdempotencyTTL > 0 {
+		idempotencyService = service.NewIdempotencyService(repo, service.WithIdempotencyTTL(cfg.IdempotencyTTL))
+		workers = append(workers, idempotencyService.Run)
+		passes = append(passes, pass{"idempotency key sweep", idempotencyService.SweepExpired})
+	}
+	runWorkers := func(ctx context.Context) {
+		var wg sync.WaitGroup
+		for _, run := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				run(ctx)
+			}()
+		}
+		wg.Wait()
+	}
+
+	// Rate limiting runs before the tenant is resolved, so rejected callers
+	// cannot make the server open tenant databases.
+	unary := []grpc.UnaryServerInterceptor{
+		interceptor.Auth(authenticator),
+		interceptor.Track(connMonitor),
+		interceptor.RateLimit(limiter),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		interceptor.StreamAuth(authenticator),
+		interceptor.StreamTrack(connMonitor),
+		interceptor.StreamRateLimit(limiter),
+	}
+	var calendarOpts []calendar.Option
+
+	if cfg.TenantDir == "" {
+		go runWorkers(ctx)
+	} else {
+		resolver, err := tenant.NewResolver(cfg.TenantUsers)
+		if err != nil {
+			log.Fatalf("Failed to set up tenants: %v", err)
+		}
+		tenants, err := tenant.NewManager(cfg.TenantDir,
+			tenant.WithMaxOpen(cfg.TenantMaxOpen),
+			tenant.WithIdleTimeout(cfg.TenantIdleTimeout),
+			tenant.WithDBOptions(cfg.DB),
+			tenant.WithOnOpen(runWorkers),
+			tenant.WithWake(cfg.TenantWakeInterval, func(ctx context.Context) {
+				id, _ := tenant.IDFrom(ctx)
+				for _, p := range passes {
+					if err := p.run(ctx); err != nil {
+						log.Errorf("Tenant %s: %s failed: %v", id, p.name, err)
+					}
+				}
+			}),
+		)
+		if err != nil {
+			log.Fatalf("Failed to set up tenants: %v", err)
+		}
+		defer tenants.Close()
+		go tenants.Run(ctx)
+
+		unary = append(unary, interceptor.Tenant(resolver, tenants))
+		stream = append(stream, interceptor.StreamTenant(resolver, tenants))
+		calendarOpts = append(calendarOpts, calendar.WithTenants(tenants))
+		log.Infof("Multi-tenant mode: databases in %s", cfg.TenantDir)
+	}
+
+	unary = append(unary, interceptor.Validation(validator))
+	if idempotencyService != nil {
+		unary = append(unary, interceptor.Idempotency(idempotencyService))
 	}
-	defer db.DB.Close()
 
-	repo := &repository.RepositoryDB{DB: db.DB}
-	taskService := service.NewTaskService(repo)
-	taskHandler := handler.NewTaskHandler(taskService)
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(connMonitor),
+		// Long-lived streams such as downloads rely on client keepalive
+		// pings; without a policy grpc-go drops clients pinging more often
+		// than every 5 minutes.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(append(stream, interceptor.StreamValidation(validator))...),
+	}
+
+	// The in-process server backs the HTTP gateway. It shares the
+	// interceptors but never uses TLS, since it is not reachable over the
+	// network.
+	inprocServer := grpc.NewServer(serverOpts...)
 
-	grpcServer := grpc.NewServer()
-	todo.RegisterTodoServiceServer(grpcServer, taskHandler)
+	var tlsConfig *tls.Config
+	if cfg.TLSCertFile != "" {
+		reloader, err := certs.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+		if err != nil {
+			log.Fatalf("Failed to load TLS certificates: %v", err)
+		}
+		go reloader.Watch(ctx, cfg.TLSReloadInterval)
+
+		tlsConfig = reloader.TLSConfig(cfg.TLSMinVersion)
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		log.Infof("TLS enabled (mTLS: %t)", cfg.TLSClientCAFile != "")
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	for _, s := range []*grpc.Server{grpcServer, inprocServer} {
+		todo.RegisterTodoServiceServer(s, taskHandler)
+		todo.RegisterAdminServiceServer(s, adminHandler)
+	}
 
 	reflection.Register(grpcServer)
 
@@ -47,8 +280,51 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	inprocListener := bufconn.Listen(1 << 20)
+	go inprocServer.Serve(inprocListener)
+
+	inprocConn, err := grpc.NewClient("passthrough:///inproc",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return inprocListener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		log.Fatalf("Failed to connect in-process client: %v", err)
+	}
+	defer inprocConn.Close()
+
+	todoDesc := todo.File_todoService_todo_proto.Services().ByName("TodoService")
+	webHandler, err := webrpc.New(inprocConn, todoDesc)
+	if err != nil {
+		log.Fatalf("Failed to build gRPC-Web handler: %v", err)
+	}
+
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/v1/", gateway.New(todo.NewTodoServiceClient(inprocConn)))
+	httpMux.Handle("/"+string(todoDesc.FullName())+"/", webHandler)
+	httpMux.Handle(calendar.PathPrefix, calendar.NewHandler(taskService, calendarOpts...))
+
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.HTTPPort),
+		Handler:           cors.Handler(cfg.CORSAllowedOrigins, httpMux),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Infof("HTTP gateway listening on port %d", cfg.HTTPPort)
+
+		var err error
+		if tlsConfig != nil {
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to serve HTTP: %v", err)
+		}
+	}()
 
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -56,6 +332,12 @@ func main() {
 		<-sigChan
 
 		log.Info("Graceful shutdown initiated")
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("HTTP shutdown failed: %v", err)
+		}
+		inprocServer.GracefulStop()
 		grpcServer.GracefulStop()
 		cancel()
 	}()
@@ -70,3 +352,37 @@ func main() {
 		}
 	}
 }
+
+// newBlobStore builds the attachment store selected by ATTACHMENT_STORE.
+func newBlobStore(cfg *config.Config) (service.BlobStore, error) {
+	if cfg.AttachmentStore == "s3" {
+		return blob.NewS3Store(blob.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			Prefix:          cfg.S3Prefix,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		})
+	}
+	return blob.NewFileStore(cfg.AttachmentDir)
+}
+
+// newEventPublisher builds the publisher selected by EVENT_PUBLISHER. With
+// none selected, events only feed webhooks.
+func newEventPublisher(cfg *config.Config) (outbox.EventPublisher, error) {
+	switch cfg.EventPublisher {
+	case "stdout":
+		return outbox.NewWriterPublisher(os.Stdout), nil
+	case "file":
+		return outbox.OpenFilePublisher(cfg.EventFile)
+	case "nats":
+		return outbox.DialNATS(cfg.NATSURL, cfg.NATSSubjectPrefix, cfg.NATSJetStream)
+	case "kafka":
+		if len(cfg.KafkaBrokers) == 0 {
+			return nil, errors.New("KAFKA_BROKERS is not set")
+		}
+		return outbox.NewKafkaPublisher(cfg.KafkaBrokers, cfg.KafkaTopic), nil
+	}
+	return outbox.Fanout{}, nil
+}
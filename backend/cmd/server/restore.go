@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/backup"
+	"github.com/Elmar006/todo_grpc/internal/config"
+	"github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+)
+
+// restore implements "server restore [-tenant id] [-at time] [snapshot]":
+// it verifies a snapshot and swaps it in for the database. Without a
+// snapshot path it picks the newest one taken at or before -at. The
+// server must be stopped.
+func restore(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	tenantID := fs.String("tenant", "", "tenant whose database to restore (with TENANT_DATA_DIR)")
+	at := fs.String("at", "", "restore the newest snapshot taken at or before this RFC 3339 time (default: newest)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dbPath, backupDir := cfg.DBPath, cfg.BackupDir
+	if cfg.TenantDir != "" {
+		if err := tenant.Validate(*tenantID); err != nil {
+			return fmt.Errorf("-tenant: %w", err)
+		}
+		dbPath = filepath.Join(cfg.TenantDir, *tenantID+".db")
+		backupDir = filepath.Join(cfg.BackupDir, *tenantID)
+	} else if *tenantID != "" {
+		return fmt.Errorf("-tenant needs TENANT_DATA_DIR")
+	}
+
+	snapshot := fs.Arg(0)
+	if snapshot == "" {
+		until := time.Now()
+		if *at != "" {
+			var err error
+			if until, err = time.Parse(time.RFC3339, *at); err != nil {
+				return fmt.Errorf("-at: %w", err)
+			}
+		}
+		snap, err := backup.Latest(backupDir, until)
+		if err != nil {
+			return err
+		}
+		snapshot = snap.Path
+	}
+
+	kept, err := backup.Restore(context.Background(), snapshot, dbPath)
+	if err != nil {
+		return err
+	}
+
+	log := logger.L()
+	log.Infof("Restored %s from %s", dbPath, snapshot)
+	if kept != "" {
+		log.Infof("Previous database kept as %s", kept)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Elmar006/todo_grpc/internal/config"
+	"github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+)
+
+// createTenant implements "server create-tenant id...": it provisions the
+// database of each tenant. It may run while the server does.
+func createTenant(cfg *config.Config, ids []string) error {
+	if cfg.TenantDir == "" {
+		return errors.New("create-tenant needs TENANT_DATA_DIR")
+	}
+	if len(ids) == 0 {
+		return errors.New("usage: server create-tenant id...")
+	}
+
+	m, err := tenant.NewManager(cfg.TenantDir, tenant.WithDBOptions(cfg.DB))
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := m.Create(id); err != nil {
+			return fmt.Errorf("tenant %s: %w", id, err)
+		}
+		logger.L().Infof("Created tenant %s", id)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/yaml.v3"
+)
+
+// settings are resolved from, in increasing priority: defaults, the
+// config file, TODOCTL_* environment variables and command-line flags.
+type settings struct {
+	Addr       string `yaml:"addr"`
+	Token      string `yaml:"token"`
+	TLS        bool   `yaml:"tls"`
+	CAFile     string `yaml:"ca_file"`
+	CertFile   string `yaml:"cert_file"`
+	KeyFile    string `yaml:"key_file"`
+	ServerName string `yaml:"server_name"`
+	Output     string `yaml:"output"`
+	TZ         string `yaml:"tz"`
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "todoctl", "config.yaml")
+}
+
+func loadSettings(fs *flag.FlagSet, configPath string) (*settings, error) {
+	s := &settings{Addr: "localhost:50051", Output: "table"}
+
+	if configPath != "" {
+		data, err := os.ReadFile(configPath)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, s); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", configPath, err)
+			}
+		case !errors.Is(err, os.ErrNotExist) || configPath != defaultConfigPath():
+			return nil, err
+		}
+	}
+
+	envString := func(key string, dst *string) {
+		if v, ok := os.LookupEnv(key); ok {
+			*dst = v
+		}
+	}
+	envString("TODOCTL_ADDR", &s.Addr)
+	envString("TODOCTL_TOKEN", &s.Token)
+	envString("TODOCTL_CA_FILE", &s.CAFile)
+	envString("TODOCTL_CERT_FILE", &s.CertFile)
+	envString("TODOCTL_KEY_FILE", &s.KeyFile)
+	envString("TODOCTL_SERVER_NAME", &s.ServerName)
+	envString("TODOCTL_OUTPUT", &s.Output)
+	envString("TODOCTL_TZ", &s.TZ)
+	if v, ok := os.LookupEnv("TODOCTL_TLS"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("TODOCTL_TLS: %w", err)
+		}
+		s.TLS = b
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		v := f.Value.String()
+		switch f.Name {
+		case "addr":
+			s.Addr = v
+		case "token":
+			s.Token = v
+		case "tls":
+			s.TLS = v == "true"
+		case "ca-file":
+			s.CAFile = v
+		case "cert-file":
+			s.CertFile = v
+		case "key-file":
+			s.KeyFile = v
+		case "server-name":
+			s.ServerName = v
+		case "output":
+			s.Output = v
+		case "tz":
+			s.TZ = v
+		}
+	})
+
+	return s, nil
+}
+
+func (s *settings) dialOptions() ([]grpc.DialOption, error) {
+	creds, err := s.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if s.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken{token: s.Token, secure: s.useTLS()}))
+	}
+	return opts, nil
+}
+
+func (s *settings) useTLS() bool {
+	return s.TLS || s.CAFile != "" || s.CertFile != ""
+}
+
+func (s *settings) transportCredentials() (credentials.TransportCredentials, error) {
+	if !s.useTLS() {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{ServerName: s.ServerName, MinVersion: tls.VersionTLS12}
+	if s.CAFile != "" {
+		pem, err := os.ReadFile(s.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", s.CAFile)
+		}
+	}
+	if s.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+type bearerToken struct {
+	token  string
+	secure bool
+}
+
+func (b bearerToken) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearerToken) RequireTransportSecurity() bool {
+	return b.secure
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var envKeys = []string{
+	"TODOCTL_ADDR", "TODOCTL_TOKEN", "TODOCTL_TLS", "TODOCTL_CA_FILE", "TODOCTL_CERT_FILE",
+	"TODOCTL_KEY_FILE", "TODOCTL_SERVER_NAME", "TODOCTL_OUTPUT", "TODOCTL_TZ",
+}
+
+// clearEnv unsets the TODOCTL_* variables for the test and sets env.
+func clearEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, key := range envKeys {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	for key, v := range env {
+		t.Setenv(key, v)
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSettingsPrecedence(t *testing.T) {
+	config := "addr: config:1\ntoken: config-token\ntls: true\noutput: yaml\n"
+
+	tests := []struct {
+		name   string
+		config string
+		env    map[string]string
+		args   []string
+		want   settings
+	}{
+		{
+			name: "defaults",
+			want: settings{Addr: "localhost:50051", Output: "table"},
+		},
+		{
+			name:   "config file over defaults",
+			config: config,
+			want:   settings{Addr: "config:1", Token: "config-token", TLS: true, Output: "yaml"},
+		},
+		{
+			name:   "env over config file",
+			config: config,
+			env:    map[string]string{"TODOCTL_ADDR": "env:2", "TODOCTL_TLS": "false", "TODOCTL_TZ": "Europe/Berlin"},
+			want:   settings{Addr: "env:2", Token: "config-token", Output: "yaml", TZ: "Europe/Berlin"},
+		},
+		{
+			name:   "flag over env",
+			config: config,
+			env:    map[string]string{"TODOCTL_ADDR": "env:2", "TODOCTL_TLS": "false"},
+			args:   []string{"-addr", "flag:3", "-tls", "-output", "json"},
+			want:   settings{Addr: "flag:3", Token: "config-token", TLS: true, Output: "json"},
+		},
+		{
+			name: "flag default does not override",
+			env:  map[string]string{"TODOCTL_OUTPUT": "json"},
+			args: []string{"-token", "flag-token"},
+			want: settings{Addr: "localhost:50051", Token: "flag-token", Output: "json"},
+		},
+		{
+			name: "flag set to its default still overrides",
+			env:  map[string]string{"TODOCTL_ADDR": "env:2", "TODOCTL_TLS": "true"},
+			args: []string{"-addr", "localhost:50051", "-tls=false"},
+			want: settings{Addr: "localhost:50051", Output: "table"},
+		},
+		{
+			name: "every env variable",
+			env: map[string]string{
+				"TODOCTL_ADDR": "env:2", "TODOCTL_TOKEN": "t", "TODOCTL_TLS": "1", "TODOCTL_CA_FILE": "ca.pem",
+				"TODOCTL_CERT_FILE": "cert.pem", "TODOCTL_KEY_FILE": "key.pem", "TODOCTL_SERVER_NAME": "todo",
+				"TODOCTL_OUTPUT": "yaml", "TODOCTL_TZ": "UTC",
+			},
+			want: settings{
+				Addr: "env:2", Token: "t", TLS: true, CAFile: "ca.pem", CertFile: "cert.pem",
+				KeyFile: "key.pem", ServerName: "todo", Output: "yaml", TZ: "UTC",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t, tt.env)
+			global, _, _ := globalFlags()
+			if err := global.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			path := ""
+			if tt.config != "" {
+				path = writeConfig(t, tt.config)
+			}
+
+			got, err := loadSettings(global, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestLoadSettingsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		env  map[string]string
+	}{
+		{name: "missing config file", path: filepath.Join(t.TempDir(), "missing.yaml")},
+		{name: "malformed config file", path: writeConfig(t, "addr: [")},
+		{name: "malformed TODOCTL_TLS", env: map[string]string{"TODOCTL_TLS": "maybe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t, tt.env)
+			global, _, _ := globalFlags()
+			if _, err := loadSettings(global, tt.path); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadSettingsMissingDefaultConfig(t *testing.T) {
+	clearEnv(t, nil)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	global, configPath, _ := globalFlags()
+	if err := global.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadSettings(global, *configPath); err != nil {
+		t.Errorf("expected a missing default config file to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const usage = `todoctl is a command-line client for the TodoService API.
+
+Usage:
+  todoctl [global flags] <command> [flags] [args]
+
+Commands:
+  create   -title T [-description D]   create a task
+  get      ID                          show a task
+  list     [-filter F]                 list tasks
+  update   ID [-title T] [-description D] [-completed=true|false]
+  complete ID                          mark a task as completed
+  delete   ID                          delete a task
+  watch    [-filter F] [-interval D]   print task changes as they happen
+
+Global flags:
+`
+
+type app struct {
+	client  todo.TodoServiceClient
+	out     *printer
+	timeout time.Duration
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if st, ok := status.FromError(err); ok {
+			fmt.Fprintf(os.Stderr, "todoctl: %s: %s\n", st.Code(), st.Message())
+		} else {
+			fmt.Fprintf(os.Stderr, "todoctl: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// globalFlags defines the flags that precede the command. Only flags set
+// on the command line override the config file and environment.
+func globalFlags() (global *flag.FlagSet, configPath *string, timeout *time.Duration) {
+	global = flag.NewFlagSet("todoctl", flag.ContinueOnError)
+	global.Usage = func() {
+		fmt.Fprint(global.Output(), usage)
+		global.PrintDefaults()
+	}
+	configPath = global.String("config", defaultConfigPath(), "config file")
+	global.String("addr", "localhost:50051", "server address (TODOCTL_ADDR)")
+	global.String("token", "", "bearer token (TODOCTL_TOKEN)")
+	global.Bool("tls", false, "connect with TLS (TODOCTL_TLS)")
+	global.String("ca-file", "", "CA certificate to verify the server (TODOCTL_CA_FILE)")
+	global.String("cert-file", "", "client certificate for mTLS (TODOCTL_CERT_FILE)")
+	global.String("key-file", "", "client key for mTLS (TODOCTL_KEY_FILE)")
+	global.String("server-name", "", "override the TLS server name (TODOCTL_SERVER_NAME)")
+	global.String("output", "table", "output format: table, json or yaml (TODOCTL_OUTPUT)")
+	global.String("tz", "", "IANA time zone for table output, local by default (TODOCTL_TZ)")
+	timeout = global.Duration("timeout", 10*time.Second, "per-request timeout")
+	return global, configPath, timeout
+}
+
+func run(args []string) error {
+	global, configPath, timeout := globalFlags()
+	if err := global.Parse(args); err != nil {
+		return err
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		return errors.New("missing command")
+	}
+
+	s, err := loadSettings(global, *configPath)
+	if err != nil {
+		return err
+	}
+	out, err := newPrinter(os.Stdout, s.Output, s.TZ)
+	if err != nil {
+		return err
+	}
+	opts, err := s.dialOptions()
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(s.Addr, opts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	a := &app{client: todo.NewTodoServiceClient(conn), out: out, timeout: *timeout}
+
+	cmd, cmdArgs := global.Arg(0), global.Args()[1:]
+	switch cmd {
+	case "create":
+		return a.create(cmdArgs)
+	case "get":
+		return a.get(cmdArgs)
+	case "list":
+		return a.list(cmdArgs)
+	case "update":
+		return a.update(cmdArgs)
+	case "complete":
+		return a.complete(cmdArgs)
+	case "delete":
+		return a.delete(cmdArgs)
+	case "watch":
+		return a.watch(cmdArgs)
+	}
+	return fmt.Errorf("unknown command %q", cmd)
+}
+
+func (a *app) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), a.timeout)
+}
+
+func (a *app) create(args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	title := fs.String("title", "", "task title")
+	description := fs.String("description", "", "task description")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+
+	task, err := a.client.CreateTask(ctx, &todo.CreateTaskRequest{Title: *title, Description: *description})
+	if err != nil {
+		return err
+	}
+	return a.out.task(task)
+}
+
+func (a *app) get(args []string) error {
+	id, err := parseID("get", args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+
+	task, err := a.client.GetTask(ctx, &todo.GetTaskRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	return a.out.task(task)
+}
+
+func (a *app) list(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	filter := fs.String("filter", "", "only tasks whose title or description contains this text")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+
+	resp, err := a.client.ListTasks(ctx, &todo.ListTasksRequest{Filter: *filter})
+	if err != nil {
+		return err
+	}
+	return a.out.list(resp.GetTasks())
+}
+
+func (a *app) update(args []string) error {
+	if len(args) == 0 {
+		return errors.New("update: missing task ID")
+	}
+	id, err := parseID("update", args[:1])
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	title := fs.String("title", "", "new title")
+	description := fs.String("description", "", "new description")
+	completed := fs.Bool("completed", false, "completion state")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	req := &todo.UpdateTaskRequest{Id: id}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			req.Title = title
+		case "description":
+			req.Description = description
+		case "completed":
+			req.Completed = completed
+		}
+	})
+
+	return a.sendUpdate(req)
+}
+
+func (a *app) complete(args []string) error {
+	id, err := parseID("complete", args)
+	if err != nil {
+		return err
+	}
+
+	done := true
+	return a.sendUpdate(&todo.UpdateTaskRequest{Id: id, Completed: &done})
+}
+
+func (a *app) sendUpdate(req *todo.UpdateTaskRequest) error {
+	ctx, cancel := a.context()
+	defer cancel()
+
+	task, err := a.client.UpdateTask(ctx, req)
+	if err != nil {
+		return err
+	}
+	return a.out.task(task)
+}
+
+func (a *app) delete(args []string) error {
+	id, err := parseID("delete", args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := a.context()
+	defer cancel()
+
+	if _, err := a.client.DeleteTask(ctx, &todo.DeleteTaskRequest{Id: id}); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "task %d deleted\n", id)
+	return nil
+}
+
+func parseID(cmd string, args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%s: expected exactly one task ID", cmd)
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid task ID %q", cmd, args[0])
+	}
+	return id, nil
+}
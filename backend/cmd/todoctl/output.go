@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+var jsonOptions = protojson.MarshalOptions{
+	Multiline:       true,
+	UseProtoNames:   true,
+	EmitUnpopulated: true,
+}
+
+type printer struct {
+	w      io.Writer
+	format string
+	loc    *time.Location
+}
+
+// newPrinter renders table timestamps in the IANA time zone tz, or in the
+// local one if tz is empty. JSON and YAML keep UTC.
+func newPrinter(w io.Writer, format, tz string) (*printer, error) {
+	loc := time.Local
+	if tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", tz)
+		}
+	}
+	switch format {
+	case "table", "json", "yaml":
+		return &printer{w: w, format: format, loc: loc}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q (want table, json or yaml)", format)
+}
+
+func (p *printer) task(t *todo.Task) error {
+	if p.format != "table" {
+		return p.message(t)
+	}
+	return p.table([]*todo.Task{t})
+}
+
+func (p *printer) list(tasks []*todo.Task) error {
+	if p.format != "table" {
+		return p.message(&todo.ListTasksResponse{Tasks: tasks})
+	}
+	return p.table(tasks)
+}
+
+func (p *printer) table(tasks []*todo.Task) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tDONE\tCREATED\tUPDATED")
+	for _, t := range tasks {
+		done := ""
+		if t.GetCompleted() {
+			done = "x"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", t.GetId(), t.GetTitle(), done, p.time(t.GetCreatedAt()), p.time(t.GetUpdatedAt()))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) time(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().In(p.loc).Format(time.RFC3339)
+}
+
+func (p *printer) message(msg proto.Message) error {
+	b, err := jsonOptions.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if p.format != "yaml" {
+		_, err = fmt.Fprintln(p.w, string(b))
+		return err
+	}
+
+	// JSON is valid YAML; decoding into a node keeps the field order.
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	enc := yaml.NewEncoder(p.w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle drops the flow style inherited from the JSON input and
+// unquotes strings that do not need quoting.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" {
+		if _, err := strconv.ParseFloat(n.Value, 64); err == nil {
+			n.Style = yaml.DoubleQuotedStyle
+		}
+	}
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// watch polls ListTasks and prints every task that was added, changed or
+// removed since the previous poll.
+func (a *app) watch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	filter := fs.String("filter", "", "only watch tasks matching this text")
+	interval := fs.Duration("interval", 2*time.Second, "poll interval")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var seen map[int64]*todo.Task
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		current, err := a.snapshot(ctx, *filter)
+		if err != nil {
+			return err
+		}
+
+		if seen == nil {
+			if err := a.out.list(sortedTasks(current)); err != nil {
+				return err
+			}
+		} else if err := a.printChanges(seen, current); err != nil {
+			return err
+		}
+		seen = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *app) snapshot(ctx context.Context, filter string) (map[int64]*todo.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	resp, err := a.client.ListTasks(ctx, &todo.ListTasksRequest{Filter: filter})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make(map[int64]*todo.Task, len(resp.GetTasks()))
+	for _, t := range resp.GetTasks() {
+		tasks[t.GetId()] = t
+	}
+	return tasks, nil
+}
+
+func (a *app) printChanges(prev, current map[int64]*todo.Task) error {
+	for _, t := range sortedTasks(current) {
+		old, ok := prev[t.GetId()]
+		switch {
+		case !ok:
+			fmt.Fprintf(a.out.w, "# created %d\n", t.GetId())
+		case !proto.Equal(old, t):
+			fmt.Fprintf(a.out.w, "# updated %d\n", t.GetId())
+		default:
+			continue
+		}
+		if err := a.out.task(t); err != nil {
+			return err
+		}
+	}
+	for _, t := range sortedTasks(prev) {
+		if _, ok := current[t.GetId()]; !ok {
+			fmt.Fprintf(a.out.w, "# deleted %d\n", t.GetId())
+		}
+	}
+	return nil
+}
+
+func sortedTasks(m map[int64]*todo.Task) []*todo.Task {
+	tasks := make([]*todo.Task, 0, len(m))
+	for _, t := range m {
+		tasks = append(tasks, t)
+	}
+	slices.SortFunc(tasks, func(x, y *todo.Task) int {
+		return cmp.Compare(x.GetId(), y.GetId())
+	})
+	return tasks
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidToken = errors.New("invalid auth token")
+
+// CertSubjectKey is the metadata key under which the HTTP gateway passes
+// the subject of a verified client certificate on to the in-process gRPC
+// server. It is only trusted on in-process connections.
+const CertSubjectKey = "x-client-cert-subject-bin"
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the authenticated caller, if any.
+func PrincipalFrom(ctx context.Context) (string, bool) {
+	p, ok := ctx.Value(principalKey{}).(string)
+	return p, ok && p != ""
+}
+
+// TokenAuthenticator maps static bearer tokens to principal names.
+type TokenAuthenticator struct {
+	tokens map[string]string
+}
+
+func NewTokenAuthenticator(tokens map[string]string) *TokenAuthenticator {
+	return &TokenAuthenticator{tokens: tokens}
+}
+
+// Authenticate resolves an "authorization" header value such as
+// "Bearer <token>" to a principal.
+func (a *TokenAuthenticator) Authenticate(header string) (string, error) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+
+	principal, ok := a.tokens[strings.TrimSpace(token)]
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	return principal, nil
+}
+
+// CertSubject returns the common name of the verified client
+// certificate, or its full subject if it has none.
+func CertSubject(state *tls.ConnectionState) (string, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	subject := state.VerifiedChains[0][0].Subject
+	if subject.CommonName != "" {
+		return subject.CommonName, true
+	}
+	return subject.String(), true
+}
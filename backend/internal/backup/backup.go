@@ -0,0 +1,280 @@
+// Package backup keeps rotated, checksummed snapshots of a SQLite
+// database and restores them.
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	prefix     = "todo-"
+	ext        = ".db"
+	timeFormat = "20060102T150405.000Z"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+	ErrCorrupt          = errors.New("backup failed integrity check")
+	ErrNoBackup         = errors.New("no backup found")
+)
+
+// Snapshot describes one backup file.
+type Snapshot struct {
+	Path      string
+	Size      int64
+	SHA256    string
+	CreatedAt time.Time
+}
+
+// Create writes a snapshot into dir by calling write with the path of a
+// file that must not exist yet, such as with VACUUM INTO, then records
+// its checksum and deletes all but the newest keep snapshots. keep <= 0
+// keeps every snapshot.
+func Create(ctx context.Context, dir string, keep int, write func(ctx context.Context, path string) error) (*Snapshot, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	name := prefix + now.Format(timeFormat) + ext
+	tmp := filepath.Join(dir, "."+name+".tmp")
+	os.Remove(tmp)
+
+	if err := write(ctx, tmp); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+
+	sum, size, err := checksum(tmp)
+	if err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path+".sha256", []byte(sum+"  "+name+"\n"), 0o644); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		os.Remove(path + ".sha256")
+		return nil, err
+	}
+
+	if err := Rotate(dir, keep); err != nil {
+		return nil, err
+	}
+	return &Snapshot{Path: path, Size: size, SHA256: sum, CreatedAt: now}, nil
+}
+
+// List returns the snapshots in dir, oldest first.
+func List(dir string) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*Snapshot
+	for _, e := range entries {
+		stamp, ok := strings.CutPrefix(e.Name(), prefix)
+		if !ok {
+			continue
+		}
+		if stamp, ok = strings.CutSuffix(stamp, ext); !ok {
+			continue
+		}
+		createdAt, err := time.Parse(timeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &Snapshot{
+			Path:      filepath.Join(dir, e.Name()),
+			Size:      info.Size(),
+			CreatedAt: createdAt,
+		})
+	}
+
+	slices.SortFunc(snapshots, func(a, b *Snapshot) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return snapshots, nil
+}
+
+// Latest returns the newest snapshot in dir taken at or before at.
+func Latest(dir string, at time.Time) (*Snapshot, error) {
+	snapshots, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].CreatedAt.After(at) {
+			return snapshots[i], nil
+		}
+	}
+	return nil, ErrNoBackup
+}
+
+// Rotate deletes all but the newest keep snapshots in dir.
+func Rotate(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	snapshots, err := List(dir)
+	if err != nil {
+		return err
+	}
+
+	for len(snapshots) > keep {
+		path := snapshots[0].Path
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		os.Remove(path + ".sha256")
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// Verify checks a snapshot against its recorded checksum and runs
+// SQLite's integrity check on it.
+func Verify(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path + ".sha256")
+	if err != nil {
+		return fmt.Errorf("read checksum: %w", err)
+	}
+	want, _, _ := strings.Cut(strings.TrimSpace(string(data)), " ")
+
+	got, _, err := checksum(path)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return ErrChecksumMismatch
+	}
+	return checkIntegrity(ctx, path)
+}
+
+func checkIntegrity(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `PRAGMA integrity_check`)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return err
+		}
+		if msg != "ok" {
+			problems = append(problems, msg)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrCorrupt, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Restore replaces the database at dbPath with a verified snapshot. The
+// replaced database is kept next to it with a ".pre-restore-<time>"
+// suffix. The server must not be running.
+func Restore(ctx context.Context, snapshot, dbPath string) (string, error) {
+	if err := Verify(ctx, snapshot); err != nil {
+		return "", err
+	}
+
+	tmp := dbPath + ".restore"
+	if err := copyFile(snapshot, tmp); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := checkIntegrity(ctx, tmp); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+
+	var kept string
+	if _, err := os.Stat(dbPath); err == nil {
+		kept = dbPath + ".pre-restore-" + time.Now().UTC().Format(timeFormat)
+		// The journal files belong to the replaced database and must not
+		// be applied to the restored one.
+		for _, suffix := range []string{"", "-wal", "-shm", "-journal"} {
+			if err := os.Rename(dbPath+suffix, kept+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+				os.Remove(tmp)
+				return "", err
+			}
+		}
+	}
+
+	if err := os.Rename(tmp, dbPath); err != nil {
+		return "", err
+	}
+	return kept, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func checksum(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
@@ -0,0 +1,129 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func count(t *testing.T, path string) int {
+	t.Helper()
+	var n int
+	if err := openDB(t, path).QueryRow(`SELECT COUNT(*) FROM item`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func vacuumInto(db *sql.DB) func(ctx context.Context, path string) error {
+	return func(ctx context.Context, path string) error {
+		_, err := db.ExecContext(ctx, `VACUUM INTO ?`, path)
+		return err
+	}
+}
+
+func TestCreateRotateRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "todo.db")
+	backupDir := filepath.Join(dir, "backups")
+
+	db := openDB(t, dbPath)
+	if _, err := db.Exec(`CREATE TABLE item (id INTEGER PRIMARY KEY)`); err != nil {
+		t.Fatal(err)
+	}
+
+	var snaps []*Snapshot
+	for i := 0; i < 3; i++ {
+		if _, err := db.Exec(`INSERT INTO item DEFAULT VALUES`); err != nil {
+			t.Fatal(err)
+		}
+		snap, err := Create(ctx, backupDir, 2, vacuumInto(db))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Verify(ctx, snap.Path); err != nil {
+			t.Fatalf("snapshot %d: %v", i, err)
+		}
+		snaps = append(snaps, snap)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	listed, err := List(backupDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 2 || listed[0].Path != snaps[1].Path || listed[1].Path != snaps[2].Path {
+		t.Fatalf("expected the two newest snapshots to be kept, got %v", listed)
+	}
+	if _, err := os.Stat(snaps[0].Path + ".sha256"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected rotated checksum file to be deleted, got %v", err)
+	}
+
+	// Point in time: the newest snapshot not after the second one.
+	snap, err := Latest(backupDir, snaps[1].CreatedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Path != snaps[1].Path {
+		t.Fatalf("latest = %s, want %s", snap.Path, snaps[1].Path)
+	}
+	if _, err := Latest(backupDir, snaps[0].CreatedAt); !errors.Is(err, ErrNoBackup) {
+		t.Errorf("expected ErrNoBackup before the oldest kept snapshot, got %v", err)
+	}
+
+	db.Close()
+	kept, err := Restore(ctx, snap.Path, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, dbPath); n != 2 {
+		t.Errorf("restored database has %d items, want 2", n)
+	}
+	if n := count(t, kept); n != 3 {
+		t.Errorf("kept database has %d items, want 3", n)
+	}
+}
+
+func TestRestoreRejectsDamagedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "todo.db")
+
+	db := openDB(t, dbPath)
+	if _, err := db.Exec(`CREATE TABLE item (id INTEGER PRIMARY KEY); INSERT INTO item DEFAULT VALUES`); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := Create(ctx, filepath.Join(dir, "backups"), 0, vacuumInto(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.OpenFile(snap.Path, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteAt([]byte("garbage"), 100)
+	f.Close()
+
+	if _, err := Restore(ctx, snap.Path, dbPath); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+	if n := count(t, dbPath); n != 1 {
+		t.Errorf("expected the database to be left alone, got %d items", n)
+	}
+}
@@ -0,0 +1,21 @@
+// Package blob stores attachment contents outside of the database.
+package blob
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// checkKey rejects keys that could escape the store's namespace. Keys are
+// generated by the service, so anything but a plain name is a bug.
+func checkKey(key string) error {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return ErrInvalidKey
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package calendar
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	"github.com/Elmar006/todo_grpc/internal/taskio"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+)
+
+// PathPrefix is where the HTTP server mounts Handler.
+const PathPrefix = "/calendar/"
+
+// Path is the secret feed path for token. Feeds of a tenant live under
+// the tenant id, since calendar apps cannot send the tenant header.
+func Path(tenantID, token string) string {
+	if tenantID != "" {
+		return PathPrefix + tenantID + "/" + token + ".ics"
+	}
+	return PathPrefix + token + ".ics"
+}
+
+// Handler serves read-only iCalendar feeds at Path(token). Calendar apps
+// poll the feed, so responses carry an ETag and honour If-None-Match.
+type Handler struct {
+	taskService *service.TaskService
+	tenants     *tenant.Manager
+	mux         *http.ServeMux
+}
+
+type Option func(*Handler)
+
+// WithTenants serves feeds from per-tenant databases.
+func WithTenants(m *tenant.Manager) Option {
+	return func(h *Handler) { h.tenants = m }
+}
+
+func NewHandler(taskService *service.TaskService, opts ...Option) *Handler {
+	h := &Handler{taskService: taskService, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.tenants != nil {
+		h.mux.HandleFunc("GET "+PathPrefix+"{tenant}/{file}", h.tenantFeed)
+	} else {
+		h.mux.HandleFunc("GET "+PathPrefix+"{file}", h.feed)
+	}
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) tenantFeed(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("tenant")
+	if tenant.Validate(id) != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	db, release, err := h.tenants.Acquire(r.Context(), id)
+	if errors.Is(err, tenant.ErrUnknownTenant) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.L().Errorf("calendar feed of tenant %s failed: %v", id, err)
+		http.Error(w, "tenant unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	defer release()
+
+	h.feed(w, r.WithContext(tenant.WithDB(r.Context(), id, db)))
+}
+
+func (h *Handler) feed(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok || token == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	tasks, err := h.taskService.CalendarFeed(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, service.ErrFeedNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		log.L().Errorf("calendar feed failed: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := render(tasks)
+	if err != nil {
+		log.L().Errorf("calendar feed encode failed: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Write(body)
+}
+
+func render(tasks []*model.Model) ([]byte, error) {
+	var buf bytes.Buffer
+	enc, err := taskio.NewEncoder(taskio.ICalendar, &buf)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if err := enc.Encode(task); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
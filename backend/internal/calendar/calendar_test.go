@@ -0,0 +1,156 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+)
+
+func newTaskService(t *testing.T) *service.TaskService {
+	t.Helper()
+	pool, err := db.Open(filepath.Join(t.TempDir(), "todo.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	repo := &repository.RepositoryDB{Pool: pool}
+	return service.NewTaskService(repo, service.WithFeedRepository(repo))
+}
+
+// feedToken creates a task for alice and returns her feed token.
+func feedToken(t *testing.T, ctx context.Context, tasks *service.TaskService) string {
+	t.Helper()
+	ctx = auth.WithPrincipal(ctx, "alice")
+	if _, err := tasks.CreateTask(ctx, "Buy milk", ""); err != nil {
+		t.Fatal(err)
+	}
+	token, err := tasks.CalendarFeedToken(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func get(h http.Handler, path, etag string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestFeed(t *testing.T) {
+	tasks := newTaskService(t)
+	token := feedToken(t, context.Background(), tasks)
+	h := NewHandler(tasks)
+
+	rec := get(h, Path("", token), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/calendar; charset=utf-8" {
+		t.Errorf("expected an iCalendar content type, got %q", got)
+	}
+	if !strings.Contains(rec.Body.String(), "BEGIN:VCALENDAR") || !strings.Contains(rec.Body.String(), "SUMMARY:Buy milk") {
+		t.Errorf("expected the task in the feed, got %s", rec.Body)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		etag     string
+		wantCode int
+	}{
+		{"unchanged", Path("", token), etag, http.StatusNotModified},
+		{"stale etag", Path("", token), `"stale"`, http.StatusOK},
+		{"unknown token", Path("", "not-a-token"), "", http.StatusNotFound},
+		{"missing extension", PathPrefix + token, "", http.StatusNotFound},
+		{"empty token", PathPrefix + ".ics", "", http.StatusNotFound},
+		{"wrong method", "", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rec *httptest.ResponseRecorder
+			if tt.path == "" {
+				rec = httptest.NewRecorder()
+				h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path("", token), nil))
+			} else {
+				rec = get(h, tt.path, tt.etag)
+			}
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body)
+			}
+			if tt.wantCode == http.StatusNotModified && rec.Body.Len() > 0 {
+				t.Errorf("expected no body, got %s", rec.Body)
+			}
+		})
+	}
+
+	// A change to the tasks changes the ETag.
+	if _, err := tasks.CreateTask(auth.WithPrincipal(context.Background(), "alice"), "Buy bread", ""); err != nil {
+		t.Fatal(err)
+	}
+	if rec := get(h, Path("", token), etag); rec.Code != http.StatusOK {
+		t.Errorf("expected 200 after a change, got %d", rec.Code)
+	}
+}
+
+func TestTenantFeed(t *testing.T) {
+	m, err := tenant.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(m.Close)
+	for _, id := range []string{"acme", "globex"} {
+		if err := m.Create(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The repository works on whichever tenant database is in the context.
+	repo := &repository.RepositoryDB{}
+	tasks := service.NewTaskService(repo, service.WithFeedRepository(repo))
+
+	pool, release, err := m.Acquire(context.Background(), "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := feedToken(t, tenant.WithDB(context.Background(), "acme", pool), tasks)
+	release()
+
+	h := NewHandler(tasks, WithTenants(m))
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+	}{
+		{"own tenant", Path("acme", token), http.StatusOK},
+		{"other tenant", Path("globex", token), http.StatusNotFound},
+		{"unknown tenant", Path("initech", token), http.StatusNotFound},
+		{"invalid tenant", Path("ACME!", token), http.StatusNotFound},
+		{"no tenant", Path("", token), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(h, tt.path, "")
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body)
+			}
+		})
+	}
+}
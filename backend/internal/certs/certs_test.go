@@ -0,0 +1,170 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type issued struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func issue(t *testing.T, cn string, parent *issued, isCA bool) *issued {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &issued{cert: cert, key: key, der: der}
+}
+
+func (i *issued) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalECPrivateKey(i.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: i.der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if keyFile != "" {
+		if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func (i *issued) keyPair() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{i.der}, PrivateKey: i.key}
+}
+
+// handshake connects with the client certificate and returns the server
+// certificate's common name.
+func handshake(t *testing.T, cfg *tls.Config, ca *issued, client *issued) (string, error) {
+	t.Helper()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.(*tls.Conn).Handshake()
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientCfg := &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+	if client != nil {
+		clientCfg.Certificates = []tls.Certificate{client.keyPair()}
+	}
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), clientCfg)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	// TLS 1.3 reports client certificate failures on the first read.
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if _, err := conn.Read(make([]byte, 1)); err != nil && !errors.Is(err, io.EOF) {
+		if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+			return "", err
+		}
+	}
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+}
+
+func TestReloaderMutualTLSAndReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	ca := issue(t, "test-ca", nil, true)
+	ca.write(t, caFile, "")
+	issue(t, "server-v1", ca, false).write(t, certFile, keyFile)
+
+	r, err := NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := r.TLSConfig(tls.VersionTLS12)
+
+	client := issue(t, "alice", ca, false)
+	if cn, err := handshake(t, cfg, ca, client); err != nil || cn != "server-v1" {
+		t.Fatalf("expected server-v1 handshake, got %q, %v", cn, err)
+	}
+	if _, err := handshake(t, cfg, ca, nil); err == nil {
+		t.Error("expected handshake without client certificate to fail")
+	}
+
+	issue(t, "server-v2", ca, false).write(t, certFile, keyFile)
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(certFile, future, future)
+
+	changed, err := r.changed()
+	if err != nil || !changed {
+		t.Fatalf("expected change to be detected, got %t, %v", changed, err)
+	}
+	if err := r.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if cn, err := handshake(t, cfg, ca, client); err != nil || cn != "server-v2" {
+		t.Fatalf("expected server-v2 after reload, got %q, %v", cn, err)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	if v, err := ParseVersion(""); err != nil || v != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2 default, got %x, %v", v, err)
+	}
+	if _, err := ParseVersion("2.0"); err == nil {
+		t.Error("expected error for unknown version")
+	}
+}
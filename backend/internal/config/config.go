@@ -1,24 +1,111 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/certs"
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/ratelimit"
 )
 
 type Config struct {
 	GRPCPort int
+	HTTPPort int
 	DBPath   string
+	DB       db.Options
+
+	// TenantDir switches to one database per tenant, kept as
+	// <TenantDir>/<tenant>.db instead of DBPath.
+	TenantDir         string
+	TenantUsers       map[string]string
+	TenantMaxOpen     int
+	TenantIdleTimeout time.Duration
+	// TenantWakeInterval is how often closed tenant databases are opened
+	// to run background work that came due. Zero disables it.
+	TenantWakeInterval time.Duration
+
+	// BackupDir holds database snapshots, BackupKeep of them per database.
+	// A zero BackupInterval disables scheduled backups.
+	BackupDir      string
+	BackupInterval time.Duration
+	BackupKeep     int
+
+	// IdempotencyTTL is how long idempotency keys and their responses are
+	// kept; zero ignores idempotency keys.
+	IdempotencyTTL time.Duration
+
+	TitleMaxLength       int
+	DescriptionMaxLength int
+	FilterMaxLength      int
+	CommentMaxLength     int
+
+	AuthTokens map[string]string
+
+	RateLimit        ratelimit.Limit
+	MethodRateLimits map[string]ratelimit.Limit
+	TaskQuota        int
+
+	TLSCertFile       string
+	TLSKeyFile        string
+	TLSClientCAFile   string
+	TLSMinVersion     uint16
+	TLSReloadInterval time.Duration
+
+	// KeepaliveMinTime is the shortest interval at which clients may send
+	// keepalive pings; clients pinging more often are disconnected.
+	KeepaliveMinTime time.Duration
+
+	CORSAllowedOrigins []string
+
+	// AdminUsers are the principals allowed to list changes across all
+	// tasks.
+	AdminUsers []string
+
+	// RBACPolicyFile replaces the built-in role policy when set.
+	RBACPolicyFile string
+
+	WebhookMaxAttempts int
+	WebhookBackoffBase time.Duration
+	WebhookBackoffMax  time.Duration
+	WebhookTimeout     time.Duration
+
+	// AttachmentStore selects where attachment contents are kept: "file"
+	// (AttachmentDir) or "s3".
+	AttachmentStore        string
+	AttachmentDir          string
+	AttachmentMaxSize      int64
+	AttachmentContentTypes []string
+	S3Endpoint             string
+	S3Region               string
+	S3Bucket               string
+	S3Prefix               string
+	S3AccessKeyID          string
+	S3SecretAccessKey      string
+
+	// EventPublisher selects where task events go besides webhooks:
+	// "" (nowhere), "stdout", "file", "nats" or "kafka".
+	EventPublisher    string
+	EventFile         string
+	NATSURL           string
+	NATSSubjectPrefix string
+	NATSJetStream     bool
+	KafkaBrokers      []string
+	KafkaTopic        string
 }
 
 func Load() (*Config, error) {
-	portStr := os.Getenv("GRPC_PORT")
-	port := 50051
-	if portStr != "" {
-		var err error
-		port, err = strconv.Atoi(portStr)
-		if err != nil {
-			return nil, err
-		}
+	port, err := getInt("GRPC_PORT", 50051)
+	if err != nil {
+		return nil, err
+	}
+
+	httpPort, err := getInt("HTTP_PORT", 8080)
+	if err != nil {
+		return nil, err
 	}
 
 	dbPath := os.Getenv("DB_PATH")
@@ -26,8 +113,307 @@ func Load() (*Config, error) {
 		dbPath = "./data/todo.db"
 	}
 
+	dbOptions, err := getDBOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	tenantUsers, err := getMap("TENANT_USERS")
+	if err != nil {
+		return nil, err
+	}
+	tenantMaxOpen, err := getInt("TENANT_MAX_OPEN", 64)
+	if err != nil {
+		return nil, err
+	}
+	tenantIdle, err := getDuration("TENANT_IDLE_TIMEOUT", 10*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	tenantWake, err := getDuration("TENANT_WAKE_INTERVAL", 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	backupInterval, err := getDuration("BACKUP_INTERVAL", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	backupKeep, err := getInt("BACKUP_KEEP", 7)
+	if err != nil {
+		return nil, err
+	}
+
+	idempotencyTTL, err := getDuration("IDEMPOTENCY_TTL", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	titleMax, err := getInt("TITLE_MAX_LENGTH", 200)
+	if err != nil {
+		return nil, err
+	}
+	descriptionMax, err := getInt("DESCRIPTION_MAX_LENGTH", 10000)
+	if err != nil {
+		return nil, err
+	}
+	filterMax, err := getInt("FILTER_MAX_LENGTH", 200)
+	if err != nil {
+		return nil, err
+	}
+
+	commentMax, err := getInt("COMMENT_MAX_LENGTH", 5000)
+	if err != nil {
+		return nil, err
+	}
+
+	authTokens, err := getMap("AUTH_TOKENS")
+	if err != nil {
+		return nil, err
+	}
+
+	rps, err := getFloat("RATE_LIMIT_RPS", 10)
+	if err != nil {
+		return nil, err
+	}
+	burst, err := getInt("RATE_LIMIT_BURST", 20)
+	if err != nil {
+		return nil, err
+	}
+	methodLimits, err := getRateLimits("RATE_LIMIT_METHODS")
+	if err != nil {
+		return nil, err
+	}
+	taskQuota, err := getInt("TASK_QUOTA", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsMinVersion, err := certs.ParseVersion(os.Getenv("TLS_MIN_VERSION"))
+	if err != nil {
+		return nil, err
+	}
+	tlsReload, err := getDuration("TLS_RELOAD_INTERVAL", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	keepaliveMinTime, err := getDuration("KEEPALIVE_MIN_TIME", 20*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookAttempts, err := getInt("WEBHOOK_MAX_ATTEMPTS", 8)
+	if err != nil {
+		return nil, err
+	}
+	webhookBackoffBase, err := getDuration("WEBHOOK_BACKOFF_BASE", 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	webhookBackoffMax, err := getDuration("WEBHOOK_BACKOFF_MAX", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	webhookTimeout, err := getDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentStore := getString("ATTACHMENT_STORE", "file")
+	switch attachmentStore {
+	case "file", "s3":
+	default:
+		return nil, fmt.Errorf("ATTACHMENT_STORE: unknown store %q", attachmentStore)
+	}
+	attachmentMaxSize, err := getInt("ATTACHMENT_MAX_SIZE", 10<<20)
+	if err != nil {
+		return nil, err
+	}
+
+	eventPublisher := os.Getenv("EVENT_PUBLISHER")
+	switch eventPublisher {
+	case "", "stdout", "file", "nats", "kafka":
+	default:
+		return nil, fmt.Errorf("EVENT_PUBLISHER: unknown publisher %q", eventPublisher)
+	}
+	natsJetStream, err := getBool("NATS_JETSTREAM", false)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
-		GRPCPort: port,
-		DBPath:   dbPath,
+		GRPCPort:               port,
+		HTTPPort:               httpPort,
+		DBPath:                 dbPath,
+		DB:                     dbOptions,
+		TenantDir:              os.Getenv("TENANT_DATA_DIR"),
+		TenantUsers:            tenantUsers,
+		TenantMaxOpen:          tenantMaxOpen,
+		TenantIdleTimeout:      tenantIdle,
+		TenantWakeInterval:     tenantWake,
+		BackupDir:              getString("BACKUP_DIR", "./data/backups"),
+		BackupInterval:         backupInterval,
+		BackupKeep:             backupKeep,
+		IdempotencyTTL:         idempotencyTTL,
+		TitleMaxLength:         titleMax,
+		DescriptionMaxLength:   descriptionMax,
+		FilterMaxLength:        filterMax,
+		CommentMaxLength:       commentMax,
+		AuthTokens:             authTokens,
+		RateLimit:              ratelimit.Limit{RPS: rps, Burst: burst},
+		MethodRateLimits:       methodLimits,
+		TaskQuota:              taskQuota,
+		TLSCertFile:            os.Getenv("TLS_CERT_FILE"),
+		TLSKeyFile:             os.Getenv("TLS_KEY_FILE"),
+		TLSClientCAFile:        os.Getenv("TLS_CLIENT_CA_FILE"),
+		TLSMinVersion:          tlsMinVersion,
+		TLSReloadInterval:      tlsReload,
+		KeepaliveMinTime:       keepaliveMinTime,
+		CORSAllowedOrigins:     getList("CORS_ALLOWED_ORIGINS"),
+		AdminUsers:             getList("ADMIN_USERS"),
+		RBACPolicyFile:         os.Getenv("RBAC_POLICY_FILE"),
+		WebhookMaxAttempts:     webhookAttempts,
+		WebhookBackoffBase:     webhookBackoffBase,
+		WebhookBackoffMax:      webhookBackoffMax,
+		WebhookTimeout:         webhookTimeout,
+		AttachmentStore:        attachmentStore,
+		AttachmentDir:          getString("ATTACHMENT_DIR", "./data/attachments"),
+		AttachmentMaxSize:      int64(attachmentMaxSize),
+		AttachmentContentTypes: getList("ATTACHMENT_CONTENT_TYPES"),
+		S3Endpoint:             os.Getenv("S3_ENDPOINT"),
+		S3Region:               getString("S3_REGION", "us-east-1"),
+		S3Bucket:               os.Getenv("S3_BUCKET"),
+		S3Prefix:               os.Getenv("S3_PREFIX"),
+		S3AccessKeyID:          os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey:      os.Getenv("S3_SECRET_ACCESS_KEY"),
+		EventPublisher:         eventPublisher,
+		EventFile:              getString("EVENT_FILE", "./data/events.jsonl"),
+		NATSURL:                getString("NATS_URL", "nats://localhost:4222"),
+		NATSSubjectPrefix:      getString("NATS_SUBJECT_PREFIX", "todo.events"),
+		NATSJetStream:          natsJetStream,
+		KafkaBrokers:           getList("KAFKA_BROKERS"),
+		KafkaTopic:             getString("KAFKA_TOPIC", "todo.events"),
 	}, nil
 }
+
+func getString(key, def string) string {
+	if s := os.Getenv(key); s != "" {
+		return s
+	}
+	return def
+}
+
+func getBool(key string, def bool) (bool, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+func getInt(key string, def int) (int, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func getFloat(key string, def float64) (float64, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func getDuration(key string, def time.Duration) (time.Duration, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// getDBOptions reads the SQLite tuning variables over db.DefaultOptions.
+func getDBOptions() (db.Options, error) {
+	opts := db.DefaultOptions()
+	opts.JournalMode = strings.ToLower(getString("DB_JOURNAL_MODE", opts.JournalMode))
+	opts.Synchronous = strings.ToLower(getString("DB_SYNCHRONOUS", opts.Synchronous))
+
+	var err error
+	if opts.BusyTimeout, err = getDuration("DB_BUSY_TIMEOUT", opts.BusyTimeout); err != nil {
+		return opts, err
+	}
+	if opts.ForeignKeys, err = getBool("DB_FOREIGN_KEYS", opts.ForeignKeys); err != nil {
+		return opts, err
+	}
+	if opts.MaxReaders, err = getInt("DB_MAX_READERS", opts.MaxReaders); err != nil {
+		return opts, err
+	}
+	if opts.ConnMaxIdleTime, err = getDuration("DB_CONN_MAX_IDLE_TIME", opts.ConnMaxIdleTime); err != nil {
+		return opts, err
+	}
+	if opts.StatementCacheSize, err = getInt("DB_STATEMENT_CACHE_SIZE", opts.StatementCacheSize); err != nil {
+		return opts, err
+	}
+	if err := opts.Validate(); err != nil {
+		return opts, fmt.Errorf("DB options: %w", err)
+	}
+	return opts, nil
+}
+
+// getList parses comma-separated values, dropping empty entries.
+func getList(key string) []string {
+	var list []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// getMap parses "key=value,key=value" lists.
+func getMap(key string) (map[string]string, error) {
+	m := make(map[string]string)
+	s := os.Getenv(key)
+	if s == "" {
+		return m, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%s: malformed entry %q", key, pair)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// getRateLimits parses "Method=rps:burst" lists, e.g. "CreateTask=1:5".
+func getRateLimits(key string) (map[string]ratelimit.Limit, error) {
+	raw, err := getMap(key)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[string]ratelimit.Limit, len(raw))
+	for method, value := range raw {
+		rpsStr, burstStr, _ := strings.Cut(value, ":")
+		rps, err := strconv.ParseFloat(rpsStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", key, method, err)
+		}
+		burst := 1
+		if burstStr != "" {
+			if burst, err = strconv.Atoi(burstStr); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", key, method, err)
+			}
+		}
+		limits[method] = ratelimit.Limit{RPS: rps, Burst: burst}
+	}
+	return limits, nil
+}
@@ -0,0 +1,51 @@
+package cors
+
+import (
+	"net/http"
+	"strings"
+)
+
+var (
+	allowedMethods = "GET, POST, PATCH, DELETE, OPTIONS"
+	allowedHeaders = strings.Join([]string{
+		"Authorization", "Content-Type",
+		"Connect-Protocol-Version", "Connect-Timeout-Ms",
+		"Grpc-Timeout", "X-Grpc-Web", "X-User-Agent", "Idempotency-Key",
+		"X-Timezone", "X-Tenant-Id",
+	}, ", ")
+	exposedHeaders = strings.Join([]string{
+		"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin", "Idempotent-Replayed",
+	}, ", ")
+)
+
+// Handler adds CORS headers for the allowed origins and answers preflight
+// requests. An origin of "*" allows any origin.
+func Handler(allowedOrigins []string, next http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		allowed[o] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !(allowed["*"] || allowed[origin]) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Set("Access-Control-Expose-Headers", exposedHeaders)
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.Set("Access-Control-Allow-Methods", allowedMethods)
+			h.Set("Access-Control-Allow-Headers", allowedHeaders)
+			h.Set("Access-Control-Max-Age", "7200")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,55 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPreflight(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	h := Handler([]string{"https://app.example.com"}, next)
+
+	tests := []struct {
+		name        string
+		origin      string
+		wantStatus  int
+		wantAllowed bool
+	}{
+		{name: "allowed origin", origin: "https://app.example.com", wantStatus: http.StatusNoContent, wantAllowed: true},
+		{name: "other origin", origin: "https://evil.example.com", wantStatus: http.StatusTeapot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/v1/tasks", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !tt.wantAllowed {
+				if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+					t.Errorf("expected no CORS headers, got Access-Control-Allow-Origin %q", got)
+				}
+				return
+			}
+
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.origin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.origin)
+			}
+			allowed := strings.Split(rec.Header().Get("Access-Control-Allow-Headers"), ", ")
+			for _, header := range []string{"Authorization", "Idempotency-Key", "X-Timezone", "X-Tenant-Id"} {
+				if !slices.Contains(allowed, header) {
+					t.Errorf("expected %s in Access-Control-Allow-Headers %v", header, allowed)
+				}
+			}
+		})
+	}
+}
@@ -1,46 +1,256 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
 
-var DB *sql.DB
+var DB *Pool
 
-func Init(dbFile string) error {
+// Options tune how SQLite databases are opened.
+type Options struct {
+	// JournalMode is one of delete, truncate, persist, memory, wal or off.
+	JournalMode string
+	// Synchronous is one of off, normal, full or extra.
+	Synchronous string
+	BusyTimeout time.Duration
+	ForeignKeys bool
+	// MaxReaders limits the read-only connections; there is always a
+	// single writer connection.
+	MaxReaders      int
+	ConnMaxIdleTime time.Duration
+	// StatementCacheSize caps the prepared statements kept per pool; 0
+	// disables the cache.
+	StatementCacheSize int
+}
+
+// DefaultOptions suit a server with concurrent readers and writers.
+func DefaultOptions() Options {
+	return Options{
+		JournalMode:        "wal",
+		Synchronous:        "normal",
+		BusyTimeout:        5 * time.Second,
+		ForeignKeys:        true,
+		MaxReaders:         4,
+		ConnMaxIdleTime:    5 * time.Minute,
+		StatementCacheSize: 256,
+	}
+}
+
+var (
+	journalModes = []string{"delete", "truncate", "persist", "memory", "wal", "off"}
+	syncLevels   = []string{"off", "normal", "full", "extra"}
+)
+
+// Validate reports options SQLite would reject or ignore.
+func (o Options) Validate() error {
+	if !contains(journalModes, o.JournalMode) {
+		return fmt.Errorf("unknown journal mode %q", o.JournalMode)
+	}
+	if !contains(syncLevels, o.Synchronous) {
+		return fmt.Errorf("unknown synchronous level %q", o.Synchronous)
+	}
+	if o.BusyTimeout < 0 || o.MaxReaders < 1 || o.StatementCacheSize < 0 {
+		return errors.New("busy timeout, readers and statement cache size must not be negative, readers at least 1")
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func Init(dbFile string, opts Options) error {
 	if dbFile == "" {
 		dbFile = "./data/todo.db"
 	}
 
-	sqlDB, err := sql.Open("sqlite", dbFile)
+	pool, err := Open(dbFile, opts)
 	if err != nil {
 		return err
 	}
 
+	DB = pool
+	return nil
+}
+
+// Pool is a SQLite database opened as a single writer connection and a
+// pool of read-only connections. In WAL mode readers never wait for the
+// writer, and a single writer queues writes in-process instead of having
+// them fail with SQLITE_BUSY.
+type Pool struct {
+	Writer *sql.DB
+	Reader *sql.DB
+
+	writeStmts *stmtCache
+	readStmts  *stmtCache
+}
+
+// Open opens the SQLite database at path and brings its schema up to date.
+func Open(path string, opts Options) (*Pool, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", opts.BusyTimeout.Milliseconds()))
+	pragmas.Add("_pragma", "synchronous("+opts.Synchronous+")")
+	if opts.ForeignKeys {
+		pragmas.Add("_pragma", "foreign_keys(1)")
+	} else {
+		pragmas.Add("_pragma", "foreign_keys(0)")
+	}
+
+	// Taking the write lock at BEGIN keeps a transaction that reads first
+	// from failing with SQLITE_BUSY when it later writes.
+	writerDSN := "file:" + path + "?_txlock=immediate&_pragma=journal_mode(" + opts.JournalMode + ")&" + pragmas.Encode()
+	writer, err := openDB(writerDSN)
+	if err != nil {
+		return nil, err
+	}
+	writer.SetMaxOpenConns(1)
+
+	if err := Migrate(writer); err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	reader, err := openDB("file:" + path + "?_pragma=query_only(1)&" + pragmas.Encode())
+	if err != nil {
+		writer.Close()
+		return nil, err
+	}
+	reader.SetMaxOpenConns(opts.MaxReaders)
+	reader.SetMaxIdleConns(opts.MaxReaders)
+	reader.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+
+	return &Pool{
+		Writer:     writer,
+		Reader:     reader,
+		writeStmts: newStmtCache(writer, opts.StatementCacheSize),
+		readStmts:  newStmtCache(reader, opts.StatementCacheSize),
+	}, nil
+}
+
+func openDB(dsn string) (*sql.DB, error) {
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
 	if err := sqlDB.Ping(); err != nil {
 		sqlDB.Close()
-		return err
+		return nil, err
 	}
+	return sqlDB, nil
+}
 
-	query := `
-	CREATE TABLE IF NOT EXISTS task (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		title TEXT NOT NULL,
-		description TEXT NOT NULL,
-		completed BOOLEAN NOT NULL DEFAULT FALSE,
-		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
-		updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
-	);
-	CREATE INDEX IF NOT EXISTS idx_completed ON task(completed);
-	CREATE INDEX IF NOT EXISTS idx_created_at ON task(created_at);
-	`
-
-	if _, err := sqlDB.Exec(query); err != nil {
-		sqlDB.Close()
-		return err
+// IsRead reports whether query only reads and can run on the reader pool.
+func IsRead(query string) bool {
+	q := strings.TrimSpace(query)
+	return len(q) >= 6 && strings.EqualFold(q[:6], "SELECT")
+}
+
+// ReadStmt returns a cached prepared statement for query on the reader
+// pool. It returns nil once the cache is full; callers then run the query
+// unprepared.
+func (p *Pool) ReadStmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.readStmts.get(ctx, query)
+}
+
+// WriteStmt is ReadStmt for the writer connection. It must not be called
+// inside a transaction, which holds the only writer connection; use
+// CachedWriteStmt and PrepareWrite instead.
+func (p *Pool) WriteStmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.writeStmts.get(ctx, query)
+}
+
+// CachedWriteStmt returns the prepared writer statement for query if it
+// is cached, without preparing it.
+func (p *Pool) CachedWriteStmt(query string) *sql.Stmt {
+	return p.writeStmts.lookup(query)
+}
+
+// PrepareWrite caches writer statements for queries, for example those a
+// finished transaction ran unprepared.
+func (p *Pool) PrepareWrite(ctx context.Context, queries ...string) {
+	for _, q := range queries {
+		p.writeStmts.get(ctx, q)
 	}
+}
 
-	DB = sqlDB
-	return nil
+func (p *Pool) Close() error {
+	p.readStmts.close()
+	p.writeStmts.close()
+	return errors.Join(p.Reader.Close(), p.Writer.Close())
+}
+
+// stmtCache keeps prepared statements of one *sql.DB by query text.
+type stmtCache struct {
+	db   *sql.DB
+	size int
+
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+}
+
+func newStmtCache(db *sql.DB, size int) *stmtCache {
+	return &stmtCache{db: db, size: size, stmts: make(map[string]*sql.Stmt)}
+}
+
+func (c *stmtCache) lookup(query string) *sql.Stmt {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stmts[query]
+}
+
+// get prepares query outside the lock: preparing may wait for a busy
+// connection, and lookups must not wait behind it.
+func (c *stmtCache) get(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.mu.RLock()
+	stmt, ok := c.stmts[query]
+	full := len(c.stmts) >= c.size
+	c.mu.RUnlock()
+	if ok || full {
+		return stmt, nil
+	}
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.stmts[query]; ok {
+		stmt.Close()
+		return cached, nil
+	}
+	if len(c.stmts) >= c.size {
+		stmt.Close()
+		return nil, nil
+	}
+	c.stmts[query] = stmt
+	return stmt, nil
+}
+
+func (c *stmtCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for query, stmt := range c.stmts {
+		stmt.Close()
+		delete(c.stmts, query)
+	}
 }
@@ -0,0 +1,342 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// migrations are applied in order; the number of applied entries is kept
+// in PRAGMA user_version. Only ever append to this list.
+var migrations = []string{
+	`
+	CREATE TABLE IF NOT EXISTS task (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		description TEXT NOT NULL,
+		completed BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_completed ON task(completed);
+	CREATE INDEX IF NOT EXISTS idx_created_at ON task(created_at);
+	`,
+	`
+	ALTER TABLE task ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+	CREATE INDEX IF NOT EXISTS idx_owner ON task(owner);
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS calendar_feed (
+		owner TEXT PRIMARY KEY,
+		token TEXT NOT NULL UNIQUE,
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS webhook (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		url TEXT NOT NULL,
+		secret TEXT NOT NULL,
+		events TEXT NOT NULL DEFAULT '',
+		owner TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE TABLE IF NOT EXISTS webhook_delivery (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		webhook_id INTEGER NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+		event_id TEXT NOT NULL,
+		event_type TEXT NOT NULL,
+		payload BLOB NOT NULL,
+		status TEXT NOT NULL DEFAULT 'pending',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at INTEGER NOT NULL,
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_webhook_delivery_due ON webhook_delivery(status, next_attempt_at);
+	CREATE INDEX IF NOT EXISTS idx_webhook_delivery_webhook ON webhook_delivery(webhook_id, id);
+	CREATE TABLE IF NOT EXISTS webhook_attempt (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		delivery_id INTEGER NOT NULL REFERENCES webhook_delivery(id) ON DELETE CASCADE,
+		attempted_at INTEGER NOT NULL,
+		status_code INTEGER NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		duration_ms INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX IF NOT EXISTS idx_webhook_attempt_delivery ON webhook_attempt(delivery_id);
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS event_outbox (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		event_id TEXT NOT NULL UNIQUE,
+		event_type TEXT NOT NULL,
+		payload BLOB NOT NULL,
+		created_at INTEGER NOT NULL,
+		published_at INTEGER,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at INTEGER NOT NULL,
+		last_error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox(published_at, id);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_delivery_event ON webhook_delivery(webhook_id, event_id);
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS task_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		changed_at INTEGER NOT NULL,
+		changes TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_task_history_task ON task_history(task_id, id);
+	CREATE INDEX IF NOT EXISTS idx_task_history_changed_at ON task_history(changed_at);
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS task_revision (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		revision INTEGER NOT NULL,
+		title TEXT NOT NULL,
+		description TEXT NOT NULL,
+		completed BOOLEAN NOT NULL,
+		owner TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		actor TEXT NOT NULL DEFAULT '',
+		revised_at INTEGER NOT NULL,
+		UNIQUE (task_id, revision)
+	);
+	INSERT INTO task_revision (task_id, revision, title, description, completed, owner, created_at, updated_at, revised_at)
+	SELECT id, 1, title, description, completed, owner, created_at, updated_at, CAST(unixepoch('subsec') * 1000 AS INTEGER)
+	FROM task;
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS comment (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		author TEXT NOT NULL DEFAULT '',
+		body TEXT NOT NULL,
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_comment_task ON comment(task_id, id);
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS attachment (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		filename TEXT NOT NULL,
+		content_type TEXT NOT NULL,
+		size INTEGER NOT NULL,
+		sha256 TEXT NOT NULL,
+		storage_key TEXT NOT NULL UNIQUE,
+		uploader TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at INTEGER
+	);
+	CREATE INDEX IF NOT EXISTS idx_attachment_task ON attachment(task_id, id);
+	CREATE INDEX IF NOT EXISTS idx_attachment_deleted ON attachment(deleted_at);
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS workspace (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE TABLE IF NOT EXISTS workspace_member (
+		workspace_id INTEGER NOT NULL,
+		member TEXT NOT NULL,
+		role TEXT NOT NULL,
+		added_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (workspace_id, member)
+	);
+	CREATE INDEX IF NOT EXISTS idx_workspace_member_member ON workspace_member(member);
+	ALTER TABLE task ADD COLUMN workspace_id INTEGER NOT NULL DEFAULT 0;
+	CREATE INDEX IF NOT EXISTS idx_task_workspace ON task(workspace_id);
+	ALTER TABLE task_revision ADD COLUMN workspace_id INTEGER NOT NULL DEFAULT 0;
+	CREATE TABLE IF NOT EXISTS task_assignee (
+		task_id INTEGER NOT NULL,
+		assignee TEXT NOT NULL,
+		PRIMARY KEY (task_id, assignee)
+	);
+	CREATE INDEX IF NOT EXISTS idx_task_assignee_assignee ON task_assignee(assignee);
+	`,
+	`
+	UPDATE workspace_member SET role = 'editor' WHERE role = 'member';
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS task_share (
+		task_id INTEGER NOT NULL,
+		user TEXT NOT NULL,
+		role TEXT NOT NULL,
+		granted_by TEXT NOT NULL DEFAULT '',
+		granted_at INTEGER NOT NULL,
+		PRIMARY KEY (task_id, user)
+	);
+	CREATE INDEX IF NOT EXISTS idx_task_share_user ON task_share(user);
+	CREATE TABLE IF NOT EXISTS share_link (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		token_hash TEXT NOT NULL UNIQUE,
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at INTEGER NOT NULL,
+		expires_at INTEGER NOT NULL,
+		revoked_at INTEGER
+	);
+	CREATE INDEX IF NOT EXISTS idx_share_link_task ON share_link(task_id);
+	`,
+	`
+	-- Timestamps stored as TEXT move to UTC Unix milliseconds; see
+	-- utcTimestamps.
+	DROP INDEX IF EXISTS idx_created_at;
+	`,
+	`
+	CREATE TABLE IF NOT EXISTS idempotency_key (
+		principal TEXT NOT NULL,
+		method TEXT NOT NULL,
+		key TEXT NOT NULL,
+		request_hash TEXT NOT NULL,
+		response_type TEXT NOT NULL DEFAULT '',
+		response BLOB,
+		created_at INTEGER NOT NULL,
+		expires_at INTEGER NOT NULL,
+		PRIMARY KEY (principal, method, key)
+	);
+	CREATE INDEX IF NOT EXISTS idx_idempotency_key_expires ON idempotency_key(expires_at);
+	`,
+}
+
+// dataMigrations run in the same transaction after the SQL migration with
+// the same index, for changes SQL alone cannot make.
+var dataMigrations = map[int]func(tx *sql.Tx) error{
+	12: utcTimestamps,
+}
+
+func Migrate(sqlDB *sql.DB) error {
+	var version int
+	if err := sqlDB.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := sqlDB.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		if migrate, ok := dataMigrations[i]; ok {
+			if err := migrate(tx); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %d: %w", i+1, err)
+			}
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// textTimestamps are the columns that held timestamps as TEXT, written
+// both by CURRENT_TIMESTAMP and by the driver from time.Time values.
+var textTimestamps = []struct{ table, column string }{
+	{"task", "created_at"},
+	{"task", "updated_at"},
+	{"calendar_feed", "created_at"},
+	{"webhook", "created_at"},
+	{"webhook_delivery", "created_at"},
+	{"task_revision", "created_at"},
+	{"task_revision", "updated_at"},
+	{"comment", "created_at"},
+	{"comment", "updated_at"},
+	{"attachment", "created_at"},
+	{"workspace", "created_at"},
+	{"workspace_member", "added_at"},
+}
+
+// utcTimestamps rewrites every TEXT timestamp as an INTEGER of UTC Unix
+// milliseconds, the encoding the other timestamp columns already use.
+// The new columns have no useful default, so writers must set them.
+func utcTimestamps(tx *sql.Tx) error {
+	for _, c := range textTimestamps {
+		tmp := c.column + "_ms"
+		if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s INTEGER NOT NULL DEFAULT 0`, c.table, tmp)); err != nil {
+			return err
+		}
+
+		values, err := textValues(tx, c.table, c.column)
+		if err != nil {
+			return err
+		}
+		update := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE rowid = ?`, c.table, tmp)
+		for rowID, text := range values {
+			t, err := parseTextTime(text)
+			if err != nil {
+				return fmt.Errorf("%s.%s of row %d: %w", c.table, c.column, rowID, err)
+			}
+			if _, err := tx.Exec(update, t.UnixMilli(), rowID); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s DROP COLUMN %s`, c.table, c.column)); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s RENAME COLUMN %s TO %s`, c.table, tmp, c.column)); err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_created_at ON task(created_at)`)
+	return err
+}
+
+func textValues(tx *sql.Tx, table, column string) (map[int64]string, error) {
+	rows, err := tx.Query(fmt.Sprintf(`SELECT rowid, %s FROM %s`, column, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[int64]string)
+	for rows.Next() {
+		var rowID int64
+		var text string
+		if err := rows.Scan(&rowID, &text); err != nil {
+			return nil, err
+		}
+		values[rowID] = text
+	}
+	return values, rows.Err()
+}
+
+// textTimeLayouts cover CURRENT_TIMESTAMP (UTC without a zone) and the
+// time.Time.String form the driver used for Go values.
+var textTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+}
+
+func parseTextTime(s string) (time.Time, error) {
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	for _, layout := range textTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamp %q", s)
+}
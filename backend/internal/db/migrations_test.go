@@ -0,0 +1,71 @@
+package db
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMigrateConvertsTextTimestamps(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	// Bring the schema to the version before the conversion and write rows
+	// the way CURRENT_TIMESTAMP and the driver used to.
+	for i := 0; i < 12; i++ {
+		if _, err := sqlDB.Exec(migrations[i]); err != nil {
+			t.Fatalf("migration %d: %v", i+1, err)
+		}
+	}
+	if _, err := sqlDB.Exec(`PRAGMA user_version = 12`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sqlDB.Exec(`INSERT INTO task (title, description, owner, created_at, updated_at) VALUES
+		('sql default', '', '', '2026-03-01 12:30:00', '2026-03-01 12:30:00'),
+		('go value', '', '', '2026-03-01 14:30:00.123456789 +0200 EET', '2026-03-01 14:31:00.5 +0200 EET m=+0.001')`,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(sqlDB); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := sqlDB.Query(`SELECT created_at, updated_at, typeof(created_at) FROM task ORDER BY created_at`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	want := [][2]time.Time{
+		{time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC), time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{time.Date(2026, 3, 1, 12, 30, 0, 123e6, time.UTC), time.Date(2026, 3, 1, 12, 31, 0, 500e6, time.UTC)},
+	}
+	var i int
+	for ; rows.Next(); i++ {
+		var created, updated int64
+		var typ string
+		if err := rows.Scan(&created, &updated, &typ); err != nil {
+			t.Fatal(err)
+		}
+		if typ != "integer" {
+			t.Errorf("row %d: expected an integer column, got %s", i, typ)
+		}
+		if got := time.UnixMilli(created).UTC(); i < len(want) && !got.Equal(want[i][0]) {
+			t.Errorf("row %d: expected created_at %v, got %v", i, want[i][0], got)
+		}
+		if got := time.UnixMilli(updated).UTC(); i < len(want) && !got.Equal(want[i][1]) {
+			t.Errorf("row %d: expected updated_at %v, got %v", i, want[i][1], got)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(want) {
+		t.Errorf("expected %d rows, got %d", len(want), i)
+	}
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// Task lifecycle event types.
+const (
+	TaskCreated   = "task.created"
+	TaskUpdated   = "task.updated"
+	TaskCompleted = "task.completed"
+	TaskDeleted   = "task.deleted"
+)
+
+// Comment event types. Their events carry the comment and the task it
+// belongs to.
+const (
+	CommentAdded   = "comment.added"
+	CommentEdited  = "comment.edited"
+	CommentDeleted = "comment.deleted"
+)
+
+// Types lists every event type in a stable order.
+var Types = []string{
+	TaskCreated, TaskUpdated, TaskCompleted, TaskDeleted,
+	CommentAdded, CommentEdited, CommentDeleted,
+}
+
+func Known(eventType string) bool {
+	for _, t := range Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event describes a change to a task or one of its comments. Delivery is at-least-once; ID is
+// unique per event and serves as the idempotency key that lets consumers
+// drop redeliveries.
+type Event struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	Task       *model.Model   `json:"task"`
+	Comment    *model.Comment `json:"comment,omitempty"`
+}
+
+func New(eventType string, task *model.Model) Event {
+	return Event{
+		ID:         newID(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Task:       task,
+	}
+}
+
+func NewComment(eventType string, task *model.Model, comment *model.Comment) Event {
+	ev := New(eventType, task)
+	ev.Comment = comment
+	return ev
+}
+
+// Pending is an event waiting in the outbox to be published.
+type Pending struct {
+	Event
+	Attempts      int
+	NextAttemptAt time.Time
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
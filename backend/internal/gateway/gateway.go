@@ -0,0 +1,664 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/timezone"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxBodySize = 1 << 20
+
+var (
+	marshaler      = protojson.MarshalOptions{EmitUnpopulated: true}
+	errorMarshaler = protojson.MarshalOptions{}
+	unmarshaler    = protojson.UnmarshalOptions{}
+)
+
+// forwardedHeaders are copied from the HTTP request into gRPC metadata.
+var forwardedHeaders = []string{"Authorization", "X-Tenant-Id", "X-Timezone", "Idempotency-Key"}
+
+// Gateway translates JSON HTTP requests into TodoService calls, so every
+// gRPC interceptor also applies to REST clients.
+type Gateway struct {
+	client todo.TodoServiceClient
+	mux    *http.ServeMux
+}
+
+func New(client todo.TodoServiceClient) *Gateway {
+	g := &Gateway{client: client, mux: http.NewServeMux()}
+
+	g.mux.HandleFunc("GET /v1/tasks", g.listTasks)
+	g.mux.HandleFunc("POST /v1/tasks", g.createTask)
+	g.mux.HandleFunc("GET /v1/tasks/{id}", g.getTask)
+	g.mux.HandleFunc("PATCH /v1/tasks/{id}", g.updateTask)
+	g.mux.HandleFunc("DELETE /v1/tasks/{id}", g.deleteTask)
+	g.mux.HandleFunc("GET /v1/calendar-feed", g.getCalendarFeed)
+	g.mux.HandleFunc("POST /v1/calendar-feed/rotate", g.rotateCalendarFeed)
+	g.mux.HandleFunc("GET /v1/webhooks", g.listWebhooks)
+	g.mux.HandleFunc("POST /v1/webhooks", g.createWebhook)
+	g.mux.HandleFunc("DELETE /v1/webhooks/{id}", g.deleteWebhook)
+	g.mux.HandleFunc("GET /v1/webhooks/{id}/deliveries", g.listWebhookDeliveries)
+	g.mux.HandleFunc("POST /v1/webhook-deliveries/{id}/retry", g.retryWebhookDelivery)
+	g.mux.HandleFunc("GET /v1/tasks/{id}/history", g.getTaskHistory)
+	g.mux.HandleFunc("GET /v1/admin/changes", g.listTaskChanges)
+	g.mux.HandleFunc("POST /v1/admin/backups", g.backup)
+	g.mux.HandleFunc("GET /v1/tasks/{id}/revisions", g.listTaskRevisions)
+	g.mux.HandleFunc("POST /v1/tasks/{id}/revert", g.revertTask)
+	g.mux.HandleFunc("POST /v1/tasks/{id}/restore", g.restoreTask)
+	g.mux.HandleFunc("GET /v1/tasks/{id}/comments", g.listComments)
+	g.mux.HandleFunc("POST /v1/tasks/{id}/comments", g.addComment)
+	g.mux.HandleFunc("PATCH /v1/comments/{id}", g.editComment)
+	g.mux.HandleFunc("DELETE /v1/comments/{id}", g.deleteComment)
+	g.mux.HandleFunc("GET /v1/tasks/{id}/attachments", g.listAttachments)
+	g.mux.HandleFunc("DELETE /v1/attachments/{id}", g.deleteAttachment)
+	g.mux.HandleFunc("POST /v1/tasks/{id}/assign", g.assignTask)
+	g.mux.HandleFunc("POST /v1/tasks/{id}/unassign", g.unassignTask)
+	g.mux.HandleFunc("GET /v1/tasks/{id}/shares", g.listTaskShares)
+	g.mux.HandleFunc("PUT /v1/tasks/{id}/shares/{user}", g.shareTask)
+	g.mux.HandleFunc("DELETE /v1/tasks/{id}/shares/{user}", g.unshareTask)
+	g.mux.HandleFunc("GET /v1/tasks/{id}/share-links", g.listShareLinks)
+	g.mux.HandleFunc("POST /v1/tasks/{id}/share-links", g.createShareLink)
+	g.mux.HandleFunc("POST /v1/share-links/{id}/revoke", g.revokeShareLink)
+	g.mux.HandleFunc("GET /v1/shared/{token}", g.getSharedTask)
+	g.mux.HandleFunc("GET /v1/workspaces", g.listWorkspaces)
+	g.mux.HandleFunc("POST /v1/workspaces", g.createWorkspace)
+	g.mux.HandleFunc("GET /v1/workspaces/{id}/members", g.listWorkspaceMembers)
+	g.mux.HandleFunc("PUT /v1/workspaces/{id}/members/{member}", g.setWorkspaceMember)
+	g.mux.HandleFunc("DELETE /v1/workspaces/{id}/members/{member}", g.removeWorkspaceMember)
+
+	return g
+}
+
+// ServeHTTP renders timestamps in the IANA time zone named by the
+// X-Timezone header, UTC by default.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	loc, err := timezone.Load(r.Header.Get("X-Timezone"))
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+	g.mux.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), locationKey{}, loc)))
+}
+
+type locationKey struct{}
+
+// listTasks accepts assignee=me|none and workspace_id besides filter.
+func (g *Gateway) listTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &todo.ListTasksRequest{Filter: query.Get("filter")}
+	switch query.Get("assignee") {
+	case "":
+	case "me":
+		req.AssigneeFilter = todo.AssigneeFilter_ASSIGNEE_FILTER_ASSIGNED_TO_ME
+	case "none":
+		req.AssigneeFilter = todo.AssigneeFilter_ASSIGNEE_FILTER_UNASSIGNED
+	default:
+		writeError(w, status.Error(codes.InvalidArgument, "assignee must be me or none"))
+		return
+	}
+	if v := query.Get("workspace_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, "workspace_id must be an integer"))
+			return
+		}
+		req.WorkspaceId = id
+	}
+	resp, err := g.client.ListTasks(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) createTask(w http.ResponseWriter, r *http.Request) {
+	req := &todo.CreateTaskRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	resp, err := g.client.CreateTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusCreated, resp, err)
+}
+
+func (g *Gateway) getTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.GetTask(outgoing(r), &todo.GetTaskRequest{Id: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) updateTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.UpdateTaskRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.Id = id
+
+	resp, err := g.client.UpdateTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) deleteTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.DeleteTask(outgoing(r), &todo.DeleteTaskRequest{Id: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) getCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.GetCalendarFeed(outgoing(r), &todo.GetCalendarFeedRequest{})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) rotateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.GetCalendarFeed(outgoing(r), &todo.GetCalendarFeedRequest{Rotate: true})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.ListWebhooks(outgoing(r), &todo.ListWebhooksRequest{})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) createWebhook(w http.ResponseWriter, r *http.Request) {
+	req := &todo.CreateWebhookRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	resp, err := g.client.CreateWebhook(outgoing(r), req)
+	writeResponse(w, r, http.StatusCreated, resp, err)
+}
+
+func (g *Gateway) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.DeleteWebhook(outgoing(r), &todo.DeleteWebhookRequest{Id: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.ListWebhookDeliveriesRequest{WebhookId: id}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, "limit must be an integer"))
+			return
+		}
+		req.Limit = int32(limit)
+	}
+	resp, err := g.client.ListWebhookDeliveries(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) retryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.RetryWebhookDelivery(outgoing(r), &todo.RetryWebhookDeliveryRequest{Id: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) getTaskHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.GetTaskHistoryRequest{TaskId: id, PageToken: r.URL.Query().Get("page_token")}
+	if req.PageSize, ok = pageSize(w, r); !ok {
+		return
+	}
+	resp, err := g.client.GetTaskHistory(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listTaskChanges(w http.ResponseWriter, r *http.Request) {
+	req := &todo.ListTaskChangesRequest{PageToken: r.URL.Query().Get("page_token")}
+	var ok bool
+	if req.StartTime, ok = queryTime(w, r, "start_time"); !ok {
+		return
+	}
+	if req.EndTime, ok = queryTime(w, r, "end_time"); !ok {
+		return
+	}
+	if req.PageSize, ok = pageSize(w, r); !ok {
+		return
+	}
+	resp, err := g.client.ListTaskChanges(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) backup(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.Backup(outgoing(r), &todo.BackupRequest{})
+	writeResponse(w, r, http.StatusCreated, resp, err)
+}
+
+func (g *Gateway) listTaskRevisions(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.ListTaskRevisions(outgoing(r), &todo.ListTaskRevisionsRequest{TaskId: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) revertTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.RevertTaskRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.TaskId = id
+	resp, err := g.client.RevertTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) restoreTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.RestoreTask(outgoing(r), &todo.RestoreTaskRequest{TaskId: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listComments(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.ListCommentsRequest{TaskId: id, PageToken: r.URL.Query().Get("page_token")}
+	if req.PageSize, ok = pageSize(w, r); !ok {
+		return
+	}
+	resp, err := g.client.ListComments(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) addComment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.AddCommentRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.TaskId = id
+	resp, err := g.client.AddComment(outgoing(r), req)
+	writeResponse(w, r, http.StatusCreated, resp, err)
+}
+
+func (g *Gateway) editComment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.EditCommentRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.Id = id
+	resp, err := g.client.EditComment(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) deleteComment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.DeleteComment(outgoing(r), &todo.DeleteCommentRequest{Id: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listAttachments(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.ListAttachments(outgoing(r), &todo.ListAttachmentsRequest{TaskId: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.DeleteAttachment(outgoing(r), &todo.DeleteAttachmentRequest{Id: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) assignTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.AssignTaskRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.TaskId = id
+	resp, err := g.client.AssignTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) unassignTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.UnassignTaskRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.TaskId = id
+	resp, err := g.client.UnassignTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listTaskShares(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.ListTaskShares(outgoing(r), &todo.ListTaskSharesRequest{TaskId: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) shareTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.ShareTaskRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.TaskId, req.User = id, r.PathValue("user")
+	resp, err := g.client.ShareTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) unshareTask(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.UnshareTaskRequest{TaskId: id, User: r.PathValue("user")}
+	resp, err := g.client.UnshareTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listShareLinks(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.ListShareLinks(outgoing(r), &todo.ListShareLinksRequest{TaskId: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) createShareLink(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.CreateShareLinkRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.TaskId = id
+	resp, err := g.client.CreateShareLink(outgoing(r), req)
+	writeResponse(w, r, http.StatusCreated, resp, err)
+}
+
+func (g *Gateway) revokeShareLink(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.RevokeShareLink(outgoing(r), &todo.RevokeShareLinkRequest{Id: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) getSharedTask(w http.ResponseWriter, r *http.Request) {
+	req := &todo.GetSharedTaskRequest{Token: r.PathValue("token")}
+	resp, err := g.client.GetSharedTask(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) listWorkspaces(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.ListWorkspaces(outgoing(r), &todo.ListWorkspacesRequest{})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) createWorkspace(w http.ResponseWriter, r *http.Request) {
+	req := &todo.CreateWorkspaceRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	resp, err := g.client.CreateWorkspace(outgoing(r), req)
+	writeResponse(w, r, http.StatusCreated, resp, err)
+}
+
+func (g *Gateway) listWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	resp, err := g.client.ListWorkspaceMembers(outgoing(r), &todo.ListWorkspaceMembersRequest{WorkspaceId: id})
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) setWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.SetWorkspaceMemberRequest{}
+	if !readBody(w, r, req) {
+		return
+	}
+	req.WorkspaceId, req.Member = id, r.PathValue("member")
+	resp, err := g.client.SetWorkspaceMember(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func (g *Gateway) removeWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	req := &todo.RemoveWorkspaceMemberRequest{WorkspaceId: id, Member: r.PathValue("member")}
+	resp, err := g.client.RemoveWorkspaceMember(outgoing(r), req)
+	writeResponse(w, r, http.StatusOK, resp, err)
+}
+
+func pageSize(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	v := r.URL.Query().Get("page_size")
+	if v == "" {
+		return 0, true
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "page_size must be an integer"))
+		return 0, false
+	}
+	return int32(n), true
+}
+
+// queryTime parses an RFC 3339 query parameter; it is nil when absent.
+func queryTime(w http.ResponseWriter, r *http.Request, name string) (*timestamppb.Timestamp, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, name+" must be an RFC 3339 timestamp"))
+		return nil, false
+	}
+	return timestamppb.New(t), true
+}
+
+// outgoing carries auth headers, the client certificate and the client
+// address over to the gRPC call.
+func outgoing(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, h := range forwardedHeaders {
+		if v := r.Header.Get(h); v != "" {
+			md.Set(h, v)
+		}
+	}
+	SetClientMetadata(md, r)
+	return metadata.NewOutgoingContext(r.Context(), md)
+}
+
+// SetClientMetadata sets what the in-process server trusts the HTTP
+// front end to tell it about the client: its address and the subject of
+// its verified certificate. Any copies the client sent are dropped.
+func SetClientMetadata(md metadata.MD, r *http.Request) {
+	delete(md, auth.CertSubjectKey)
+	delete(md, "x-forwarded-for")
+	if subject, ok := auth.CertSubject(r.TLS); ok {
+		md.Set(auth.CertSubjectKey, subject)
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		md.Set("x-forwarded-for", host)
+	}
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, "id must be an integer"))
+		return 0, false
+	}
+	return id, true
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return false
+	}
+	if len(body) == 0 {
+		return true
+	}
+	if err := unmarshaler.Unmarshal(body, msg); err != nil {
+		writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, r *http.Request, code int, msg proto.Message, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	loc, _ := r.Context().Value(locationKey{}).(*time.Location)
+	if loc == nil || loc == time.UTC {
+		writeJSON(w, code, msg)
+		return
+	}
+
+	body, err := marshaler.Marshal(msg)
+	if err == nil {
+		body, err = localize(body, msg.ProtoReflect().Descriptor(), loc)
+	}
+	if err != nil {
+		log.L().Errorf("gateway: marshal response: %v", err)
+		writeError(w, status.Error(codes.Internal, "internal error"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// writeError renders the gRPC status, details included, as a
+// google.rpc.Status JSON body.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeJSONWith(errorMarshaler, w, HTTPStatusFromCode(st.Code()), st.Proto())
+}
+
+func writeJSON(w http.ResponseWriter, code int, msg proto.Message) {
+	writeJSONWith(marshaler, w, code, msg)
+}
+
+func writeJSONWith(m protojson.MarshalOptions, w http.ResponseWriter, code int, msg proto.Message) {
+	body, err := m.Marshal(msg)
+	if err != nil {
+		log.L().Errorf("gateway: marshal response: %v", err)
+		code = http.StatusInternalServerError
+		body = []byte(`{"code":13,"message":"internal error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// HTTPStatusFromCode follows the mapping in google/rpc/code.proto.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
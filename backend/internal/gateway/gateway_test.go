@@ -0,0 +1,160 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeClient struct {
+	todo.TodoServiceClient
+	t *testing.T
+}
+
+func (f *fakeClient) CreateTask(ctx context.Context, req *todo.CreateTaskRequest, _ ...grpc.CallOption) (*todo.Task, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if got := md.Get("authorization"); len(got) == 0 || got[0] != "Bearer secret" {
+		f.t.Errorf("expected authorization to be forwarded, got %v", got)
+	}
+	return &todo.Task{Id: 7, Title: req.GetTitle()}, nil
+}
+
+func (f *fakeClient) GetTask(_ context.Context, req *todo.GetTaskRequest, _ ...grpc.CallOption) (*todo.Task, error) {
+	return nil, status.Error(codes.NotFound, "task not found")
+}
+
+func (f *fakeClient) UpdateTask(_ context.Context, req *todo.UpdateTaskRequest, _ ...grpc.CallOption) (*todo.Task, error) {
+	if req.GetId() != 7 || req.Title != nil || !req.GetCompleted() {
+		f.t.Errorf("unexpected update request: %v", req)
+	}
+	return &todo.Task{Id: req.GetId(), Completed: true}, nil
+}
+
+func (f *fakeClient) ListTasks(context.Context, *todo.ListTasksRequest, ...grpc.CallOption) (*todo.ListTasksResponse, error) {
+	at := timestamppb.New(time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC))
+	return &todo.ListTasksResponse{Tasks: []*todo.Task{{Id: 7, Title: "2026-03-01T12:30:00Z", CreatedAt: at, UpdatedAt: at}}}, nil
+}
+
+func TestGateway(t *testing.T) {
+	g := New(&fakeClient{t: t})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{"create", http.MethodPost, "/v1/tasks", `{"title":"Buy milk"}`, http.StatusCreated, `"title":"Buy milk"`},
+		{"get not found", http.MethodGet, "/v1/tasks/1", "", http.StatusNotFound, `"code":5`},
+		{"bad id", http.MethodGet, "/v1/tasks/abc", "", http.StatusBadRequest, `"code":3`},
+		{"update", http.MethodPatch, "/v1/tasks/7", `{"completed":true}`, http.StatusOK, `"completed":true`},
+		{"unknown field", http.MethodPatch, "/v1/tasks/7", `{"done":true}`, http.StatusBadRequest, `"code":3`},
+		{"wrong method", http.MethodPut, "/v1/tasks/7", "", http.StatusMethodNotAllowed, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer secret")
+			rec := httptest.NewRecorder()
+
+			g.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d: %s", tt.wantCode, rec.Code, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("expected body to contain %s, got %s", tt.wantBody, rec.Body)
+			}
+		})
+	}
+}
+
+func TestGatewayTimezone(t *testing.T) {
+	g := New(&fakeClient{t: t})
+
+	tests := []struct {
+		name     string
+		zone     string
+		wantCode int
+		wantBody string
+	}{
+		{"utc by default", "", http.StatusOK, `"createdAt":"2026-03-01T12:30:00Z"`},
+		{"iana zone", "Asia/Tokyo", http.StatusOK, `"createdAt":"2026-03-01T21:30:00+09:00"`},
+		{"unknown zone", "Mars/Olympus", http.StatusBadRequest, `"code":3`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/tasks", nil)
+			if tt.zone != "" {
+				req.Header.Set("X-Timezone", tt.zone)
+			}
+			rec := httptest.NewRecorder()
+
+			g.ServeHTTP(rec, req)
+
+			body := strings.ReplaceAll(rec.Body.String(), " ", "")
+			if rec.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d: %s", tt.wantCode, rec.Code, body)
+			}
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("expected body to contain %s, got %s", tt.wantBody, body)
+			}
+			// Only timestamp fields are converted, not strings that look like one.
+			if tt.wantCode == http.StatusOK && !strings.Contains(body, `"title":"2026-03-01T12:30:00Z"`) {
+				t.Errorf("expected title to be unchanged, got %s", body)
+			}
+		})
+	}
+}
+
+func TestSetClientMetadata(t *testing.T) {
+	verified := &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "alice"}}}},
+	}
+
+	tests := []struct {
+		name        string
+		tls         *tls.ConnectionState
+		wantSubject []string
+	}{
+		{"verified certificate", verified, []string{"alice"}},
+		{"no certificate", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/tasks", nil)
+			req.TLS = tt.tls
+			md := metadata.Pairs(auth.CertSubjectKey, "mallory", "x-forwarded-for", "10.0.0.1")
+
+			SetClientMetadata(md, req)
+
+			if got := md.Get(auth.CertSubjectKey); !slices.Equal(got, tt.wantSubject) {
+				t.Errorf("expected subject %v, got %v", tt.wantSubject, got)
+			}
+			if got := md.Get("x-forwarded-for"); !slices.Equal(got, []string{"192.0.2.1"}) {
+				t.Errorf("expected the remote address, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+// localize rewrites the google.protobuf.Timestamp values in body, the
+// protojson encoding of a message of type md, from UTC to loc. protojson
+// itself always renders UTC.
+func localize(body []byte, md protoreflect.MessageDescriptor, loc *time.Location) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(localizeMessage(v, md, loc))
+}
+
+func localizeMessage(v any, md protoreflect.MessageDescriptor, loc *time.Location) any {
+	if md.FullName() == timestampName {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return v
+		}
+		return t.In(loc).Format(time.RFC3339Nano)
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || fd.IsMap() {
+			continue
+		}
+		value, ok := obj[fd.JSONName()]
+		if !ok {
+			continue
+		}
+		if list, ok := value.([]any); ok && fd.IsList() {
+			for j := range list {
+				list[j] = localizeMessage(list[j], fd.Message(), loc)
+			}
+			continue
+		}
+		obj[fd.JSONName()] = localizeMessage(value, fd.Message(), loc)
+	}
+	return obj
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maintenanceTimeout bounds operations that read or rewrite the whole
+// database.
+const maintenanceTimeout = 5 * time.Minute
+
+type AdminHandler struct {
+	adminService *service.AdminService
+	todo.UnimplementedAdminServiceServer
+}
+
+func NewAdminHandler(s *service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: s}
+}
+
+func (h *AdminHandler) GetDatabaseStats(ctx context.Context, _ *todo.GetDatabaseStatsRequest) (*todo.DatabaseStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
+	defer cancel()
+
+	stats, err := h.adminService.DatabaseStats(ctx)
+	if err != nil {
+		return nil, adminError("GetDatabaseStats", err)
+	}
+
+	resp := &todo.DatabaseStats{
+		FileSizeBytes: stats.FileSize,
+		WalSizeBytes:  stats.WALSize,
+		PageSize:      stats.PageSize,
+		PageCount:     stats.PageCount,
+		FreelistCount: stats.FreelistCount,
+		JournalMode:   stats.JournalMode,
+	}
+	for _, t := range stats.Tables {
+		resp.Tables = append(resp.Tables, &todo.TableStats{Name: t.Name, RowCount: t.RowCount})
+	}
+	return resp, nil
+}
+
+func (h *AdminHandler) Vacuum(ctx context.Context, _ *todo.VacuumRequest) (*todo.VacuumResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
+	defer cancel()
+
+	log.L().Info("Vacuum request")
+
+	before, after, err := h.adminService.Vacuum(ctx)
+	if err != nil {
+		return nil, adminError("Vacuum", err)
+	}
+
+	log.L().Infof("Vacuum success: size %d -> %d bytes", before, after)
+	return &todo.VacuumResponse{SizeBeforeBytes: before, SizeAfterBytes: after}, nil
+}
+
+func (h *AdminHandler) Analyze(ctx context.Context, _ *todo.AnalyzeRequest) (*todo.AnalyzeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
+	defer cancel()
+
+	log.L().Info("Analyze request")
+
+	if err := h.adminService.Analyze(ctx); err != nil {
+		return nil, adminError("Analyze", err)
+	}
+
+	log.L().Info("Analyze success")
+	return &todo.AnalyzeResponse{}, nil
+}
+
+func (h *AdminHandler) CheckIntegrity(ctx context.Context, req *todo.CheckIntegrityRequest) (*todo.IntegrityReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
+	defer cancel()
+
+	log.L().Infof("CheckIntegrity request: quick=%t", req.GetQuick())
+
+	problems, err := h.adminService.CheckIntegrity(ctx, req.GetQuick())
+	if err != nil {
+		return nil, adminError("CheckIntegrity", err)
+	}
+
+	if len(problems) > 0 {
+		log.L().Errorf("CheckIntegrity found %d problems: %v", len(problems), problems)
+	}
+	return &todo.IntegrityReport{Ok: len(problems) == 0, Problems: problems}, nil
+}
+
+func (h *AdminHandler) GetLogLevel(ctx context.Context, _ *todo.GetLogLevelRequest) (*todo.LogLevel, error) {
+	level, err := h.adminService.LogLevel(ctx)
+	if err != nil {
+		return nil, adminError("GetLogLevel", err)
+	}
+	return &todo.LogLevel{Level: level}, nil
+}
+
+func (h *AdminHandler) SetLogLevel(ctx context.Context, req *todo.SetLogLevelRequest) (*todo.LogLevel, error) {
+	level, err := h.adminService.SetLogLevel(ctx, req.GetLevel())
+	if err != nil {
+		return nil, adminError("SetLogLevel", err)
+	}
+	return &todo.LogLevel{Level: level}, nil
+}
+
+func (h *AdminHandler) ListConnections(ctx context.Context, _ *todo.ListConnectionsRequest) (*todo.ListConnectionsResponse, error) {
+	conns, calls, err := h.adminService.Connections(ctx)
+	if err != nil {
+		return nil, adminError("ListConnections", err)
+	}
+
+	resp := &todo.ListConnectionsResponse{}
+	for _, c := range conns {
+		resp.Connections = append(resp.Connections, &todo.Connection{
+			Id:          c.ID,
+			RemoteAddr:  c.RemoteAddr,
+			ConnectedAt: timestamppb.New(c.ConnectedAt),
+		})
+	}
+	for _, c := range calls {
+		resp.Calls = append(resp.Calls, &todo.Call{
+			ConnectionId: c.ConnectionID,
+			Method:       c.Method,
+			Principal:    c.Principal,
+			Streaming:    c.Streaming,
+			StartedAt:    timestamppb.New(c.StartedAt),
+		})
+	}
+	return resp, nil
+}
+
+func adminError(method string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthenticated):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrUnknownLogLevel):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// attachmentChunkSize bounds the payload of a single download message.
+const attachmentChunkSize = 64 << 10
+
+var errAttachmentsDisabled = status.Error(codes.Unimplemented, "attachments are not enabled")
+
+func (h *TaskHandler) UploadAttachment(stream grpc.ClientStreamingServer[todo.UploadAttachmentRequest, todo.Attachment]) error {
+	if h.attachmentService == nil {
+		return errAttachmentsDisabled
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "first message must carry attachment info")
+		}
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "first message must carry attachment info")
+	}
+
+	log.L().Infof("UploadAttachment request: task_id=%d filename=%q content_type=%q size=%d",
+		info.GetTaskId(), info.GetFilename(), info.GetContentType(), info.GetSize())
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go pipeAttachmentData(stream, pw)
+
+	a, err := h.attachmentService.Upload(stream.Context(), service.AttachmentUpload{
+		TaskID:      info.GetTaskId(),
+		Filename:    info.GetFilename(),
+		ContentType: info.GetContentType(),
+		Size:        info.GetSize(),
+		SHA256:      info.GetSha256(),
+	}, pr)
+	if err != nil {
+		return attachmentError("UploadAttachment", err)
+	}
+
+	log.L().Infof("UploadAttachment success: id=%d size=%d", a.ID, a.Size)
+	return stream.SendAndClose(convertAttachment(a))
+}
+
+// pipeAttachmentData feeds the data chunks of an upload stream into pw.
+func pipeAttachmentData(stream grpc.ClientStreamingServer[todo.UploadAttachmentRequest, todo.Attachment], pw *io.PipeWriter) {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			pw.Close()
+			return
+		}
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if req.GetInfo() != nil {
+			pw.CloseWithError(status.Error(codes.InvalidArgument, "attachment info may only be sent once"))
+			return
+		}
+		if _, err := pw.Write(req.GetData()); err != nil {
+			return
+		}
+	}
+}
+
+func (h *TaskHandler) DownloadAttachment(req *todo.DownloadAttachmentRequest, stream grpc.ServerStreamingServer[todo.DownloadAttachmentResponse]) error {
+	if h.attachmentService == nil {
+		return errAttachmentsDisabled
+	}
+
+	log.L().Infof("DownloadAttachment request: id=%d", req.GetId())
+
+	a, rc, err := h.attachmentService.Open(stream.Context(), req.GetId())
+	if err != nil {
+		return attachmentError("DownloadAttachment", err)
+	}
+	defer rc.Close()
+
+	if err := stream.Send(&todo.DownloadAttachmentResponse{
+		Payload: &todo.DownloadAttachmentResponse_Attachment{Attachment: convertAttachment(a)},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := io.ReadFull(rc, buf)
+		if n > 0 {
+			if err := stream.Send(&todo.DownloadAttachmentResponse{
+				Payload: &todo.DownloadAttachmentResponse_Data{Data: buf[:n]},
+			}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return attachmentError("DownloadAttachment", err)
+		}
+	}
+
+	log.L().Infof("DownloadAttachment success: id=%d size=%d", a.ID, a.Size)
+	return nil
+}
+
+func (h *TaskHandler) ListAttachments(ctx context.Context, req *todo.ListAttachmentsRequest) (*todo.ListAttachmentsResponse, error) {
+	if h.attachmentService == nil {
+		return nil, errAttachmentsDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	attachments, err := h.attachmentService.ListAttachments(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, attachmentError("ListAttachments", err)
+	}
+
+	resp := &todo.ListAttachmentsResponse{Attachments: make([]*todo.Attachment, len(attachments))}
+	for i, a := range attachments {
+		resp.Attachments[i] = convertAttachment(a)
+	}
+
+	log.L().Infof("ListAttachments success: task_id=%d count=%d", req.GetTaskId(), len(attachments))
+	return resp, nil
+}
+
+func (h *TaskHandler) DeleteAttachment(ctx context.Context, req *todo.DeleteAttachmentRequest) (*todo.DeleteAttachmentResponse, error) {
+	if h.attachmentService == nil {
+		return nil, errAttachmentsDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("DeleteAttachment request: id=%d", req.GetId())
+
+	if err := h.attachmentService.DeleteAttachment(ctx, req.GetId()); err != nil {
+		return nil, attachmentError("DeleteAttachment", err)
+	}
+
+	log.L().Infof("DeleteAttachment success: id=%d", req.GetId())
+	return &todo.DeleteAttachmentResponse{}, nil
+}
+
+func attachmentError(method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound), errors.Is(err, service.ErrAttachmentNotFound):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrAttachmentTooLarge),
+		errors.Is(err, service.ErrContentTypeNotAllowed),
+		errors.Is(err, service.ErrContentTypeMismatch),
+		errors.Is(err, service.ErrSizeMismatch):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrChecksumMismatch):
+		log.L().Errorf("%s failed: %v", method, err)
+		return status.Error(codes.DataLoss, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func convertAttachment(a *model.Attachment) *todo.Attachment {
+	return &todo.Attachment{
+		Id:          a.ID,
+		TaskId:      a.TaskID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Sha256:      a.SHA256,
+		Uploader:    a.Uploader,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"time"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// backupTimeout is longer than requestTimeout since a snapshot copies the
+// whole database.
+const backupTimeout = 5 * time.Minute
+
+var errBackupsDisabled = status.Error(codes.Unimplemented, "backups are not enabled")
+
+func (h *TaskHandler) Backup(ctx context.Context, _ *todo.BackupRequest) (*todo.BackupSnapshot, error) {
+	if h.backupService == nil {
+		return nil, errBackupsDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, backupTimeout)
+	defer cancel()
+
+	log.L().Info("Backup request")
+
+	snap, err := h.backupService.Backup(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnauthenticated):
+			log.L().Warnf("Backup failed: %v", err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, service.ErrPermissionDenied):
+			log.L().Warnf("Backup failed: %v", err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, context.DeadlineExceeded):
+			log.L().Errorf("Backup timeout exceeded")
+			return nil, status.Error(codes.DeadlineExceeded, "request timeout")
+		}
+		log.L().Errorf("Backup failed: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	log.L().Infof("Backup success: path=%s size=%d", snap.Path, snap.Size)
+	return &todo.BackupSnapshot{
+		Name:      filepath.Base(snap.Path),
+		SizeBytes: snap.Size,
+		Sha256:    snap.SHA256,
+		CreatedAt: timestamppb.New(snap.CreatedAt),
+	}, nil
+}
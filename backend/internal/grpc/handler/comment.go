@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errCommentsDisabled = status.Error(codes.Unimplemented, "comments are not enabled")
+
+func (h *TaskHandler) AddComment(ctx context.Context, req *todo.AddCommentRequest) (*todo.Comment, error) {
+	if h.commentService == nil {
+		return nil, errCommentsDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("AddComment request: task_id=%d", req.GetTaskId())
+
+	comment, err := h.commentService.AddComment(ctx, req.GetTaskId(), req.GetBody())
+	if err != nil {
+		return nil, commentError("AddComment", err)
+	}
+
+	log.L().Infof("AddComment success: id=%d", comment.ID)
+	return convertComment(comment), nil
+}
+
+func (h *TaskHandler) ListComments(ctx context.Context, req *todo.ListCommentsRequest) (*todo.ListCommentsResponse, error) {
+	if h.commentService == nil {
+		return nil, errCommentsDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	page, err := h.commentService.ListComments(ctx, req.GetTaskId(), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, commentError("ListComments", err)
+	}
+
+	resp := &todo.ListCommentsResponse{
+		Comments:      make([]*todo.Comment, len(page.Comments)),
+		NextPageToken: page.NextPageToken,
+	}
+	for i, c := range page.Comments {
+		resp.Comments[i] = convertComment(c)
+	}
+
+	log.L().Infof("ListComments success: task_id=%d count=%d", req.GetTaskId(), len(page.Comments))
+	return resp, nil
+}
+
+func (h *TaskHandler) EditComment(ctx context.Context, req *todo.EditCommentRequest) (*todo.Comment, error) {
+	if h.commentService == nil {
+		return nil, errCommentsDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("EditComment request: id=%d", req.GetId())
+
+	comment, err := h.commentService.EditComment(ctx, req.GetId(), req.GetBody())
+	if err != nil {
+		return nil, commentError("EditComment", err)
+	}
+
+	log.L().Infof("EditComment success: id=%d", comment.ID)
+	return convertComment(comment), nil
+}
+
+func (h *TaskHandler) DeleteComment(ctx context.Context, req *todo.DeleteCommentRequest) (*todo.DeleteCommentResponse, error) {
+	if h.commentService == nil {
+		return nil, errCommentsDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("DeleteComment request: id=%d", req.GetId())
+
+	if err := h.commentService.DeleteComment(ctx, req.GetId()); err != nil {
+		return nil, commentError("DeleteComment", err)
+	}
+
+	log.L().Infof("DeleteComment success: id=%d", req.GetId())
+	return &todo.DeleteCommentResponse{}, nil
+}
+
+func commentError(method string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidData):
+		log.L().Warnf("%s failed: %v", method, err)
+		return invalidArgument(err)
+	case errors.Is(err, service.ErrTaskNotFound), errors.Is(err, service.ErrCommentNotFound):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func convertComment(c *model.Comment) *todo.Comment {
+	return &todo.Comment{
+		Id:        c.ID,
+		TaskId:    c.TaskID,
+		Author:    c.Author,
+		Body:      c.Body,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+	}
+}
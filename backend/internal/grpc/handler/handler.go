@@ -5,37 +5,122 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Elmar006/todo_grpc/internal/calendar"
 	log "github.com/Elmar006/todo_grpc/internal/logger"
 	"github.com/Elmar006/todo_grpc/internal/model"
 	"github.com/Elmar006/todo_grpc/internal/service"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+	"github.com/Elmar006/todo_grpc/internal/validation"
 	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const requestTimeout = 5 * time.Second
 
 type TaskHandler struct {
-	taskService *service.TaskService
+	taskService       *service.TaskService
+	webhookService    *service.WebhookService
+	historyService    *service.HistoryService
+	commentService    *service.CommentService
+	attachmentService *service.AttachmentService
+	workspaceService  *service.WorkspaceService
+	backupService     *service.BackupService
 	todo.UnimplementedTodoServiceServer
 }
 
-func NewTaskHandler(taskService *service.TaskService) *TaskHandler {
-	return &TaskHandler{taskService: taskService}
+type Option func(*TaskHandler)
+
+// WithWebhookService enables the webhook RPCs; without it they return
+// Unimplemented.
+func WithWebhookService(s *service.WebhookService) Option {
+	return func(h *TaskHandler) {
+		h.webhookService = s
+	}
+}
+
+// WithHistoryService enables the task history RPCs; without it they return
+// Unimplemented.
+func WithHistoryService(s *service.HistoryService) Option {
+	return func(h *TaskHandler) {
+		h.historyService = s
+	}
+}
+
+// WithBackupService enables the Backup RPC; without it it returns
+// Unimplemented.
+func WithBackupService(s *service.BackupService) Option {
+	return func(h *TaskHandler) {
+		h.backupService = s
+	}
+}
+
+// WithCommentService enables the comment RPCs; without it they return
+// Unimplemented.
+func WithCommentService(s *service.CommentService) Option {
+	return func(h *TaskHandler) {
+		h.commentService = s
+	}
+}
+
+// WithAttachmentService enables the attachment RPCs; without it they
+// return Unimplemented.
+func WithAttachmentService(s *service.AttachmentService) Option {
+	return func(h *TaskHandler) {
+		h.attachmentService = s
+	}
+}
+
+// WithWorkspaceService enables the workspace RPCs; without it they return
+// Unimplemented.
+func WithWorkspaceService(s *service.WorkspaceService) Option {
+	return func(h *TaskHandler) {
+		h.workspaceService = s
+	}
+}
+
+func NewTaskHandler(taskService *service.TaskService, opts ...Option) *TaskHandler {
+	h := &TaskHandler{taskService: taskService}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
 
 func (h *TaskHandler) CreateTask(ctx context.Context, req *todo.CreateTaskRequest) (*todo.Task, error) {
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	log.L().Infof("CreateTask request: title=%q", req.GetTitle())
+	log.L().Infof("CreateTask request: title=%q workspace_id=%d", req.GetTitle(), req.GetWorkspaceId())
 
-	task, err := h.taskService.CreateTask(ctx, req.GetTitle(), req.GetDescription())
+	task, err := h.taskService.CreateTaskIn(ctx, req.GetWorkspaceId(), req.GetTitle(), req.GetDescription())
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidData) {
-			log.L().Warnf("CreateTask failed: invalid data - title is empty")
-			return nil, status.Error(codes.InvalidArgument, err.Error())
+			log.L().Warnf("CreateTask failed: %v", err)
+			return nil, invalidArgument(err)
+		}
+		if errors.Is(err, service.ErrQuotaExceeded) {
+			log.L().Warnf("CreateTask failed: %v", err)
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if errors.Is(err, service.ErrUnauthenticated) {
+			log.L().Warnf("CreateTask failed: %v", err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, service.ErrWorkspaceNotFound) {
+			log.L().Warnf("CreateTask failed: %v", err)
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, service.ErrPermissionDenied) {
+			log.L().Warnf("CreateTask failed: %v", err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, service.ErrWorkspacesDisabled) {
+			log.L().Warnf("CreateTask failed: %v", err)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
 		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.L().Errorf("CreateTask timeout exceeded")
@@ -46,14 +131,7 @@ func (h *TaskHandler) CreateTask(ctx context.Context, req *todo.CreateTaskReques
 	}
 
 	log.L().Infof("CreateTask success: id=%d", task.ID)
-	return &todo.Task{
-		Id:          task.ID,
-		Title:       task.Title,
-		Description: *task.Description,
-		Completed:   task.Completed,
-		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-		UpdateAt:    task.UpdatedAt.Format(time.RFC3339),
-	}, nil
+	return convertStruct(task), nil
 }
 
 func (h *TaskHandler) GetTask(ctx context.Context, req *todo.GetTaskRequest) (*todo.Task, error) {
@@ -68,6 +146,10 @@ func (h *TaskHandler) GetTask(ctx context.Context, req *todo.GetTaskRequest) (*t
 			log.L().Warnf("GetTask not found: id=%d", req.GetId())
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, service.ErrPermissionDenied) {
+			log.L().Warnf("GetTask failed: %v", err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.L().Errorf("GetTask timeout exceeded")
 			return nil, status.Error(codes.DeadlineExceeded, "request timeout")
@@ -80,14 +162,27 @@ func (h *TaskHandler) GetTask(ctx context.Context, req *todo.GetTaskRequest) (*t
 	return convertStruct(taskModel), nil
 }
 
-func (h *TaskHandler) ListTask(ctx context.Context, req *todo.ListTasksRequest) (*todo.ListTasksResponse, error) {
+func (h *TaskHandler) ListTasks(ctx context.Context, req *todo.ListTasksRequest) (*todo.ListTasksResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	log.L().Info("ListTask request")
+	log.L().Infof("ListTask request: filter=%q workspace_id=%d assignee=%s",
+		req.GetFilter(), req.GetWorkspaceId(), req.GetAssigneeFilter())
 
-	taskModel, err := h.taskService.ListTask(ctx, "")
+	taskModel, err := h.taskService.SearchTasks(ctx, req.GetFilter(), req.GetWorkspaceId(), assigneeFilter(req.GetAssigneeFilter()))
 	if err != nil {
+		if errors.Is(err, service.ErrUnauthenticated) {
+			log.L().Warnf("ListTask failed: %v", err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, service.ErrWorkspaceNotFound) {
+			log.L().Warnf("ListTask failed: %v", err)
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, service.ErrWorkspacesDisabled) {
+			log.L().Warnf("ListTask failed: %v", err)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.L().Errorf("ListTask timeout exceeded")
 			return nil, status.Error(codes.DeadlineExceeded, "request timeout")
@@ -117,6 +212,10 @@ func (h *TaskHandler) UpdateTask(ctx context.Context, req *todo.UpdateTaskReques
 			log.L().Warnf("UpdateTask not found: id=%d", req.GetId())
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, service.ErrPermissionDenied) {
+			log.L().Warnf("UpdateTask failed: %v", err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.L().Errorf("UpdateTask timeout exceeded")
 			return nil, status.Error(codes.DeadlineExceeded, "request timeout")
@@ -135,11 +234,20 @@ func (h *TaskHandler) UpdateTask(ctx context.Context, req *todo.UpdateTaskReques
 	if req.Completed != nil {
 		taskModel.Completed = req.GetCompleted()
 	}
-	if err := h.taskService.UpdateTask(ctx, taskModel); err != nil {
+	updated, err := h.taskService.UpdateTask(ctx, taskModel)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidData) {
+			log.L().Warnf("UpdateTask failed: %v", err)
+			return nil, invalidArgument(err)
+		}
 		if errors.Is(err, service.ErrTaskNotFound) {
 			log.L().Warnf("UpdateTask not found: id=%d", req.GetId())
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, service.ErrPermissionDenied) {
+			log.L().Warnf("UpdateTask failed: %v", err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.L().Errorf("UpdateTask timeout exceeded")
 			return nil, status.Error(codes.DeadlineExceeded, "request timeout")
@@ -149,7 +257,7 @@ func (h *TaskHandler) UpdateTask(ctx context.Context, req *todo.UpdateTaskReques
 	}
 
 	log.L().Infof("UpdateTask success: id=%d", req.GetId())
-	return convertStruct(taskModel), nil
+	return convertStruct(updated), nil
 }
 
 func (h *TaskHandler) DeleteTask(ctx context.Context, req *todo.DeleteTaskRequest) (*todo.DeleteTaskResponse, error) {
@@ -163,6 +271,10 @@ func (h *TaskHandler) DeleteTask(ctx context.Context, req *todo.DeleteTaskReques
 			log.L().Warnf("DeleteTask not found: id=%d", req.GetId())
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
+		if errors.Is(err, service.ErrPermissionDenied) {
+			log.L().Warnf("DeleteTask failed: %v", err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.L().Errorf("DeleteTask timeout exceeded")
 			return nil, status.Error(codes.DeadlineExceeded, "request timeout")
@@ -175,6 +287,43 @@ func (h *TaskHandler) DeleteTask(ctx context.Context, req *todo.DeleteTaskReques
 	return &todo.DeleteTaskResponse{}, nil
 }
 
+func (h *TaskHandler) GetCalendarFeed(ctx context.Context, req *todo.GetCalendarFeedRequest) (*todo.CalendarFeed, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("GetCalendarFeed request: rotate=%t", req.GetRotate())
+
+	token, err := h.taskService.CalendarFeedToken(ctx, req.GetRotate())
+	if err != nil {
+		if errors.Is(err, service.ErrUnauthenticated) {
+			log.L().Warnf("GetCalendarFeed failed: %v", err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, service.ErrFeedsDisabled) {
+			log.L().Warnf("GetCalendarFeed failed: %v", err)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.L().Errorf("GetCalendarFeed timeout exceeded")
+			return nil, status.Error(codes.DeadlineExceeded, "request timeout")
+		}
+		log.L().Errorf("GetCalendarFeed failed: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	log.L().Info("GetCalendarFeed success")
+	tenantID, _ := tenant.IDFrom(ctx)
+	return &todo.CalendarFeed{Path: calendar.Path(tenantID, token)}, nil
+}
+
+func invalidArgument(err error) error {
+	var verr *validation.Error
+	if errors.As(err, &verr) {
+		return verr.GRPCStatus().Err()
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
 func convertStruct(m *model.Model) *todo.Task {
 	desc := ""
 	if m.Description != nil {
@@ -186,7 +335,9 @@ func convertStruct(m *model.Model) *todo.Task {
 		Title:       m.Title,
 		Description: desc,
 		Completed:   m.Completed,
-		CreatedAt:   m.CreatedAt.Format(time.RFC3339),
-		UpdateAt:    m.UpdatedAt.Format(time.RFC3339),
+		CreatedAt:   timestamppb.New(m.CreatedAt),
+		UpdatedAt:   timestamppb.New(m.UpdatedAt),
+		WorkspaceId: m.WorkspaceID,
+		AssigneeIds: m.AssigneeIDs,
 	}
 }
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errHistoryDisabled = status.Error(codes.Unimplemented, "task history is not enabled")
+
+func (h *TaskHandler) GetTaskHistory(ctx context.Context, req *todo.GetTaskHistoryRequest) (*todo.GetTaskHistoryResponse, error) {
+	if h.historyService == nil {
+		return nil, errHistoryDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("GetTaskHistory request: task_id=%d", req.GetTaskId())
+
+	page, err := h.historyService.TaskHistory(ctx, req.GetTaskId(), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, historyError("GetTaskHistory", err)
+	}
+
+	log.L().Infof("GetTaskHistory success: task_id=%d count=%d", req.GetTaskId(), len(page.Changes))
+	return &todo.GetTaskHistoryResponse{
+		Changes:       convertChanges(page.Changes),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func (h *TaskHandler) ListTaskChanges(ctx context.Context, req *todo.ListTaskChangesRequest) (*todo.ListTaskChangesResponse, error) {
+	if h.historyService == nil {
+		return nil, errHistoryDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("ListTaskChanges request: start=%s end=%s", req.GetStartTime().AsTime(), req.GetEndTime().AsTime())
+
+	var violations []validation.Violation
+	if err := req.GetStartTime().CheckValid(); err != nil {
+		violations = append(violations, validation.Violation{Field: "start_time", Description: "must be a valid timestamp"})
+	}
+	if err := req.GetEndTime().CheckValid(); err != nil {
+		violations = append(violations, validation.Violation{Field: "end_time", Description: "must be a valid timestamp"})
+	}
+	if len(violations) > 0 {
+		return nil, historyError("ListTaskChanges", &validation.Error{Violations: violations})
+	}
+
+	start, end := req.GetStartTime().AsTime(), req.GetEndTime().AsTime()
+	page, err := h.historyService.ChangesBetween(ctx, start, end, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, historyError("ListTaskChanges", err)
+	}
+
+	log.L().Infof("ListTaskChanges success: count=%d", len(page.Changes))
+	return &todo.ListTaskChangesResponse{
+		Changes:       convertChanges(page.Changes),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+func historyError(method string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidData):
+		log.L().Warnf("%s failed: %v", method, err)
+		return invalidArgument(err)
+	case errors.Is(err, service.ErrNoHistory), errors.Is(err, service.ErrTaskNotFound):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrUnauthenticated):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func convertChanges(changes []*model.TaskChange) []*todo.TaskChange {
+	resp := make([]*todo.TaskChange, len(changes))
+	for i, c := range changes {
+		tc := &todo.TaskChange{
+			Id:        c.ID,
+			TaskId:    c.TaskID,
+			Action:    c.Action,
+			Actor:     c.Actor,
+			ChangedAt: timestamppb.New(c.ChangedAt),
+		}
+		for _, f := range c.Changes {
+			tc.Changes = append(tc.Changes, &todo.FieldChange{Field: f.Field, OldValue: f.Old, NewValue: f.New})
+		}
+		resp[i] = tc
+	}
+	return resp
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *TaskHandler) ListTaskRevisions(ctx context.Context, req *todo.ListTaskRevisionsRequest) (*todo.ListTaskRevisionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("ListTaskRevisions request: task_id=%d", req.GetTaskId())
+
+	revisions, err := h.taskService.ListTaskRevisions(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, revisionError("ListTaskRevisions", err)
+	}
+
+	resp := &todo.ListTaskRevisionsResponse{Revisions: make([]*todo.TaskRevision, len(revisions))}
+	for i, rev := range revisions {
+		resp.Revisions[i] = &todo.TaskRevision{
+			Revision:  rev.Revision,
+			Task:      convertStruct(rev.Task),
+			Actor:     rev.Actor,
+			RevisedAt: timestamppb.New(rev.RevisedAt),
+		}
+	}
+
+	log.L().Infof("ListTaskRevisions success: task_id=%d count=%d", req.GetTaskId(), len(revisions))
+	return resp, nil
+}
+
+func (h *TaskHandler) RevertTask(ctx context.Context, req *todo.RevertTaskRequest) (*todo.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("RevertTask request: task_id=%d revision=%d", req.GetTaskId(), req.GetRevision())
+
+	task, err := h.taskService.RevertTask(ctx, req.GetTaskId(), req.GetRevision())
+	if err != nil {
+		return nil, revisionError("RevertTask", err)
+	}
+
+	log.L().Infof("RevertTask success: task_id=%d revision=%d", req.GetTaskId(), req.GetRevision())
+	return convertStruct(task), nil
+}
+
+func (h *TaskHandler) RestoreTask(ctx context.Context, req *todo.RestoreTaskRequest) (*todo.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("RestoreTask request: task_id=%d", req.GetTaskId())
+
+	task, err := h.taskService.RestoreTask(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, revisionError("RestoreTask", err)
+	}
+
+	log.L().Infof("RestoreTask success: task_id=%d", req.GetTaskId())
+	return convertStruct(task), nil
+}
+
+func revisionError(method string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound), errors.Is(err, service.ErrRevisionNotFound):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrTaskNotDeleted):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrRevisionsDisabled):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var shareRoles = map[string]todo.ShareRole{
+	model.RoleViewer: todo.ShareRole_SHARE_ROLE_VIEWER,
+	model.RoleEditor: todo.ShareRole_SHARE_ROLE_EDITOR,
+}
+
+func (h *TaskHandler) ShareTask(ctx context.Context, req *todo.ShareTaskRequest) (*todo.TaskShare, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("ShareTask request: task_id=%d user=%q role=%s", req.GetTaskId(), req.GetUser(), req.GetRole())
+
+	share, err := h.taskService.ShareTask(ctx, req.GetTaskId(), req.GetUser(), sharedRole(req.GetRole()))
+	if err != nil {
+		return nil, shareError("ShareTask", err)
+	}
+
+	log.L().Infof("ShareTask success: task_id=%d user=%q", share.TaskID, share.User)
+	return convertTaskShare(share), nil
+}
+
+func (h *TaskHandler) UnshareTask(ctx context.Context, req *todo.UnshareTaskRequest) (*todo.UnshareTaskResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("UnshareTask request: task_id=%d user=%q", req.GetTaskId(), req.GetUser())
+
+	if err := h.taskService.UnshareTask(ctx, req.GetTaskId(), req.GetUser()); err != nil {
+		return nil, shareError("UnshareTask", err)
+	}
+
+	log.L().Infof("UnshareTask success: task_id=%d user=%q", req.GetTaskId(), req.GetUser())
+	return &todo.UnshareTaskResponse{}, nil
+}
+
+func (h *TaskHandler) ListTaskShares(ctx context.Context, req *todo.ListTaskSharesRequest) (*todo.ListTaskSharesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	shares, err := h.taskService.ListTaskShares(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, shareError("ListTaskShares", err)
+	}
+
+	resp := &todo.ListTaskSharesResponse{Shares: make([]*todo.TaskShare, len(shares))}
+	for i, s := range shares {
+		resp.Shares[i] = convertTaskShare(s)
+	}
+
+	log.L().Infof("ListTaskShares success: task_id=%d count=%d", req.GetTaskId(), len(shares))
+	return resp, nil
+}
+
+func (h *TaskHandler) CreateShareLink(ctx context.Context, req *todo.CreateShareLinkRequest) (*todo.ShareLink, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("CreateShareLink request: task_id=%d ttl_seconds=%d", req.GetTaskId(), req.GetTtlSeconds())
+
+	link, err := h.taskService.CreateShareLink(ctx, req.GetTaskId(), time.Duration(req.GetTtlSeconds())*time.Second)
+	if err != nil {
+		return nil, shareError("CreateShareLink", err)
+	}
+
+	log.L().Infof("CreateShareLink success: id=%d", link.ID)
+	return convertShareLink(link), nil
+}
+
+func (h *TaskHandler) ListShareLinks(ctx context.Context, req *todo.ListShareLinksRequest) (*todo.ListShareLinksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	links, err := h.taskService.ListShareLinks(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, shareError("ListShareLinks", err)
+	}
+
+	resp := &todo.ListShareLinksResponse{Links: make([]*todo.ShareLink, len(links))}
+	for i, l := range links {
+		resp.Links[i] = convertShareLink(l)
+	}
+
+	log.L().Infof("ListShareLinks success: task_id=%d count=%d", req.GetTaskId(), len(links))
+	return resp, nil
+}
+
+func (h *TaskHandler) RevokeShareLink(ctx context.Context, req *todo.RevokeShareLinkRequest) (*todo.ShareLink, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("RevokeShareLink request: id=%d", req.GetId())
+
+	link, err := h.taskService.RevokeShareLink(ctx, req.GetId())
+	if err != nil {
+		return nil, shareError("RevokeShareLink", err)
+	}
+
+	log.L().Infof("RevokeShareLink success: id=%d", link.ID)
+	return convertShareLink(link), nil
+}
+
+func (h *TaskHandler) GetSharedTask(ctx context.Context, req *todo.GetSharedTaskRequest) (*todo.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	task, err := h.taskService.SharedTask(ctx, req.GetToken())
+	if err != nil {
+		return nil, shareError("GetSharedTask", err)
+	}
+
+	log.L().Infof("GetSharedTask success: id=%d", task.ID)
+	return convertStruct(task), nil
+}
+
+func shareError(method string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidData):
+		log.L().Warnf("%s failed: %v", method, err)
+		return invalidArgument(err)
+	case errors.Is(err, service.ErrTaskNotFound), errors.Is(err, service.ErrShareNotFound),
+		errors.Is(err, service.ErrShareLinkNotFound):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrSharesDisabled):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func sharedRole(r todo.ShareRole) string {
+	for role, pr := range shareRoles {
+		if pr == r {
+			return role
+		}
+	}
+	return ""
+}
+
+func convertTaskShare(s *model.TaskShare) *todo.TaskShare {
+	return &todo.TaskShare{
+		TaskId:    s.TaskID,
+		User:      s.User,
+		Role:      shareRoles[s.Role],
+		GrantedBy: s.GrantedBy,
+		GrantedAt: timestamppb.New(s.GrantedAt),
+	}
+}
+
+func convertShareLink(l *model.ShareLink) *todo.ShareLink {
+	link := &todo.ShareLink{
+		Id:        l.ID,
+		TaskId:    l.TaskID,
+		Token:     l.Token,
+		CreatedBy: l.CreatedBy,
+		CreatedAt: timestamppb.New(l.CreatedAt),
+		ExpiresAt: timestamppb.New(l.ExpiresAt),
+	}
+	if l.RevokedAt != nil {
+		link.RevokedAt = timestamppb.New(*l.RevokedAt)
+	}
+	return link
+}
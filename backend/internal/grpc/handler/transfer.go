@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	"github.com/Elmar006/todo_grpc/internal/taskio"
+	"github.com/Elmar006/todo_grpc/internal/timezone"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize bounds the payload of a single ExportTasksResponse.
+const exportChunkSize = 32 << 10
+
+func (h *TaskHandler) ExportTasks(req *todo.ExportTasksRequest, stream grpc.ServerStreamingServer[todo.ExportTasksResponse]) error {
+	log.L().Infof("ExportTasks request: format=%s filter=%q", req.GetFormat(), req.GetFilter())
+
+	format, err := taskFormat(req.GetFormat())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	loc, err := timezone.FromIncoming(stream.Context())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	w := &chunkWriter{stream: stream}
+	enc, err := taskio.NewEncoderIn(format, w, loc)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := h.taskService.ExportTasks(stream.Context(), req.GetFilter(), enc); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return status.Error(codes.Canceled, "export canceled")
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		log.L().Errorf("ExportTasks failed: %v", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	log.L().Infof("ExportTasks success: bytes=%d", w.written)
+	return nil
+}
+
+func (h *TaskHandler) ImportTasks(stream grpc.ClientStreamingServer[todo.ImportTasksRequest, todo.ImportTasksResponse]) error {
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "first message must carry import options")
+		}
+		return err
+	}
+	opts := first.GetOptions()
+	if opts == nil {
+		return status.Error(codes.InvalidArgument, "first message must carry import options")
+	}
+
+	log.L().Infof("ImportTasks request: format=%s dry_run=%t", opts.GetFormat(), opts.GetDryRun())
+
+	format, err := taskFormat(opts.GetFormat())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go pipeImportData(stream, pw)
+
+	dec, err := taskio.NewDecoder(format, pr, opts.GetFieldMapping())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res, err := h.taskService.ImportTasks(stream.Context(), dec, service.ImportOptions{
+		DryRun:      opts.GetDryRun(),
+		OnDuplicate: duplicatePolicy(opts.GetOnDuplicate()),
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		if errors.Is(err, service.ErrUnauthenticated) {
+			log.L().Warnf("ImportTasks failed: %v", err)
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, context.Canceled) {
+			return status.Error(codes.Canceled, "import canceled")
+		}
+		log.L().Errorf("ImportTasks failed: %v", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	resp := &todo.ImportTasksResponse{
+		Total:    int64(res.Total),
+		Imported: int64(res.Imported),
+		Skipped:  int64(res.Skipped),
+		DryRun:   res.DryRun,
+	}
+	for _, e := range res.Errors {
+		resp.Errors = append(resp.Errors, &todo.ImportRowError{Row: int64(e.Row), Message: e.Message})
+	}
+
+	log.L().Infof("ImportTasks success: total=%d imported=%d skipped=%d errors=%d",
+		res.Total, res.Imported, res.Skipped, len(res.Errors))
+	return stream.SendAndClose(resp)
+}
+
+// pipeImportData feeds the data chunks of an import stream into pw. A
+// receive error, including a rejected message, is handed to the decoder
+// so the import stops with it.
+func pipeImportData(stream grpc.ClientStreamingServer[todo.ImportTasksRequest, todo.ImportTasksResponse], pw *io.PipeWriter) {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			pw.Close()
+			return
+		}
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if req.GetOptions() != nil {
+			pw.CloseWithError(status.Error(codes.InvalidArgument, "import options may only be sent once"))
+			return
+		}
+		if _, err := pw.Write(req.GetData()); err != nil {
+			return
+		}
+	}
+}
+
+// chunkWriter sends everything written to it as ExportTasksResponse
+// messages of at most exportChunkSize bytes.
+type chunkWriter struct {
+	stream  grpc.ServerStreamingServer[todo.ExportTasksResponse]
+	written int
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), exportChunkSize)]
+		if err := w.stream.Send(&todo.ExportTasksResponse{Data: chunk}); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		w.written += len(chunk)
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+func taskFormat(f todo.TaskFormat) (taskio.Format, error) {
+	switch f {
+	case todo.TaskFormat_TASK_FORMAT_JSONL:
+		return taskio.JSONLines, nil
+	case todo.TaskFormat_TASK_FORMAT_CSV:
+		return taskio.CSV, nil
+	case todo.TaskFormat_TASK_FORMAT_TODO_TXT:
+		return taskio.TodoTxt, nil
+	case todo.TaskFormat_TASK_FORMAT_ICALENDAR:
+		return taskio.ICalendar, nil
+	}
+	return 0, taskio.ErrUnknownFormat
+}
+
+func duplicatePolicy(p todo.DuplicatePolicy) service.DuplicatePolicy {
+	switch p {
+	case todo.DuplicatePolicy_DUPLICATE_POLICY_ALLOW:
+		return service.DuplicateAllow
+	case todo.DuplicatePolicy_DUPLICATE_POLICY_REJECT:
+		return service.DuplicateReject
+	}
+	return service.DuplicateSkip
+}
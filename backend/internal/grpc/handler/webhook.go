@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errWebhooksDisabled = status.Error(codes.Unimplemented, "webhooks are not enabled")
+
+func (h *TaskHandler) CreateWebhook(ctx context.Context, req *todo.CreateWebhookRequest) (*todo.Webhook, error) {
+	if h.webhookService == nil {
+		return nil, errWebhooksDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("CreateWebhook request: url=%q events=%v", req.GetUrl(), req.GetEvents())
+
+	hook, err := h.webhookService.CreateWebhook(ctx, req.GetUrl(), req.GetEvents(), req.GetSecret())
+	if err != nil {
+		return nil, webhookError("CreateWebhook", err)
+	}
+
+	log.L().Infof("CreateWebhook success: id=%d", hook.ID)
+	resp := convertWebhook(hook)
+	resp.Secret = hook.Secret
+	return resp, nil
+}
+
+func (h *TaskHandler) ListWebhooks(ctx context.Context, req *todo.ListWebhooksRequest) (*todo.ListWebhooksResponse, error) {
+	if h.webhookService == nil {
+		return nil, errWebhooksDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	hooks, err := h.webhookService.ListWebhooks(ctx)
+	if err != nil {
+		return nil, webhookError("ListWebhooks", err)
+	}
+
+	resp := &todo.ListWebhooksResponse{Webhooks: make([]*todo.Webhook, len(hooks))}
+	for i, hook := range hooks {
+		resp.Webhooks[i] = convertWebhook(hook)
+	}
+
+	log.L().Infof("ListWebhooks success: count=%d", len(hooks))
+	return resp, nil
+}
+
+func (h *TaskHandler) DeleteWebhook(ctx context.Context, req *todo.DeleteWebhookRequest) (*todo.DeleteWebhookResponse, error) {
+	if h.webhookService == nil {
+		return nil, errWebhooksDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("DeleteWebhook request: id=%d", req.GetId())
+
+	if err := h.webhookService.DeleteWebhook(ctx, req.GetId()); err != nil {
+		return nil, webhookError("DeleteWebhook", err)
+	}
+
+	log.L().Infof("DeleteWebhook success: id=%d", req.GetId())
+	return &todo.DeleteWebhookResponse{}, nil
+}
+
+func (h *TaskHandler) ListWebhookDeliveries(ctx context.Context, req *todo.ListWebhookDeliveriesRequest) (*todo.ListWebhookDeliveriesResponse, error) {
+	if h.webhookService == nil {
+		return nil, errWebhooksDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	deliveries, err := h.webhookService.ListWebhookDeliveries(ctx, req.GetWebhookId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, webhookError("ListWebhookDeliveries", err)
+	}
+
+	resp := &todo.ListWebhookDeliveriesResponse{Deliveries: make([]*todo.WebhookDelivery, len(deliveries))}
+	for i, d := range deliveries {
+		resp.Deliveries[i] = convertDelivery(d)
+	}
+
+	log.L().Infof("ListWebhookDeliveries success: webhook=%d count=%d", req.GetWebhookId(), len(deliveries))
+	return resp, nil
+}
+
+func (h *TaskHandler) RetryWebhookDelivery(ctx context.Context, req *todo.RetryWebhookDeliveryRequest) (*todo.WebhookDelivery, error) {
+	if h.webhookService == nil {
+		return nil, errWebhooksDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("RetryWebhookDelivery request: id=%d", req.GetId())
+
+	d, err := h.webhookService.RetryWebhookDelivery(ctx, req.GetId())
+	if err != nil {
+		return nil, webhookError("RetryWebhookDelivery", err)
+	}
+
+	log.L().Infof("RetryWebhookDelivery success: id=%d", req.GetId())
+	return convertDelivery(d), nil
+}
+
+func webhookError(method string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthenticated):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrInvalidData):
+		log.L().Warnf("%s failed: %v", method, err)
+		return invalidArgument(err)
+	case errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrDeliveryNotFound):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// convertWebhook leaves the secret out; it is only shown on creation.
+func convertWebhook(hook *model.Webhook) *todo.Webhook {
+	return &todo.Webhook{
+		Id:        hook.ID,
+		Url:       hook.URL,
+		Events:    hook.Events,
+		CreatedAt: timestamppb.New(hook.CreatedAt),
+	}
+}
+
+func convertDelivery(d *model.WebhookDelivery) *todo.WebhookDelivery {
+	resp := &todo.WebhookDelivery{
+		Id:            d.ID,
+		WebhookId:     d.WebhookID,
+		EventId:       d.EventID,
+		EventType:     d.EventType,
+		Status:        d.Status,
+		Attempts:      int32(d.Attempts),
+		NextAttemptAt: timestamppb.New(d.NextAttemptAt),
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+	}
+	for _, a := range d.Log {
+		resp.Log = append(resp.Log, &todo.WebhookAttempt{
+			AttemptedAt: timestamppb.New(a.AttemptedAt),
+			StatusCode:  int32(a.StatusCode),
+			Error:       a.Error,
+			DurationMs:  a.Duration.Milliseconds(),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errWorkspacesDisabled = status.Error(codes.Unimplemented, "workspaces are not enabled")
+
+var workspaceRoles = map[string]todo.WorkspaceRole{
+	model.RoleOwner:  todo.WorkspaceRole_WORKSPACE_ROLE_OWNER,
+	model.RoleAdmin:  todo.WorkspaceRole_WORKSPACE_ROLE_ADMIN,
+	model.RoleEditor: todo.WorkspaceRole_WORKSPACE_ROLE_EDITOR,
+	model.RoleViewer: todo.WorkspaceRole_WORKSPACE_ROLE_VIEWER,
+}
+
+func (h *TaskHandler) CreateWorkspace(ctx context.Context, req *todo.CreateWorkspaceRequest) (*todo.Workspace, error) {
+	if h.workspaceService == nil {
+		return nil, errWorkspacesDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("CreateWorkspace request: name=%q", req.GetName())
+
+	ws, err := h.workspaceService.CreateWorkspace(ctx, req.GetName())
+	if err != nil {
+		return nil, workspaceError("CreateWorkspace", err)
+	}
+
+	log.L().Infof("CreateWorkspace success: id=%d", ws.ID)
+	return convertWorkspace(ws), nil
+}
+
+func (h *TaskHandler) ListWorkspaces(ctx context.Context, req *todo.ListWorkspacesRequest) (*todo.ListWorkspacesResponse, error) {
+	if h.workspaceService == nil {
+		return nil, errWorkspacesDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	workspaces, err := h.workspaceService.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, workspaceError("ListWorkspaces", err)
+	}
+
+	resp := &todo.ListWorkspacesResponse{Workspaces: make([]*todo.Workspace, len(workspaces))}
+	for i, ws := range workspaces {
+		resp.Workspaces[i] = convertWorkspace(ws)
+	}
+
+	log.L().Infof("ListWorkspaces success: count=%d", len(workspaces))
+	return resp, nil
+}
+
+func (h *TaskHandler) ListWorkspaceMembers(ctx context.Context, req *todo.ListWorkspaceMembersRequest) (*todo.ListWorkspaceMembersResponse, error) {
+	if h.workspaceService == nil {
+		return nil, errWorkspacesDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	members, err := h.workspaceService.ListMembers(ctx, req.GetWorkspaceId())
+	if err != nil {
+		return nil, workspaceError("ListWorkspaceMembers", err)
+	}
+
+	resp := &todo.ListWorkspaceMembersResponse{Members: make([]*todo.WorkspaceMember, len(members))}
+	for i, m := range members {
+		resp.Members[i] = convertWorkspaceMember(m)
+	}
+
+	log.L().Infof("ListWorkspaceMembers success: workspace_id=%d count=%d", req.GetWorkspaceId(), len(members))
+	return resp, nil
+}
+
+func (h *TaskHandler) SetWorkspaceMember(ctx context.Context, req *todo.SetWorkspaceMemberRequest) (*todo.WorkspaceMember, error) {
+	if h.workspaceService == nil {
+		return nil, errWorkspacesDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("SetWorkspaceMember request: workspace_id=%d member=%q role=%s",
+		req.GetWorkspaceId(), req.GetMember(), req.GetRole())
+
+	m, err := h.workspaceService.SetMember(ctx, req.GetWorkspaceId(), req.GetMember(), modelRole(req.GetRole()))
+	if err != nil {
+		return nil, workspaceError("SetWorkspaceMember", err)
+	}
+
+	log.L().Infof("SetWorkspaceMember success: workspace_id=%d member=%q", m.WorkspaceID, m.Member)
+	return convertWorkspaceMember(m), nil
+}
+
+func (h *TaskHandler) RemoveWorkspaceMember(ctx context.Context, req *todo.RemoveWorkspaceMemberRequest) (*todo.RemoveWorkspaceMemberResponse, error) {
+	if h.workspaceService == nil {
+		return nil, errWorkspacesDisabled
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("RemoveWorkspaceMember request: workspace_id=%d member=%q", req.GetWorkspaceId(), req.GetMember())
+
+	if err := h.workspaceService.RemoveMember(ctx, req.GetWorkspaceId(), req.GetMember()); err != nil {
+		return nil, workspaceError("RemoveWorkspaceMember", err)
+	}
+
+	log.L().Infof("RemoveWorkspaceMember success: workspace_id=%d member=%q", req.GetWorkspaceId(), req.GetMember())
+	return &todo.RemoveWorkspaceMemberResponse{}, nil
+}
+
+func (h *TaskHandler) AssignTask(ctx context.Context, req *todo.AssignTaskRequest) (*todo.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("AssignTask request: task_id=%d users=%q", req.GetTaskId(), req.GetUserIds())
+
+	task, err := h.taskService.AssignTask(ctx, req.GetTaskId(), req.GetUserIds())
+	if err != nil {
+		return nil, workspaceError("AssignTask", err)
+	}
+
+	log.L().Infof("AssignTask success: task_id=%d", task.ID)
+	return convertStruct(task), nil
+}
+
+func (h *TaskHandler) UnassignTask(ctx context.Context, req *todo.UnassignTaskRequest) (*todo.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	log.L().Infof("UnassignTask request: task_id=%d users=%q", req.GetTaskId(), req.GetUserIds())
+
+	task, err := h.taskService.UnassignTask(ctx, req.GetTaskId(), req.GetUserIds())
+	if err != nil {
+		return nil, workspaceError("UnassignTask", err)
+	}
+
+	log.L().Infof("UnassignTask success: task_id=%d", task.ID)
+	return convertStruct(task), nil
+}
+
+func workspaceError(method string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidData):
+		log.L().Warnf("%s failed: %v", method, err)
+		return invalidArgument(err)
+	case errors.Is(err, service.ErrTaskNotFound), errors.Is(err, service.ErrWorkspaceNotFound),
+		errors.Is(err, service.ErrMemberNotFound):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPermissionDenied):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrUnauthenticated):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrAssigneeNotMember), errors.Is(err, service.ErrLastOwner),
+		errors.Is(err, service.ErrWorkspacesDisabled):
+		log.L().Warnf("%s failed: %v", method, err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		log.L().Errorf("%s timeout exceeded", method)
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	}
+	log.L().Errorf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func modelRole(r todo.WorkspaceRole) string {
+	for role, pr := range workspaceRoles {
+		if pr == r {
+			return role
+		}
+	}
+	return ""
+}
+
+func assigneeFilter(f todo.AssigneeFilter) service.AssigneeFilter {
+	switch f {
+	case todo.AssigneeFilter_ASSIGNEE_FILTER_ASSIGNED_TO_ME:
+		return service.AssignedToMe
+	case todo.AssigneeFilter_ASSIGNEE_FILTER_UNASSIGNED:
+		return service.Unassigned
+	}
+	return service.AnyAssignee
+}
+
+func convertWorkspace(ws *model.Workspace) *todo.Workspace {
+	return &todo.Workspace{
+		Id:        ws.ID,
+		Name:      ws.Name,
+		CreatedBy: ws.CreatedBy,
+		CreatedAt: timestamppb.New(ws.CreatedAt),
+		Role:      workspaceRoles[ws.Role],
+	}
+}
+
+func convertWorkspaceMember(m *model.WorkspaceMember) *todo.WorkspaceMember {
+	return &todo.WorkspaceMember{
+		WorkspaceId: m.WorkspaceID,
+		Member:      m.Member,
+		Role:        workspaceRoles[m.Role],
+		AddedAt:     timestamppb.New(m.AddedAt),
+	}
+}
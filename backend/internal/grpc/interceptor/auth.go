@@ -0,0 +1,93 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Auth attaches the caller's principal to the context: the bearer token
+// user if one is sent, otherwise the subject of a verified client
+// certificate, also when presented to the HTTP gateway. Calls with
+// neither stay anonymous.
+func Auth(a *auth.TokenAuthenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, a)
+		if err != nil {
+			log.L().Warnf("%s rejected: %v", info.FullMethod, err)
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth is the streaming counterpart of Auth.
+func StreamAuth(a *auth.TokenAuthenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), a)
+		if err != nil {
+			log.L().Warnf("%s rejected: %v", info.FullMethod, err)
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream overrides the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, a *auth.TokenAuthenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		if subject, ok := certSubject(ctx); ok {
+			return auth.WithPrincipal(ctx, subject), nil
+		}
+		return ctx, nil
+	}
+
+	principal, err := a.Authenticate(values[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+func certSubject(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	// The HTTP gateway terminates TLS itself and passes on the subject of
+	// the certificate its client presented. Only in-process callers may
+	// do so; the gateway drops the key from client requests.
+	if p.Addr != nil && p.Addr.Network() == "bufconn" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(auth.CertSubjectKey); len(values) > 0 && values[0] != "" {
+			return values[0], true
+		}
+		return "", false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return "", false
+	}
+	return auth.CertSubject(&info.State)
+}
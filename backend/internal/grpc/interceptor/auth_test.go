@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type bufconnAddr struct{}
+
+func (bufconnAddr) Network() string { return "bufconn" }
+func (bufconnAddr) String() string  { return "bufconn" }
+
+func TestAuthCertSubject(t *testing.T) {
+	tcp := &net.TCPAddr{IP: net.IPv4(203, 0, 113, 7), Port: 4000}
+	verified := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "alice"}}}},
+	}}
+
+	tests := []struct {
+		name     string
+		peer     *peer.Peer
+		md       metadata.MD
+		wantUser string
+	}{
+		{"client certificate", &peer.Peer{Addr: tcp, AuthInfo: verified}, nil, "alice"},
+		{"forwarded by the gateway", &peer.Peer{Addr: bufconnAddr{}}, metadata.Pairs(auth.CertSubjectKey, "bob"), "bob"},
+		{"gateway without certificate", &peer.Peer{Addr: bufconnAddr{}}, nil, ""},
+		{"sent by a network client", &peer.Peer{Addr: tcp}, metadata.Pairs(auth.CertSubjectKey, "bob"), ""},
+		{"sent next to a certificate", &peer.Peer{Addr: tcp, AuthInfo: verified}, metadata.Pairs(auth.CertSubjectKey, "bob"), "alice"},
+	}
+
+	intercept := Auth(auth.NewTokenAuthenticator(nil))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), tt.peer)
+			ctx = metadata.NewIncomingContext(ctx, tt.md)
+
+			var got string
+			_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(ctx context.Context, _ any) (any, error) {
+				got, _ = auth.PrincipalFrom(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.wantUser {
+				t.Errorf("expected principal %q, got %q", tt.wantUser, got)
+			}
+		})
+	}
+}
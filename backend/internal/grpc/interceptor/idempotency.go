@@ -0,0 +1,142 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// IdempotencyHeader is the metadata key carrying an idempotency key.
+	IdempotencyHeader = "idempotency-key"
+	// ReplayedHeader is set on responses replayed for a repeated key.
+	ReplayedHeader = "idempotent-replayed"
+
+	maxIdempotencyKeyLen = 255
+)
+
+// idempotentMethods are the mutating unary methods that honour an
+// idempotency key. Backup is not one: a snapshot can take longer than a
+// claim is held, so a retry could take a second one.
+var idempotentMethods = map[string]bool{
+	todo.TodoService_CreateTask_FullMethodName:            true,
+	todo.TodoService_UpdateTask_FullMethodName:            true,
+	todo.TodoService_DeleteTask_FullMethodName:            true,
+	todo.TodoService_CreateWebhook_FullMethodName:         true,
+	todo.TodoService_DeleteWebhook_FullMethodName:         true,
+	todo.TodoService_RetryWebhookDelivery_FullMethodName:  true,
+	todo.TodoService_RevertTask_FullMethodName:            true,
+	todo.TodoService_RestoreTask_FullMethodName:           true,
+	todo.TodoService_AddComment_FullMethodName:            true,
+	todo.TodoService_EditComment_FullMethodName:           true,
+	todo.TodoService_DeleteComment_FullMethodName:         true,
+	todo.TodoService_DeleteAttachment_FullMethodName:      true,
+	todo.TodoService_CreateWorkspace_FullMethodName:       true,
+	todo.TodoService_SetWorkspaceMember_FullMethodName:    true,
+	todo.TodoService_RemoveWorkspaceMember_FullMethodName: true,
+	todo.TodoService_AssignTask_FullMethodName:            true,
+	todo.TodoService_UnassignTask_FullMethodName:          true,
+	todo.TodoService_ShareTask_FullMethodName:             true,
+	todo.TodoService_UnshareTask_FullMethodName:           true,
+	todo.TodoService_CreateShareLink_FullMethodName:       true,
+	todo.TodoService_RevokeShareLink_FullMethodName:       true,
+}
+
+// Idempotency makes mutating calls that carry an idempotency-key header
+// safe to retry: the first successful response is stored, and a call
+// repeating the key and request gets that response back without running
+// again. Reusing a key for a different request is rejected. Failed calls
+// are not stored. Keys are scoped to the caller, so anonymous calls that
+// carry one are rejected.
+func Idempotency(s *service.IdempotencyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		msg, ok := req.(proto.Message)
+		if !ok || !idempotentMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(IdempotencyHeader)
+		if len(values) == 0 {
+			return handler(ctx, req)
+		}
+		key := values[0]
+		if key == "" || len(key) > maxIdempotencyKeyLen {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must be 1 to %d bytes", IdempotencyHeader, maxIdempotencyKeyLen)
+		}
+
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		sum := sha256.Sum256(body)
+
+		claim, done, err := s.Claim(ctx, info.FullMethod, key, hex.EncodeToString(sum[:]))
+		switch {
+		case errors.Is(err, service.ErrUnauthenticated):
+			log.L().Warnf("%s rejected: %s without a principal", info.FullMethod, IdempotencyHeader)
+			return nil, status.Errorf(codes.Unauthenticated, "%s requires an authenticated caller", IdempotencyHeader)
+		case errors.Is(err, service.ErrIdempotencyKeyReused):
+			log.L().Warnf("%s rejected: %v", info.FullMethod, err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrIdempotencyKeyInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case err != nil:
+			log.L().Errorf("%s idempotency check failed: %v", info.FullMethod, err)
+			return nil, status.Error(codes.Internal, "internal error")
+		case done != nil:
+			return replay(ctx, info.FullMethod, done.ResponseType, done.Response)
+		}
+
+		// The outcome is recorded even if the client has gone away, since
+		// that is when it retries.
+		storeCtx := context.WithoutCancel(ctx)
+		resp, err := handler(ctx, req)
+		if err != nil {
+			if relErr := s.Release(storeCtx, claim); relErr != nil {
+				log.L().Errorf("%s release idempotency key: %v", info.FullMethod, relErr)
+			}
+			return nil, err
+		}
+
+		if out, ok := resp.(proto.Message); ok {
+			data, err := proto.Marshal(out)
+			if err == nil {
+				err = s.Complete(storeCtx, claim, string(out.ProtoReflect().Descriptor().FullName()), data)
+			}
+			if err != nil {
+				log.L().Errorf("%s store idempotent response: %v", info.FullMethod, err)
+			}
+		}
+		return resp, nil
+	}
+}
+
+func replay(ctx context.Context, method, responseType string, response []byte) (any, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(responseType))
+	if err != nil {
+		log.L().Errorf("%s replay: %v", method, err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(response, msg); err != nil {
+		log.L().Errorf("%s replay: %v", method, err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(ReplayedHeader, "true"))
+	log.L().Infof("%s replayed for idempotency key", method)
+	return msg, nil
+}
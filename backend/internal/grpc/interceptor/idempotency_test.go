@@ -0,0 +1,123 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+	"github.com/Elmar006/todo_grpc/internal/service"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func newIdempotencyRepo(t *testing.T) *repository.RepositoryDB {
+	t.Helper()
+	pool, err := db.Open(filepath.Join(t.TempDir(), "todo.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return &repository.RepositoryDB{Pool: pool}
+}
+
+func TestIdempotency(t *testing.T) {
+	repo := newIdempotencyRepo(t)
+	intercept := Idempotency(service.NewIdempotencyService(repo))
+
+	var calls int64
+	var fail error
+	info := &grpc.UnaryServerInfo{FullMethod: todo.TodoService_CreateTask_FullMethodName}
+	handler := func(ctx context.Context, req any) (any, error) {
+		if fail != nil {
+			return nil, fail
+		}
+		calls++
+		return &todo.Task{Id: calls, Title: req.(*todo.CreateTaskRequest).GetTitle()}, nil
+	}
+	call := func(principal, key, title string) (*todo.Task, error) {
+		ctx := auth.WithPrincipal(context.Background(), principal)
+		if key != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyHeader, key))
+		}
+		resp, err := intercept(ctx, &todo.CreateTaskRequest{Title: title}, info, handler)
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*todo.Task), nil
+	}
+
+	first, err := call("alice", "k1", "Buy milk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	retry, err := call("alice", "k1", "Buy milk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(first, retry) || calls != 1 {
+		t.Errorf("expected the retry to replay %v without a call, got %v after %d calls", first, retry, calls)
+	}
+
+	if _, err := call("alice", "k1", "Buy bread"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a reused key, got %v", err)
+	}
+	if _, err := call("bob", "k1", "Buy bread"); err != nil || calls != 2 {
+		t.Errorf("expected keys to be scoped to the caller, got %v after %d calls", err, calls)
+	}
+	if _, err := call("alice", "", "Buy milk"); err != nil || calls != 3 {
+		t.Errorf("expected a call without a key to run, got %v after %d calls", err, calls)
+	}
+	if _, err := call("", "k1", "Buy milk"); status.Code(err) != codes.Unauthenticated || calls != 3 {
+		t.Errorf("expected Unauthenticated for an anonymous key, got %v after %d calls", err, calls)
+	}
+	if _, err := call("", "", "Buy milk"); err != nil || calls != 4 {
+		t.Errorf("expected an anonymous call without a key to run, got %v after %d calls", err, calls)
+	}
+
+	// A failed call does not keep its key.
+	fail = status.Error(codes.Unavailable, "try again")
+	if _, err := call("alice", "k2", "Buy eggs"); !errors.Is(err, fail) {
+		t.Fatalf("expected the handler error, got %v", err)
+	}
+	fail = nil
+	if _, err := call("alice", "k2", "Buy eggs"); err != nil || calls != 5 {
+		t.Errorf("expected the retry of a failed call to run, got %v after %d calls", err, calls)
+	}
+}
+
+func TestIdempotencyKeysExpire(t *testing.T) {
+	repo := newIdempotencyRepo(t)
+	s := service.NewIdempotencyService(repo, service.WithIdempotencyTTL(time.Millisecond))
+	ctx := auth.WithPrincipal(context.Background(), "alice")
+
+	claim, done, err := s.Claim(ctx, "/test/Method", "k", "hash")
+	if err != nil || done != nil {
+		t.Fatalf("expected a new claim, got %v, %v", done, err)
+	}
+	if err := s.Complete(ctx, claim, "todoService.Task", nil); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if _, done, err := s.Claim(ctx, "/test/Method", "k", "other"); err != nil || done != nil {
+		t.Errorf("expected an expired key to be claimed again, got %v, %v", done, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	n, err := repo.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 expired key deleted, got %d", n)
+	}
+}
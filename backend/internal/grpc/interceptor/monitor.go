@@ -0,0 +1,29 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/monitor"
+
+	"google.golang.org/grpc"
+)
+
+// Track lists calls with m while they run. It must follow Auth so calls
+// are listed with their principal.
+func Track(m *monitor.Monitor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		principal, _ := auth.PrincipalFrom(ctx)
+		defer m.Begin(ctx, info.FullMethod, principal, false)()
+		return handler(ctx, req)
+	}
+}
+
+// StreamTrack lists streams with m until they end.
+func StreamTrack(m *monitor.Monitor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		principal, _ := auth.PrincipalFrom(ss.Context())
+		defer m.Begin(ss.Context(), info.FullMethod, principal, true)()
+		return handler(srv, ss)
+	}
+}
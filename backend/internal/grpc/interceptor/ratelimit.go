@@ -0,0 +1,83 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/ratelimit"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimit rejects calls with ResourceExhausted once the caller's token
+// bucket for the method is empty. Callers are keyed by principal, falling
+// back to the peer IP for anonymous requests.
+func RateLimit(l *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := allow(ctx, l, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit takes one token when a stream is opened.
+func StreamRateLimit(l *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allow(ss.Context(), l, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func allow(ctx context.Context, l *ratelimit.Limiter, fullMethod string) error {
+	key := clientKey(ctx)
+	ok, retryAfter := l.Allow(fullMethod, key)
+	if ok {
+		return nil
+	}
+
+	log.L().Warnf("%s rate limited: client=%s", fullMethod, key)
+
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func clientKey(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFrom(ctx); ok {
+		return "user:" + principal
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "ip:unknown"
+	}
+
+	// In-process callers such as the HTTP gateway pass on the address of
+	// the client they serve.
+	if p.Addr.Network() == "bufconn" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			return "ip:" + values[0]
+		}
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return "ip:" + host
+}
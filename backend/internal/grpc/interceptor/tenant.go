@@ -0,0 +1,71 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Tenant resolves the caller's tenant and attaches its database to the
+// context for the duration of the call. gRPC's own services, such as
+// reflection, need no tenant.
+func Tenant(r *tenant.Resolver, m *tenant.Manager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, "/grpc.") {
+			return handler(ctx, req)
+		}
+
+		ctx, release, err := withTenant(ctx, r, m, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// StreamTenant keeps the tenant database in use until the stream ends.
+func StreamTenant(r *tenant.Resolver, m *tenant.Manager) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, "/grpc.") {
+			return handler(srv, ss)
+		}
+
+		ctx, release, err := withTenant(ss.Context(), r, m, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withTenant(ctx context.Context, r *tenant.Resolver, m *tenant.Manager, fullMethod string) (context.Context, func(), error) {
+	id, err := r.Resolve(ctx)
+	if err != nil {
+		log.L().Warnf("%s rejected: %v", fullMethod, err)
+		code := codes.PermissionDenied
+		if errors.Is(err, tenant.ErrUnauthenticated) {
+			code = codes.Unauthenticated
+		}
+		return nil, nil, status.Error(code, err.Error())
+	}
+
+	db, release, err := m.Acquire(ctx, id)
+	if errors.Is(err, tenant.ErrUnknownTenant) {
+		log.L().Warnf("%s rejected: tenant %s has not been created", fullMethod, id)
+		return nil, nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		log.L().Errorf("%s: tenant %s unavailable: %v", fullMethod, id, err)
+		return nil, nil, status.Error(codes.Unavailable, "tenant database unavailable")
+	}
+	return tenant.WithDB(ctx, id, db), release, nil
+}
@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"context"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Validation rejects request messages that break the validator rules
+// before they reach the handler.
+func Validation(v *validation.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := v.Validate(msg); err != nil {
+				log.L().Warnf("%s rejected: %v", info.FullMethod, err)
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidation checks every message a client sends on a stream.
+func StreamValidation(v *validation.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss, validator: v, method: info.FullMethod})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+	validator *validation.Validator
+	method    string
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		if err := s.validator.Validate(msg); err != nil {
+			log.L().Warnf("%s rejected: %v", s.method, err)
+			return err
+		}
+	}
+	return nil
+}
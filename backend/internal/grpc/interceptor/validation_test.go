@@ -0,0 +1,110 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/validation"
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type echoServer struct {
+	todo.UnimplementedTodoServiceServer
+}
+
+func (echoServer) CreateTask(_ context.Context, req *todo.CreateTaskRequest) (*todo.Task, error) {
+	return &todo.Task{Id: 1, Title: req.GetTitle()}, nil
+}
+
+func (echoServer) UploadAttachment(stream grpc.ClientStreamingServer[todo.UploadAttachmentRequest, todo.Attachment]) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&todo.Attachment{Id: 1})
+}
+
+func newValidatingClient(t *testing.T) todo.TodoServiceClient {
+	t.Helper()
+
+	v := validation.New(validation.DefaultLimits())
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(Validation(v)),
+		grpc.ChainStreamInterceptor(StreamValidation(v)),
+	)
+	todo.RegisterTodoServiceServer(srv, echoServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return todo.NewTodoServiceClient(conn)
+}
+
+// fieldViolations returns the fields named by the BadRequest detail of
+// err as the client sees it.
+func fieldViolations(t *testing.T, err error) []string {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	var fields []string
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			t.Fatalf("expected a BadRequest detail, got %T", d)
+		}
+		for _, fv := range br.GetFieldViolations() {
+			if fv.GetDescription() == "" {
+				t.Errorf("expected a description for %s", fv.GetField())
+			}
+			fields = append(fields, fv.GetField())
+		}
+	}
+	return fields
+}
+
+func TestValidationStatusDetails(t *testing.T) {
+	client := newValidatingClient(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateTask(ctx, &todo.CreateTaskRequest{Title: "Buy milk"}); err != nil {
+		t.Fatalf("expected a valid request to pass, got %v", err)
+	}
+
+	_, err := client.CreateTask(ctx, &todo.CreateTaskRequest{Title: " padded "})
+	if got := fieldViolations(t, err); len(got) != 1 || got[0] != "title" {
+		t.Errorf("expected a title violation, got %v", got)
+	}
+
+	stream, err := client.UploadAttachment(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &todo.AttachmentInfo{Filename: "notes.txt", ContentType: "text/plain"}
+	if err := stream.Send(&todo.UploadAttachmentRequest{Payload: &todo.UploadAttachmentRequest_Info{Info: info}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.CloseAndRecv()
+	if got := fieldViolations(t, err); len(got) != 1 || got[0] != "info.task_id" {
+		t.Errorf("expected an info.task_id violation, got %v", got)
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+// DatabaseStats describes the size and contents of a database.
+type DatabaseStats struct {
+	Tables []TableStats
+	// FileSize and WALSize are in bytes; WALSize is 0 without a
+	// write-ahead log.
+	FileSize      int64
+	WALSize       int64
+	PageSize      int64
+	PageCount     int64
+	FreelistCount int64
+	JournalMode   string
+}
+
+type TableStats struct {
+	Name     string
+	RowCount int64
+}
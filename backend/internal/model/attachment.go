@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Attachment describes a file attached to a task. The contents live in a
+// blob store under StorageKey.
+type Attachment struct {
+	ID          int64
+	TaskID      int64
+	Filename    string
+	ContentType string
+	Size        int64
+	// SHA256 is the hex-encoded checksum of the contents.
+	SHA256     string
+	StorageKey string
+	Uploader   string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Task history actions.
+const (
+	ActionCreated  = "created"
+	ActionUpdated  = "updated"
+	ActionDeleted  = "deleted"
+	ActionReverted = "reverted"
+	ActionRestored = "restored"
+)
+
+// TaskChange is one entry of a task's append-only history.
+type TaskChange struct {
+	ID        int64         `json:"id"`
+	TaskID    int64         `json:"task_id"`
+	Action    string        `json:"action"`
+	Actor     string        `json:"actor"`
+	ChangedAt time.Time     `json:"changed_at"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// FieldChange holds the old and new value of a field. Old is nil for
+// created tasks and New is nil for deleted ones.
+type FieldChange struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old,omitempty"`
+	New   *string `json:"new,omitempty"`
+}
+
+// TaskRevision is a snapshot of a task as it was after a change.
+// Revisions are numbered per task starting at 1.
+type TaskRevision struct {
+	Revision  int64     `json:"revision"`
+	Task      *Model    `json:"task"`
+	Actor     string    `json:"actor"`
+	RevisedAt time.Time `json:"revised_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// IdempotencyKey is a key a client sent with a mutating call, scoped to
+// the caller and method, together with the response of the first call
+// that succeeded with it.
+type IdempotencyKey struct {
+	Principal   string
+	Method      string
+	Key         string
+	RequestHash string
+	// ResponseType is the full protobuf name of Response. Both are empty
+	// while the first call is still running.
+	ResponseType string
+	Response     []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// Completed reports whether the response of the key has been stored.
+func (k *IdempotencyKey) Completed() bool {
+	return k.ResponseType != ""
+}
@@ -7,6 +7,33 @@ type Model struct {
 	Title       string    `json:"title"`
 	Description *string   `json:"description"`
 	Completed   bool      `json:"completed"`
+	Owner       string    `json:"owner"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// WorkspaceID is 0 for personal tasks.
+	WorkspaceID int64    `json:"workspace_id,omitempty"`
+	AssigneeIDs []string `json:"assignee_ids,omitempty"`
+}
+
+// NewTask is a task to create.
+type NewTask struct {
+	// WorkspaceID is 0 for a personal task.
+	WorkspaceID int64
+	Title       string
+	Description string
+	Owner       string
+	Completed   bool
+	// Quota, if positive, is how many tasks Owner may have in total.
+	Quota int
+}
+
+// TaskQuery selects tasks. Zero fields do not restrict the result.
+type TaskQuery struct {
+	// Text matches the title or description.
+	Text        string
+	WorkspaceID int64
+	// Assignee keeps tasks assigned to this user.
+	Assignee string
+	// Unassigned keeps tasks without assignees.
+	Unassigned bool
 }
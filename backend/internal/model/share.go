@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// TaskShare grants a user a role on a single task, on top of any role
+// they have through the task's workspace. Only RoleViewer and RoleEditor
+// are shared.
+type TaskShare struct {
+	TaskID    int64
+	User      string
+	Role      string
+	GrantedBy string
+	GrantedAt time.Time
+}
+
+// ShareLink grants read-only access to one task to anyone holding its
+// token until it expires or is revoked. Only the hash of the token is
+// stored, so Token is set just when the link is created.
+type ShareLink struct {
+	ID        int64
+	TaskID    int64
+	Token     string
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type Webhook struct {
+	ID        int64
+	URL       string
+	Secret    string
+	Events    []string
+	Owner     string
+	CreatedAt time.Time
+}
+
+// Webhook delivery states. A delivery stays pending until it succeeds
+// or runs out of attempts and becomes dead.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+type WebhookDelivery struct {
+	ID            int64
+	WebhookID     int64
+	EventID       string
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	Log           []WebhookAttempt
+
+	// Target of the delivery, filled in for dispatching.
+	URL    string
+	Secret string
+}
+
+type WebhookAttempt struct {
+	AttemptedAt time.Time
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+}
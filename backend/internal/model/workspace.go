@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Workspace roles. What each role may do is decided by the RBAC policy.
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// Workspace groups the tasks of a team.
+type Workspace struct {
+	ID        int64
+	Name      string
+	CreatedBy string
+	CreatedAt time.Time
+	// Role is the role of the user the workspace was listed for.
+	Role string
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int64
+	Member      string
+	Role        string
+	AddedAt     time.Time
+}
@@ -0,0 +1,126 @@
+// Package monitor keeps track of the connections a gRPC server holds and
+// the calls it is serving.
+package monitor
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/stats"
+)
+
+// Connection is an open client connection.
+type Connection struct {
+	ID          uint64
+	RemoteAddr  string
+	ConnectedAt time.Time
+}
+
+// Call is an RPC in progress.
+type Call struct {
+	ConnectionID uint64
+	Method       string
+	Principal    string
+	Streaming    bool
+	StartedAt    time.Time
+}
+
+// Monitor is a stats.Handler recording connections. Calls are recorded by
+// the interceptors built on Begin.
+type Monitor struct {
+	nextID atomic.Uint64
+
+	mu     sync.Mutex
+	conns  map[uint64]*Connection
+	calls  map[uint64]*Call
+	callID uint64
+}
+
+func New() *Monitor {
+	return &Monitor{conns: make(map[uint64]*Connection), calls: make(map[uint64]*Call)}
+}
+
+type connKey struct{}
+
+// TagConn numbers the connection. Calls on it inherit the returned
+// context, which is how Begin finds the connection of a call.
+func (m *Monitor) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	c := &Connection{ID: m.nextID.Add(1)}
+	if info.RemoteAddr != nil {
+		c.RemoteAddr = info.RemoteAddr.String()
+	}
+	return context.WithValue(ctx, connKey{}, c)
+}
+
+func (m *Monitor) HandleConn(ctx context.Context, s stats.ConnStats) {
+	c, ok := ctx.Value(connKey{}).(*Connection)
+	if !ok {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch s.(type) {
+	case *stats.ConnBegin:
+		c.ConnectedAt = time.Now()
+		m.conns[c.ID] = c
+	case *stats.ConnEnd:
+		delete(m.conns, c.ID)
+	}
+}
+
+func (m *Monitor) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (m *Monitor) HandleRPC(context.Context, stats.RPCStats) {}
+
+// Begin records a call until the returned function is called.
+func (m *Monitor) Begin(ctx context.Context, method, principal string, streaming bool) (end func()) {
+	call := &Call{Method: method, Principal: principal, Streaming: streaming, StartedAt: time.Now()}
+	if c, ok := ctx.Value(connKey{}).(*Connection); ok {
+		call.ConnectionID = c.ID
+	}
+
+	m.mu.Lock()
+	m.callID++
+	id := m.callID
+	m.calls[id] = call
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		delete(m.calls, id)
+		m.mu.Unlock()
+	}
+}
+
+// Connections returns the open connections, oldest first.
+func (m *Monitor) Connections() []Connection {
+	m.mu.Lock()
+	conns := make([]Connection, 0, len(m.conns))
+	for _, c := range m.conns {
+		conns = append(conns, *c)
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(conns, func(a, b Connection) int { return cmp.Compare(a.ID, b.ID) })
+	return conns
+}
+
+// Calls returns the calls in progress, oldest first.
+func (m *Monitor) Calls() []Call {
+	m.mu.Lock()
+	calls := make([]Call, 0, len(m.calls))
+	for _, c := range m.calls {
+		calls = append(calls, *c)
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(calls, func(a, b Call) int { return a.StartedAt.Compare(b.StartedAt) })
+	return calls
+}
@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+)
+
+// FilePublisher writes each event as a JSON line. It suits local
+// development and piping events into other tools.
+type FilePublisher struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterPublisher writes events to w, e.g. os.Stdout. Close does not
+// close w.
+func NewWriterPublisher(w io.Writer) *FilePublisher {
+	return &FilePublisher{w: w}
+}
+
+// OpenFilePublisher appends events to the file at path, creating it if
+// needed.
+func OpenFilePublisher(path string) (*FilePublisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{w: f, closer: f}, nil
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, ev events.Event) error {
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	// A file is synced so that an event marked as published survives a
+	// crash.
+	if f, ok := p.w.(*os.File); ok && p.closer != nil {
+		return f.Sync()
+	}
+	return nil
+}
+
+func (p *FilePublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// HeaderIdempotencyKey carries Event.ID on Kafka messages.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// KafkaPublisher writes events to a topic, keyed by task ID so that the
+// events of one task stay in one partition and in order. Publish returns
+// once all in-sync replicas have acknowledged the message.
+type KafkaPublisher struct {
+	w *kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{w: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		// The relay publishes one event at a time; do not wait for a
+		// batch to fill up.
+		BatchSize:    1,
+		BatchTimeout: 10 * time.Millisecond,
+	}}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, ev events.Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	var key []byte
+	if ev.Task != nil {
+		key = []byte(strconv.FormatInt(ev.Task.ID, 10))
+	}
+
+	return p.w.WriteMessages(ctx, kafka.Message{
+		Key:   key,
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: HeaderIdempotencyKey, Value: []byte(ev.ID)},
+			{Key: HeaderEventType, Value: []byte(ev.Type)},
+		},
+	})
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.w.Close()
+}
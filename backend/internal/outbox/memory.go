@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+)
+
+// MemoryPublisher keeps published events in memory. It is meant for
+// tests: Fail makes the following Publish calls return an error.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []events.Event
+	err    error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (m *MemoryPublisher) Publish(ctx context.Context, ev events.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, ev)
+	return nil
+}
+
+// Fail sets the error Publish returns; nil makes it succeed again.
+func (m *MemoryPublisher) Fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// Events returns every accepted event, redeliveries included.
+func (m *MemoryPublisher) Events() []events.Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]events.Event(nil), m.events...)
+}
+
+func (m *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,96 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+
+	"github.com/nats-io/nats.go"
+)
+
+const natsPublishTimeout = 10 * time.Second
+
+// HeaderEventType carries Event.Type on bus messages. The idempotency
+// key travels in the header each bus uses for deduplication.
+const HeaderEventType = "Event-Type"
+
+// NATSPublisher publishes events on "<prefix>.<event type>", e.g.
+// "todo.events.task.created". Event.ID is sent as Nats-Msg-Id.
+//
+// With JetStream, Publish waits for the stream's ack and the stream
+// drops redeliveries within its duplicate window. With core NATS,
+// Publish waits until the server has received the message.
+type NATSPublisher struct {
+	nc     *nats.Conn
+	js     nats.JetStreamContext
+	prefix string
+	owned  bool
+}
+
+// NewNATSPublisher publishes over an existing connection, which Close
+// leaves open.
+func NewNATSPublisher(nc *nats.Conn, prefix string, jetStream bool) (*NATSPublisher, error) {
+	p := &NATSPublisher{nc: nc, prefix: prefix}
+	if jetStream {
+		js, err := nc.JetStream()
+		if err != nil {
+			return nil, err
+		}
+		p.js = js
+	}
+	return p, nil
+}
+
+// DialNATS connects to url and returns a publisher that owns the
+// connection.
+func DialNATS(url, prefix string, jetStream bool) (*NATSPublisher, error) {
+	nc, err := nats.Connect(url, nats.Name("todo-grpc outbox"))
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := NewNATSPublisher(nc, prefix, jetStream)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	p.owned = true
+	return p, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, ev events.Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	// The NATS client needs a deadline to wait for acknowledgements.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, natsPublishTimeout)
+		defer cancel()
+	}
+
+	msg := nats.NewMsg(p.prefix + "." + ev.Type)
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, ev.ID)
+	msg.Header.Set(HeaderEventType, ev.Type)
+
+	if p.js != nil {
+		_, err := p.js.PublishMsg(msg, nats.Context(ctx))
+		return err
+	}
+	if err := p.nc.PublishMsg(msg); err != nil {
+		return err
+	}
+	return p.nc.FlushWithContext(ctx)
+}
+
+func (p *NATSPublisher) Close() error {
+	if p.owned {
+		return p.nc.Drain()
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/model"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+func runNATS(t *testing.T) *server.Server {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats server did not start")
+	}
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+func TestNATSPublisherJetStreamDeduplicates(t *testing.T) {
+	srv := runNATS(t)
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := js.AddStream(&nats.StreamConfig{Name: "TODO", Subjects: []string{"todo.events.>"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := NewNATSPublisher(nc, "todo.events", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ev := events.New(events.TaskCreated, &model.Model{ID: 7, Title: "Ship it"})
+	for i := 0; i < 2; i++ {
+		if err := pub.Publish(ctx, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info, err := js.StreamInfo("TODO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State.Msgs != 1 {
+		t.Fatalf("expected the redelivery to be dropped, stream has %d messages", info.State.Msgs)
+	}
+
+	msg, err := js.GetMsg("TODO", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Subject != "todo.events.task.created" || msg.Header.Get(nats.MsgIdHdr) != ev.ID {
+		t.Errorf("unexpected message %s with id %q", msg.Subject, msg.Header.Get(nats.MsgIdHdr))
+	}
+	var got events.Event
+	if err := json.Unmarshal(msg.Data, &got); err != nil || got.Task.Title != "Ship it" {
+		t.Errorf("unexpected payload %s (%v)", msg.Data, err)
+	}
+}
+
+func TestNATSPublisherCore(t *testing.T) {
+	srv := runNATS(t)
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+
+	sub, err := nc.SubscribeSync("todo.events.>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := DialNATS(srv.ClientURL(), "todo.events", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+
+	ev := events.New(events.TaskDeleted, &model.Model{ID: 7})
+	if err := pub.Publish(context.Background(), ev); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := sub.NextMsg(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Subject != "todo.events.task.deleted" || msg.Header.Get(HeaderEventType) != events.TaskDeleted {
+		t.Errorf("unexpected message %s", msg.Subject)
+	}
+}
@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+)
+
+// EventPublisher sends task events to a destination. Publish must not
+// return nil before the destination has accepted the event; the relay
+// retries on error, so an event may be published more than once and
+// destinations should deduplicate on Event.ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, ev events.Event) error
+	Close() error
+}
+
+// Fanout publishes every event to each of its publishers in order. An
+// event counts as published only once all of them accepted it.
+type Fanout []EventPublisher
+
+func (f Fanout) Publish(ctx context.Context, ev events.Event) error {
+	for _, p := range f {
+		if err := p.Publish(ctx, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f Fanout) Close() error {
+	var errs []error
+	for _, p := range f {
+		errs = append(errs, p.Close())
+	}
+	return errors.Join(errs...)
+}
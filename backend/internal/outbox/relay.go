@@ -0,0 +1,164 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBackoffBase  = time.Second
+	defaultBackoffMax   = 5 * time.Minute
+	defaultRetention    = 7 * 24 * time.Hour
+	cleanupInterval     = time.Hour
+	batchSize           = 100
+	maxErrorLength      = 512
+)
+
+// Store is the outbox table the relay drains.
+type Store interface {
+	PendingOutboxEvents(ctx context.Context, limit int) ([]events.Pending, error)
+	MarkOutboxPublished(ctx context.Context, eventID string, at time.Time) error
+	MarkOutboxFailed(ctx context.Context, eventID string, attempts int, next time.Time, lastError string) error
+	DeletePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Relay publishes outbox events in the order they were recorded. When
+// publishing fails the relay backs off and retries the same event before
+// moving on, so consumers never see a task's events out of order.
+// An event is marked published only after the publisher accepted it,
+// which gives at-least-once delivery.
+type Relay struct {
+	store        Store
+	publisher    EventPublisher
+	pollInterval time.Duration
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+	retention    time.Duration
+	now          func() time.Time
+}
+
+type Option func(*Relay)
+
+func WithPollInterval(interval time.Duration) Option {
+	return func(r *Relay) {
+		r.pollInterval = interval
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the cap the
+// doubling delay never exceeds.
+func WithBackoff(base, max time.Duration) Option {
+	return func(r *Relay) {
+		r.backoffBase = base
+		r.backoffMax = max
+	}
+}
+
+// WithRetention sets how long published events stay in the outbox.
+func WithRetention(d time.Duration) Option {
+	return func(r *Relay) {
+		r.retention = d
+	}
+}
+
+func NewRelay(store Store, publisher EventPublisher, opts ...Option) *Relay {
+	r := &Relay{
+		store:        store,
+		publisher:    publisher,
+		pollInterval: defaultPollInterval,
+		backoffBase:  defaultBackoffBase,
+		backoffMax:   defaultBackoffMax,
+		retention:    defaultRetention,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run relays events until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		if _, err := r.RelayPending(ctx); err != nil && ctx.Err() == nil {
+			log.L().Errorf("outbox relay failed: %v", err)
+		}
+
+		if now := r.now(); now.Sub(lastCleanup) >= cleanupInterval {
+			lastCleanup = now
+			if n, err := r.store.DeletePublishedOutboxEvents(ctx, now.Add(-r.retention)); err != nil {
+				log.L().Errorf("outbox cleanup failed: %v", err)
+			} else if n > 0 {
+				log.L().Infof("outbox cleanup: removed %d published events", n)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes pending events until the outbox is empty or an
+// event has to wait for a retry, and returns how many were published.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		pending, err := r.store.PendingOutboxEvents(ctx, batchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for _, p := range pending {
+			if p.NextAttemptAt.After(r.now()) {
+				return published, nil
+			}
+
+			if err := r.publisher.Publish(ctx, p.Event); err != nil {
+				if ctx.Err() != nil {
+					return published, ctx.Err()
+				}
+				attempts := p.Attempts + 1
+				log.L().Warnf("publish %s event %s failed (attempt %d): %v", p.Type, p.ID, attempts, err)
+				return published, r.store.MarkOutboxFailed(ctx, p.ID, attempts,
+					r.now().Add(r.backoff(attempts)), truncate(err.Error(), maxErrorLength))
+			}
+
+			if err := r.store.MarkOutboxPublished(ctx, p.ID, r.now()); err != nil {
+				return published, err
+			}
+			published++
+		}
+
+		if len(pending) < batchSize {
+			return published, nil
+		}
+	}
+}
+
+// backoff doubles the delay with each failed attempt, up to backoffMax.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.backoffBase
+	for i := 1; i < attempts && delay < r.backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, r.backoffMax)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
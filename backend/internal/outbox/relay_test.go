@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+func newRepo(t *testing.T) *repository.RepositoryDB {
+	t.Helper()
+	pool, err := db.Open(filepath.Join(t.TempDir(), "todo.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return &repository.RepositoryDB{Pool: pool}
+}
+
+func TestRelayPublishesMutationsInOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	task, err := repo.Create(ctx, "Ship it", "", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.Completed = true
+	if _, err := repo.Update(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	task.Title = "Shipped"
+	if _, err := repo.Update(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	// A failed mutation must not leave an event behind.
+	if err := repo.Delete(ctx, task.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	pub := NewMemoryPublisher()
+	n, err := NewRelay(repo, pub).RelayPending(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{events.TaskCreated, events.TaskCompleted, events.TaskUpdated, events.TaskDeleted}
+	got := pub.Events()
+	if n != len(want) || len(got) != len(want) {
+		t.Fatalf("expected %d events, published %d: %+v", len(want), n, got)
+	}
+	for i, ev := range got {
+		if ev.Type != want[i] || ev.Task.ID != task.ID || ev.ID == "" {
+			t.Errorf("event %d: got %s for task %d, want %s", i, ev.Type, ev.Task.ID, want[i])
+		}
+	}
+
+	if n, _ := NewRelay(repo, pub).RelayPending(ctx); n != 0 {
+		t.Errorf("published events were relayed again: %d", n)
+	}
+}
+
+func TestRelayRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	for _, title := range []string{"first", "second"} {
+		if _, err := repo.Create(ctx, title, "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pub := NewMemoryPublisher()
+	pub.Fail(errors.New("bus unavailable"))
+
+	now := time.Now()
+	relay := NewRelay(repo, pub, WithBackoff(time.Minute, time.Hour))
+	relay.now = func() time.Time { return now }
+
+	if n, err := relay.RelayPending(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing published while failing, got %d, %v", n, err)
+	}
+
+	pub.Fail(nil)
+	if n, _ := relay.RelayPending(ctx); n != 0 {
+		t.Fatalf("events were published before the backoff elapsed: %d", n)
+	}
+
+	now = now.Add(time.Minute)
+	if n, err := relay.RelayPending(ctx); err != nil || n != 2 {
+		t.Fatalf("expected both events after the backoff, got %d, %v", n, err)
+	}
+	if got := pub.Events(); got[0].Task.Title != "first" || got[1].Task.Title != "second" {
+		t.Errorf("events out of order: %+v", got)
+	}
+}
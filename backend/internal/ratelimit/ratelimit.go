@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"path"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limit is a token bucket refilled at RPS tokens per second holding at
+// most Burst tokens. A zero RPS disables limiting.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+const idleTTL = 10 * time.Minute
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter keeps one token bucket per method and client key.
+type Limiter struct {
+	def     Limit
+	methods map[string]Limit
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func New(def Limit, methods map[string]Limit) *Limiter {
+	return &Limiter{
+		def:       def,
+		methods:   methods,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token for the key on the given full method name, such as
+// "/todoService.TodoService/CreateTask". Buckets are kept per full
+// method, so services with a method of the same name do not share one. A
+// limit may be configured under the full or the short name; the full one
+// wins. When the bucket is empty Allow returns false and how long the
+// caller should wait.
+func (l *Limiter) Allow(method, key string) (bool, time.Duration) {
+	limit, ok := l.methods[method]
+	if !ok {
+		limit, ok = l.methods[path.Base(method)]
+	}
+	if !ok {
+		limit = l.def
+	}
+	if limit.RPS <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+	b := l.bucket(method+"|"+key, limit, now)
+
+	r := b.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return false, time.Second
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+func (l *Limiter) bucket(key string, limit Limit, now time.Time) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > idleTTL {
+		for k, b := range l.buckets {
+			if now.Sub(b.lastSeen) > idleTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RPS), max(limit.Burst, 1))}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b
+}
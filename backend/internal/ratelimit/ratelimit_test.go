@@ -0,0 +1,66 @@
+package ratelimit
+
+import "testing"
+
+func TestAllowPerMethodAndKey(t *testing.T) {
+	l := New(Limit{RPS: 100, Burst: 100}, map[string]Limit{
+		"CreateTask": {RPS: 0.001, Burst: 2},
+	})
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("CreateTask", "ip:1.1.1.1"); !ok {
+			t.Fatalf("call %d: expected to be allowed", i)
+		}
+	}
+
+	ok, retryAfter := l.Allow("CreateTask", "ip:1.1.1.1")
+	if ok {
+		t.Fatal("expected third call to be limited")
+	}
+	if retryAfter <= 0 {
+		t.Errorf("expected positive retry delay, got %v", retryAfter)
+	}
+
+	if ok, _ := l.Allow("CreateTask", "ip:2.2.2.2"); !ok {
+		t.Error("expected a different client to have its own bucket")
+	}
+	if ok, _ := l.Allow("GetTask", "ip:1.1.1.1"); !ok {
+		t.Error("expected other methods to use the default limit")
+	}
+}
+
+func TestAllowDisabled(t *testing.T) {
+	l := New(Limit{}, nil)
+	for i := 0; i < 1000; i++ {
+		if ok, _ := l.Allow("ListTasks", "ip:1.1.1.1"); !ok {
+			t.Fatal("expected zero RPS to disable limiting")
+		}
+	}
+}
+
+func TestAllowKeysBucketsByFullMethod(t *testing.T) {
+	l := New(Limit{RPS: 100, Burst: 100}, map[string]Limit{
+		"CreateTask":                          {RPS: 0.001, Burst: 1},
+		"/todoService.AdminService/GetStatus": {RPS: 0.001, Burst: 1},
+	})
+
+	if ok, _ := l.Allow("/todoService.TodoService/CreateTask", "ip:1.1.1.1"); !ok {
+		t.Fatal("expected first call to be allowed")
+	}
+	if ok, _ := l.Allow("/todoService.TodoService/CreateTask", "ip:1.1.1.1"); ok {
+		t.Error("expected the short-name limit to apply to the full method")
+	}
+	if ok, _ := l.Allow("/todoService.OtherService/CreateTask", "ip:1.1.1.1"); !ok {
+		t.Error("expected a method of the same name on another service to have its own bucket")
+	}
+
+	if ok, _ := l.Allow("/todoService.AdminService/GetStatus", "ip:1.1.1.1"); !ok {
+		t.Fatal("expected first call to be allowed")
+	}
+	if ok, _ := l.Allow("/todoService.AdminService/GetStatus", "ip:1.1.1.1"); ok {
+		t.Error("expected the full-name limit to apply")
+	}
+	if ok, _ := l.Allow("/todoService.TodoService/GetStatus", "ip:1.1.1.1"); !ok {
+		t.Error("expected a full-name limit to leave other services on the default")
+	}
+}
@@ -0,0 +1,107 @@
+// Package rbac decides which actions a role may perform. The roles and
+// their permissions come from a YAML policy file, so deployments can
+// change them without a rebuild.
+package rbac
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Action string
+
+const (
+	TaskRead   Action = "task.read"
+	TaskCreate Action = "task.create"
+	TaskUpdate Action = "task.update"
+	TaskDelete Action = "task.delete"
+	TaskAssign Action = "task.assign"
+	// TaskShare grants and revokes per-task shares and share links.
+	TaskShare Action = "task.share"
+
+	// ManageMembers adds and removes workspace members below owner.
+	ManageMembers Action = "workspace.members"
+	// ManageOwners grants and revokes the workspace owner role.
+	ManageOwners Action = "workspace.owners"
+)
+
+// Actions lists every action a policy can grant.
+var Actions = []Action{TaskRead, TaskCreate, TaskUpdate, TaskDelete, TaskAssign, TaskShare, ManageMembers, ManageOwners}
+
+//go:embed policy.yaml
+var defaultPolicy []byte
+
+// Policy maps roles to the actions they are allowed to perform.
+type Policy struct {
+	roles map[string][]string
+}
+
+type policyFile struct {
+	Roles map[string][]string `yaml:"roles"`
+}
+
+// Default returns the built-in policy.
+func Default() *Policy {
+	p, err := Parse(defaultPolicy)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Load reads a policy file.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse reads a policy from YAML. Permissions that match no known action
+// are rejected, so a typo cannot silently deny or grant access.
+func Parse(data []byte) (*Policy, error) {
+	var f policyFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse policy: %w", err)
+	}
+	if len(f.Roles) == 0 {
+		return nil, fmt.Errorf("policy defines no roles")
+	}
+
+	for role, perms := range f.Roles {
+		for _, perm := range perms {
+			if !slices.ContainsFunc(Actions, func(a Action) bool { return matches(perm, a) }) {
+				return nil, fmt.Errorf("role %q: unknown permission %q", role, perm)
+			}
+		}
+	}
+
+	return &Policy{roles: f.Roles}, nil
+}
+
+// Allows reports whether role may perform action. The empty role, used
+// for callers with no relation to a resource, is never allowed anything.
+func (p *Policy) Allows(role string, action Action) bool {
+	if role == "" {
+		return false
+	}
+	return slices.ContainsFunc(p.roles[role], func(perm string) bool { return matches(perm, action) })
+}
+
+func matches(perm string, action Action) bool {
+	if perm == "*" || perm == string(action) {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(perm, "*")
+	return ok && strings.HasSuffix(prefix, ".") && strings.HasPrefix(string(action), prefix)
+}
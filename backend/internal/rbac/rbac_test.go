@@ -0,0 +1,89 @@
+package rbac
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPolicyMatrix(t *testing.T) {
+	p := Default()
+
+	tests := []struct {
+		role    string
+		allowed []Action
+	}{
+		{"owner", Actions},
+		{"admin", []Action{TaskRead, TaskCreate, TaskUpdate, TaskDelete, TaskAssign, TaskShare, ManageMembers}},
+		{"editor", []Action{TaskRead, TaskCreate, TaskUpdate, TaskAssign}},
+		{"viewer", []Action{TaskRead}},
+		{"", nil},
+		{"stranger", nil},
+	}
+	for _, tt := range tests {
+		allowed := make(map[Action]bool)
+		for _, a := range tt.allowed {
+			allowed[a] = true
+		}
+		for _, a := range Actions {
+			if got := p.Allows(tt.role, a); got != allowed[a] {
+				t.Errorf("Allows(%q, %s) = %t, want %t", tt.role, a, got, allowed[a])
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		role    string
+		action  Action
+		allowed bool
+		err     string
+	}{
+		{name: "exact action", policy: "roles: {r: [task.read]}", role: "r", action: TaskRead, allowed: true},
+		{name: "other action", policy: "roles: {r: [task.read]}", role: "r", action: TaskUpdate},
+		{name: "resource wildcard", policy: "roles: {r: [task.*]}", role: "r", action: TaskDelete, allowed: true},
+		{name: "wildcard stays in resource", policy: "roles: {r: [task.*]}", role: "r", action: ManageMembers},
+		{name: "everything", policy: `roles: {r: ["*"]}`, role: "r", action: ManageOwners, allowed: true},
+		{name: "unknown role", policy: "roles: {r: [task.read]}", role: "x", action: TaskRead},
+		{name: "typo", policy: "roles: {r: [task.raed]}", err: `unknown permission "task.raed"`},
+		{name: "prefix without dot", policy: "roles: {r: [ta*]}", err: "unknown permission"},
+		{name: "no roles", policy: "roles: {}", err: "no roles"},
+		{name: "malformed", policy: "roles: [", err: "parse policy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse([]byte(tt.policy))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.Allows(tt.role, tt.action); got != tt.allowed {
+				t.Errorf("Allows(%q, %s) = %t, want %t", tt.role, tt.action, got, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte("roles:\n  viewer: [task.read, task.update]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Allows("viewer", TaskUpdate) || p.Allows("viewer", TaskDelete) {
+		t.Error("loaded policy does not match the file")
+	}
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// DatabaseStats counts the rows of every table and reports the size of
+// the database and its write-ahead log.
+func (r *RepositoryDB) DatabaseStats(ctx context.Context) (*model.DatabaseStats, error) {
+	rows, err := r.QueryContext(ctx,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite\_%' ESCAPE '\' ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := &model.DatabaseStats{Tables: make([]model.TableStats, 0, len(tables))}
+	for _, name := range tables {
+		var n int64
+		// Table names come from the schema, quoted as identifiers.
+		query := `SELECT COUNT(*) FROM "` + strings.ReplaceAll(name, `"`, `""`) + `"`
+		if err := r.conn(ctx).Reader.QueryRowContext(ctx, query).Scan(&n); err != nil {
+			return nil, err
+		}
+		stats.Tables = append(stats.Tables, model.TableStats{Name: name, RowCount: n})
+	}
+
+	if err := r.QueryRowContext(ctx,
+		`SELECT * FROM pragma_page_size(), pragma_page_count(), pragma_freelist_count(), pragma_journal_mode()`,
+	).Scan(&stats.PageSize, &stats.PageCount, &stats.FreelistCount, &stats.JournalMode); err != nil {
+		return nil, err
+	}
+
+	path, err := r.databaseFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if stats.FileSize, err = fileSize(path); err != nil {
+		return nil, err
+	}
+	if stats.WALSize, err = fileSize(path + "-wal"); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// DatabaseSize returns the size of the database in bytes, counting pages
+// still in the write-ahead log.
+func (r *RepositoryDB) DatabaseSize(ctx context.Context) (int64, error) {
+	path, err := r.databaseFile(ctx)
+	if err != nil {
+		return 0, err
+	}
+	size, err := fileSize(path)
+	if err != nil {
+		return 0, err
+	}
+	wal, err := fileSize(path + "-wal")
+	return size + wal, err
+}
+
+// Vacuum rebuilds the database file. The write-ahead log is checkpointed
+// and truncated afterwards so the file shrinks straight away.
+func (r *RepositoryDB) Vacuum(ctx context.Context) error {
+	writer := r.conn(ctx).Writer
+	if _, err := writer.ExecContext(ctx, `VACUUM`); err != nil {
+		return err
+	}
+	_, err := writer.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`)
+	return err
+}
+
+// Analyze refreshes the statistics of the query planner.
+func (r *RepositoryDB) Analyze(ctx context.Context) error {
+	_, err := r.conn(ctx).Writer.ExecContext(ctx, `ANALYZE`)
+	return err
+}
+
+// CheckIntegrity returns the problems SQLite finds in the database, or
+// none if it is intact. A quick check skips verifying indexes.
+func (r *RepositoryDB) CheckIntegrity(ctx context.Context, quick bool) ([]string, error) {
+	query := `SELECT * FROM pragma_integrity_check()`
+	if quick {
+		query = `SELECT * FROM pragma_quick_check()`
+	}
+
+	rows, err := r.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	return problems, rows.Err()
+}
+
+func (r *RepositoryDB) databaseFile(ctx context.Context) (string, error) {
+	var path string
+	err := r.QueryRowContext(ctx, `SELECT file FROM pragma_database_list() WHERE name = 'main'`).Scan(&path)
+	return path, err
+}
+
+// fileSize returns the size of the file at path, or 0 if it does not
+// exist.
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
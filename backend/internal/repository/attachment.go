@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// Deleted attachments only get a deleted_at mark, within the transaction
+// that deletes them or their task. Their blobs are removed afterwards and
+// the rows purged, see DeletedAttachments.
+
+// CreateAttachment stores the metadata of an uploaded attachment. It
+// returns ErrNotFound if the task no longer exists.
+func (r *RepositoryDB) CreateAttachment(ctx context.Context, a *model.Attachment) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := taskInTx(ctx, tx, a.TaskID); err != nil {
+		return err
+	}
+
+	a.CreatedAt = utcNow()
+	query := `INSERT INTO attachment (task_id, filename, content_type, size, sha256, storage_key, uploader, created_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query,
+		a.TaskID, a.Filename, a.ContentType, a.Size, a.SHA256, a.StorageKey, a.Uploader, a.CreatedAt.UnixMilli(),
+	)
+	if err != nil {
+		return err
+	}
+	if a.ID, err = res.LastInsertId(); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *RepositoryDB) GetAttachment(ctx context.Context, id int64) (*model.Attachment, error) {
+	query := `SELECT id, task_id, filename, content_type, size, sha256, storage_key, uploader, created_at
+	          FROM attachment
+	          WHERE id = ? AND deleted_at IS NULL`
+
+	a, err := scanAttachment(r.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (r *RepositoryDB) ListAttachments(ctx context.Context, taskID int64) ([]*model.Attachment, error) {
+	query := `SELECT id, task_id, filename, content_type, size, sha256, storage_key, uploader, created_at
+	          FROM attachment
+	          WHERE task_id = ? AND deleted_at IS NULL
+	          ORDER BY id`
+
+	return r.queryAttachments(ctx, query, taskID)
+}
+
+func (r *RepositoryDB) DeleteAttachment(ctx context.Context, id int64) error {
+	res, err := r.ExecContext(ctx, `UPDATE attachment SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`,
+		utcNow().UnixMilli(), id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeletedAttachments returns attachments whose blobs are due for removal.
+func (r *RepositoryDB) DeletedAttachments(ctx context.Context, limit int) ([]*model.Attachment, error) {
+	query := `SELECT id, task_id, filename, content_type, size, sha256, storage_key, uploader, created_at
+	          FROM attachment
+	          WHERE deleted_at IS NOT NULL
+	          ORDER BY id
+	          LIMIT ?`
+
+	return r.queryAttachments(ctx, query, limit)
+}
+
+// PurgeAttachment removes the row of a deleted attachment once its blob
+// is gone.
+func (r *RepositoryDB) PurgeAttachment(ctx context.Context, id int64) error {
+	_, err := r.ExecContext(ctx, `DELETE FROM attachment WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	return err
+}
+
+func (r *RepositoryDB) queryAttachments(ctx context.Context, query string, args ...any) ([]*model.Attachment, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []*model.Attachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func scanAttachment(row scanner) (*model.Attachment, error) {
+	a := &model.Attachment{}
+	var createdAt int64
+
+	if err := row.Scan(
+		&a.ID, &a.TaskID, &a.Filename, &a.ContentType, &a.Size, &a.SHA256, &a.StorageKey, &a.Uploader, &createdAt,
+	); err != nil {
+		return nil, err
+	}
+	a.CreatedAt = fromMillis(createdAt)
+
+	return a, nil
+}
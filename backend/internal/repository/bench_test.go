@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// Run with: go test -run '^$' -bench . -cpu 1,4 ./internal/repository
+//
+// "rollback" approximates the previous setup: rollback journal, full
+// sync and no statement cache.
+var benchConfigs = []struct {
+	name string
+	opts func(*db.Options)
+}{
+	{"rollback", func(o *db.Options) {
+		o.JournalMode, o.Synchronous, o.StatementCacheSize = "delete", "full", 0
+	}},
+	{"wal", func(o *db.Options) { o.StatementCacheSize = 0 }},
+	{"wal+stmt-cache", func(o *db.Options) {}},
+}
+
+const benchTasks = 200
+
+var benchDescription = "benchmark"
+
+func benchRepo(b *testing.B, configure func(*db.Options)) (*RepositoryDB, []int64) {
+	b.Helper()
+	opts := db.DefaultOptions()
+	configure(&opts)
+	pool, err := db.Open(filepath.Join(b.TempDir(), "bench.db"), opts)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { pool.Close() })
+
+	repo := &RepositoryDB{Pool: pool}
+	ids := make([]int64, benchTasks)
+	for i := range ids {
+		task, err := repo.Create(context.Background(), fmt.Sprintf("task %d", i), "benchmark", "bench")
+		if err != nil {
+			b.Fatal(err)
+		}
+		ids[i] = task.ID
+	}
+	return repo, ids
+}
+
+func runConfigs(b *testing.B, bench func(b *testing.B, repo *RepositoryDB, ids []int64)) {
+	for _, c := range benchConfigs {
+		b.Run(c.name, func(b *testing.B) {
+			repo, ids := benchRepo(b, c.opts)
+			b.ResetTimer()
+			bench(b, repo, ids)
+		})
+	}
+}
+
+func BenchmarkGetByID(b *testing.B) {
+	runConfigs(b, func(b *testing.B, repo *RepositoryDB, ids []int64) {
+		var n atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			ctx := context.Background()
+			for pb.Next() {
+				if _, err := repo.GetByID(ctx, ids[n.Add(1)%benchTasks]); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
+
+func BenchmarkCreate(b *testing.B) {
+	runConfigs(b, func(b *testing.B, repo *RepositoryDB, _ []int64) {
+		b.RunParallel(func(pb *testing.PB) {
+			ctx := context.Background()
+			for pb.Next() {
+				if _, err := repo.Create(ctx, "new task", "benchmark", "bench"); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
+
+func BenchmarkUpdate(b *testing.B) {
+	runConfigs(b, func(b *testing.B, repo *RepositoryDB, ids []int64) {
+		var n atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			ctx := context.Background()
+			for pb.Next() {
+				i := n.Add(1)
+				task := &model.Model{ID: ids[i%benchTasks], Title: fmt.Sprintf("update %d", i), Description: &benchDescription}
+				if _, err := repo.Update(ctx, task); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
+
+// BenchmarkMixed reads nine tasks for every update.
+func BenchmarkMixed(b *testing.B) {
+	runConfigs(b, func(b *testing.B, repo *RepositoryDB, ids []int64) {
+		var n atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			ctx := context.Background()
+			for pb.Next() {
+				i := n.Add(1)
+				id := ids[i%benchTasks]
+				var err error
+				if i%10 == 0 {
+					_, err = repo.Update(ctx, &model.Model{ID: id, Title: "mixed", Description: &benchDescription})
+				} else {
+					_, err = repo.GetByID(ctx, id)
+				}
+				if err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
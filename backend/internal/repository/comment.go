@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// AddComment, UpdateComment and DeleteComment record a comment event in
+// the outbox within the same transaction as the change. The event carries
+// the task the comment belongs to.
+func (r *RepositoryDB) AddComment(ctx context.Context, comment *model.Comment) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	task, err := taskInTx(ctx, tx, comment.TaskID)
+	if err != nil {
+		return err
+	}
+
+	comment.CreatedAt = utcNow()
+	comment.UpdatedAt = comment.CreatedAt
+	query := `INSERT INTO comment (task_id, author, body, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query,
+		comment.TaskID, comment.Author, comment.Body, comment.CreatedAt.UnixMilli(), comment.UpdatedAt.UnixMilli(),
+	)
+	if err != nil {
+		return err
+	}
+	if comment.ID, err = res.LastInsertId(); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvent(ctx, tx, events.NewComment(events.CommentAdded, task, comment)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *RepositoryDB) GetComment(ctx context.Context, id int64) (*model.Comment, error) {
+	query := `SELECT id, task_id, author, body, created_at, updated_at FROM comment WHERE id = ?`
+
+	comment, err := scanComment(r.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// ListComments returns the comments on a task oldest first, starting
+// after the comment with ID afterID.
+func (r *RepositoryDB) ListComments(ctx context.Context, taskID, afterID int64, limit int) ([]*model.Comment, error) {
+	query := `SELECT id, task_id, author, body, created_at, updated_at
+	          FROM comment
+	          WHERE task_id = ? AND id > ?
+	          ORDER BY id
+	          LIMIT ?`
+
+	rows, err := r.QueryContext(ctx, query, taskID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []*model.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// UpdateComment replaces the body of a comment.
+func (r *RepositoryDB) UpdateComment(ctx context.Context, comment *model.Comment) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	comment.UpdatedAt = utcNow()
+	res, err := tx.ExecContext(ctx, `UPDATE comment SET body = ?, updated_at = ? WHERE id = ?`,
+		comment.Body, comment.UpdatedAt.UnixMilli(), comment.ID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+
+	task, err := taskInTx(ctx, tx, comment.TaskID)
+	if err != nil {
+		return err
+	}
+	if err := insertOutboxEvent(ctx, tx, events.NewComment(events.CommentEdited, task, comment)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *RepositoryDB) DeleteComment(ctx context.Context, id int64) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	comment, err := scanComment(tx.QueryRowContext(ctx,
+		`SELECT id, task_id, author, body, created_at, updated_at FROM comment WHERE id = ?`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+	task, err := taskInTx(ctx, tx, comment.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM comment WHERE id = ?`, id); err != nil {
+		return err
+	}
+	if err := insertOutboxEvent(ctx, tx, events.NewComment(events.CommentDeleted, task, comment)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// taskInTx loads a task within tx, returning ErrNotFound if it does not
+// exist.
+func taskInTx(ctx context.Context, tx *Tx, id int64) (*model.Model, error) {
+	task, err := scanTask(tx.QueryRowContext(ctx,
+		`SELECT `+taskColumns+` FROM task WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return task, err
+}
+
+func scanComment(row scanner) (*model.Comment, error) {
+	comment := &model.Comment{}
+	var createdAt, updatedAt int64
+
+	if err := row.Scan(
+		&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &createdAt, &updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	comment.CreatedAt = fromMillis(createdAt)
+	comment.UpdatedAt = fromMillis(updatedAt)
+
+	return comment, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+)
+
+// RepositoryDB runs queries against the tenant database attached to the
+// context, or against Pool for calls without a tenant. Reads outside
+// transactions go to the reader pool, everything else to the writer, and
+// statements are prepared once and reused.
+type RepositoryDB struct {
+	*db.Pool
+}
+
+func (r *RepositoryDB) conn(ctx context.Context) *db.Pool {
+	if p, ok := tenant.DBFrom(ctx); ok {
+		return p
+	}
+	return r.Pool
+}
+
+// stmt returns the prepared statement for query, or nil if it cannot be
+// cached; the caller then runs query on sqlDB directly.
+func (r *RepositoryDB) stmt(ctx context.Context, query string) (*sql.Stmt, *sql.DB, error) {
+	p := r.conn(ctx)
+	if db.IsRead(query) {
+		stmt, err := p.ReadStmt(ctx, query)
+		return stmt, p.Reader, err
+	}
+	stmt, err := p.WriteStmt(ctx, query)
+	return stmt, p.Writer, err
+}
+
+func (r *RepositoryDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	stmt, sqlDB, err := r.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return sqlDB.QueryContext(ctx, query, args...)
+	}
+	return stmt.QueryContext(ctx, args...)
+}
+
+func (r *RepositoryDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	stmt, sqlDB, err := r.stmt(ctx, query)
+	if err != nil || stmt == nil {
+		// Unprepared, the query reports its own error through Row.Scan.
+		return sqlDB.QueryRowContext(ctx, query, args...)
+	}
+	return stmt.QueryRowContext(ctx, args...)
+}
+
+func (r *RepositoryDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	stmt, sqlDB, err := r.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return sqlDB.ExecContext(ctx, query, args...)
+	}
+	return stmt.ExecContext(ctx, args...)
+}
+
+func (r *RepositoryDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	p := r.conn(ctx)
+	tx, err := p.Writer.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, pool: p}, nil
+}
+
+// Tx is a writer transaction that reuses cached prepared statements. The
+// transaction holds the only writer connection, so statements cannot be
+// prepared during it; queries it ran unprepared are prepared once it
+// ends.
+type Tx struct {
+	*sql.Tx
+	pool   *db.Pool
+	missed []string
+	once   sync.Once
+}
+
+func (tx *Tx) stmt(ctx context.Context, query string) *sql.Stmt {
+	stmt := tx.pool.CachedWriteStmt(query)
+	if stmt == nil {
+		tx.missed = append(tx.missed, query)
+		return nil
+	}
+	return tx.Tx.StmtContext(ctx, stmt)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if stmt := tx.stmt(ctx, query); stmt != nil {
+		return stmt.QueryContext(ctx, args...)
+	}
+	return tx.Tx.QueryContext(ctx, query, args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if stmt := tx.stmt(ctx, query); stmt != nil {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return tx.Tx.QueryRowContext(ctx, query, args...)
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if stmt := tx.stmt(ctx, query); stmt != nil {
+		return stmt.ExecContext(ctx, args...)
+	}
+	return tx.Tx.ExecContext(ctx, query, args...)
+}
+
+func (tx *Tx) Commit() error {
+	err := tx.Tx.Commit()
+	tx.prepareMissed()
+	return err
+}
+
+func (tx *Tx) Rollback() error {
+	err := tx.Tx.Rollback()
+	tx.prepareMissed()
+	return err
+}
+
+func (tx *Tx) prepareMissed() {
+	tx.once.Do(func() {
+		tx.pool.PrepareWrite(context.Background(), tx.missed...)
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// FeedToken returns the calendar feed token of owner, or an empty string
+// if the owner has none yet.
+func (r *RepositoryDB) FeedToken(ctx context.Context, owner string) (string, error) {
+	var token string
+	query := `SELECT token FROM calendar_feed WHERE owner = ?`
+
+	if err := r.QueryRowContext(ctx, query, owner).Scan(&token); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return token, nil
+}
+
+// SaveFeedToken sets the calendar feed token of owner, replacing the
+// previous one.
+func (r *RepositoryDB) SaveFeedToken(ctx context.Context, owner, token string) error {
+	query := `INSERT INTO calendar_feed (owner, token, created_at) VALUES (?, ?, ?)
+	          ON CONFLICT(owner) DO UPDATE SET token = excluded.token, created_at = excluded.created_at`
+
+	_, err := r.ExecContext(ctx, query, owner, token, utcNow().UnixMilli())
+	return err
+}
+
+// FeedOwner resolves a calendar feed token. It returns ErrNotFound for
+// unknown tokens.
+func (r *RepositoryDB) FeedOwner(ctx context.Context, token string) (string, error) {
+	var owner string
+	query := `SELECT owner FROM calendar_feed WHERE token = ?`
+
+	if err := r.QueryRowContext(ctx, query, token).Scan(&owner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	return owner, nil
+}
+
+func (r *RepositoryDB) ListByOwner(ctx context.Context, owner string) ([]*model.Model, error) {
+	tasks := []*model.Model{}
+	query := `SELECT ` + taskColumns + `
+	          FROM task
+	          WHERE owner = ?
+	          ORDER BY created_at DESC`
+
+	rows, err := r.QueryContext(ctx, query, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
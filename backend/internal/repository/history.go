@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// insertHistory appends a history entry for a change made in tx. The
+// actor is the authenticated caller, if any.
+func insertHistory(ctx context.Context, tx *Tx, taskID int64, action string, changes []model.FieldChange) error {
+	if changes == nil {
+		changes = []model.FieldChange{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	actor, _ := auth.PrincipalFrom(ctx)
+	query := `INSERT INTO task_history (task_id, action, actor, changed_at, changes) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, query, taskID, action, actor, utcNow().UnixMilli(), data)
+	return err
+}
+
+// diffTasks lists the fields that differ between old and new. A nil task
+// stands for "did not exist", so every field appears on one side only.
+func diffTasks(old, new *model.Model) []model.FieldChange {
+	fields := func(t *model.Model) []*string {
+		if t == nil {
+			return []*string{nil, nil, nil}
+		}
+		completed := strconv.FormatBool(t.Completed)
+		desc := ""
+		if t.Description != nil {
+			desc = *t.Description
+		}
+		return []*string{&t.Title, &desc, &completed}
+	}
+
+	names := []string{"title", "description", "completed"}
+	oldValues, newValues := fields(old), fields(new)
+
+	var changes []model.FieldChange
+	for i, name := range names {
+		o, n := oldValues[i], newValues[i]
+		if o != nil && n != nil && *o == *n {
+			continue
+		}
+		changes = append(changes, model.FieldChange{Field: name, Old: clone(o), New: clone(n)})
+	}
+	return changes
+}
+
+func clone(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+// TaskHistory returns the history of a task in chronological order,
+// starting after the entry with ID afterID.
+func (r *RepositoryDB) TaskHistory(ctx context.Context, taskID, afterID int64, limit int) ([]*model.TaskChange, error) {
+	query := `SELECT id, task_id, action, actor, changed_at, changes
+	          FROM task_history
+	          WHERE task_id = ? AND id > ?
+	          ORDER BY id
+	          LIMIT ?`
+
+	return r.queryHistory(ctx, query, taskID, afterID, limit)
+}
+
+// ChangesBetween returns the changes to all tasks made in [from, to), in
+// chronological order, starting after the entry with ID afterID.
+func (r *RepositoryDB) ChangesBetween(ctx context.Context, from, to time.Time, afterID int64, limit int) ([]*model.TaskChange, error) {
+	query := `SELECT id, task_id, action, actor, changed_at, changes
+	          FROM task_history
+	          WHERE changed_at >= ? AND changed_at < ? AND id > ?
+	          ORDER BY id
+	          LIMIT ?`
+
+	return r.queryHistory(ctx, query, from.UnixMilli(), to.UnixMilli(), afterID, limit)
+}
+
+func (r *RepositoryDB) queryHistory(ctx context.Context, query string, args ...any) ([]*model.TaskChange, error) {
+	rows, err := r.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []*model.TaskChange{}
+	for rows.Next() {
+		c := &model.TaskChange{}
+		var changedAt int64
+		var changes []byte
+		if err := rows.Scan(&c.ID, &c.TaskID, &c.Action, &c.Actor, &changedAt, &changes); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &c.Changes); err != nil {
+			return nil, err
+		}
+		c.ChangedAt = fromMillis(changedAt)
+		history = append(history, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
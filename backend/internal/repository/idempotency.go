@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+const idempotencyColumns = `principal, method, key, request_hash, response_type, response, created_at, expires_at`
+
+// ClaimIdempotencyKey stores k as running, unless an unexpired entry with
+// the same principal, method and key exists; that entry is returned
+// instead. A running entry created before staleBefore is considered
+// abandoned and is taken over.
+func (r *RepositoryDB) ClaimIdempotencyKey(ctx context.Context, k *model.IdempotencyKey, staleBefore time.Time) (*model.IdempotencyKey, error) {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	existing, err := scanIdempotencyKey(tx.QueryRowContext(ctx,
+		`SELECT `+idempotencyColumns+` FROM idempotency_key
+		 WHERE principal = ? AND method = ? AND key = ? AND expires_at > ?`,
+		k.Principal, k.Method, k.Key, k.CreatedAt.UnixMilli()))
+	switch {
+	case err == nil && (existing.Completed() || existing.CreatedAt.After(staleBefore)):
+		return existing, nil
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	query := `INSERT INTO idempotency_key (principal, method, key, request_hash, created_at, expires_at)
+	          VALUES (?, ?, ?, ?, ?, ?)
+	          ON CONFLICT (principal, method, key) DO UPDATE SET
+	              request_hash = excluded.request_hash, response_type = '', response = NULL,
+	              created_at = excluded.created_at, expires_at = excluded.expires_at`
+	if _, err := tx.ExecContext(ctx, query,
+		k.Principal, k.Method, k.Key, k.RequestHash, k.CreatedAt.UnixMilli(), k.ExpiresAt.UnixMilli(),
+	); err != nil {
+		return nil, err
+	}
+
+	return nil, tx.Commit()
+}
+
+// CompleteIdempotencyKey stores the response of a claimed key.
+func (r *RepositoryDB) CompleteIdempotencyKey(ctx context.Context, k *model.IdempotencyKey) error {
+	query := `UPDATE idempotency_key SET response_type = ?, response = ?
+	          WHERE principal = ? AND method = ? AND key = ? AND created_at = ?`
+
+	_, err := r.ExecContext(ctx, query,
+		k.ResponseType, k.Response, k.Principal, k.Method, k.Key, k.CreatedAt.UnixMilli())
+	return err
+}
+
+// ReleaseIdempotencyKey deletes a claimed key whose call failed, so it can
+// be retried.
+func (r *RepositoryDB) ReleaseIdempotencyKey(ctx context.Context, k *model.IdempotencyKey) error {
+	query := `DELETE FROM idempotency_key
+	          WHERE principal = ? AND method = ? AND key = ? AND created_at = ? AND response_type = ''`
+
+	_, err := r.ExecContext(ctx, query, k.Principal, k.Method, k.Key, k.CreatedAt.UnixMilli())
+	return err
+}
+
+// DeleteExpiredIdempotencyKeys deletes the keys that expired by now and
+// returns how many there were.
+func (r *RepositoryDB) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.ExecContext(ctx, `DELETE FROM idempotency_key WHERE expires_at <= ?`, now.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func scanIdempotencyKey(row scanner) (*model.IdempotencyKey, error) {
+	k := &model.IdempotencyKey{}
+	var createdAt, expiresAt int64
+	if err := row.Scan(
+		&k.Principal, &k.Method, &k.Key, &k.RequestHash, &k.ResponseType, &k.Response, &createdAt, &expiresAt,
+	); err != nil {
+		return nil, err
+	}
+	k.CreatedAt = fromMillis(createdAt)
+	k.ExpiresAt = fromMillis(expiresAt)
+
+	return k, nil
+}
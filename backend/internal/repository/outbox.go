@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+)
+
+func insertOutboxEvent(ctx context.Context, tx *Tx, ev events.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	at := ev.OccurredAt.UnixMilli()
+	query := `INSERT INTO event_outbox (event_id, event_type, payload, created_at, next_attempt_at) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, query, ev.ID, ev.Type, payload, at, at)
+	return err
+}
+
+// PendingOutboxEvents returns unpublished events in the order they were
+// recorded, including ones still waiting for a retry.
+func (r *RepositoryDB) PendingOutboxEvents(ctx context.Context, limit int) ([]events.Pending, error) {
+	query := `SELECT payload, attempts, next_attempt_at
+	          FROM event_outbox
+	          WHERE published_at IS NULL
+	          ORDER BY id
+	          LIMIT ?`
+
+	rows, err := r.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []events.Pending
+	for rows.Next() {
+		var (
+			p             events.Pending
+			payload       []byte
+			nextAttemptAt int64
+		)
+		if err := rows.Scan(&payload, &p.Attempts, &nextAttemptAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &p.Event); err != nil {
+			return nil, err
+		}
+		p.NextAttemptAt = fromMillis(nextAttemptAt)
+		pending = append(pending, p)
+	}
+
+	return pending, rows.Err()
+}
+
+func (r *RepositoryDB) MarkOutboxPublished(ctx context.Context, eventID string, at time.Time) error {
+	query := `UPDATE event_outbox SET published_at = ?, last_error = '' WHERE event_id = ?`
+	_, err := r.ExecContext(ctx, query, at.UnixMilli(), eventID)
+	return err
+}
+
+func (r *RepositoryDB) MarkOutboxFailed(ctx context.Context, eventID string, attempts int, next time.Time, lastError string) error {
+	query := `UPDATE event_outbox SET attempts = ?, next_attempt_at = ?, last_error = ? WHERE event_id = ?`
+	_, err := r.ExecContext(ctx, query, attempts, next.UnixMilli(), lastError, eventID)
+	return err
+}
+
+// DeletePublishedOutboxEvents drops events published before the given
+// time and returns how many were removed.
+func (r *RepositoryDB) DeletePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM event_outbox WHERE published_at IS NOT NULL AND published_at < ?`
+	res, err := r.ExecContext(ctx, query, before.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
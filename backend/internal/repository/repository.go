@@ -4,56 +4,72 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/Elmar006/todo_grpc/internal/events"
 	"github.com/Elmar006/todo_grpc/internal/model"
 )
 
-type RepositoryDB struct {
-	*sql.DB
-}
-
 var (
-	ErrNotFound    = errors.New("failed: rows affected count = 0")
-	ErrInvalidData = errors.New("invalid data")
+	ErrNotFound      = errors.New("failed: rows affected count = 0")
+	ErrQuotaExceeded = errors.New("task quota exceeded")
 )
 
-func (r *RepositoryDB) Create(ctx context.Context, title, description string) (*model.Model, error) {
-	if title == "" {
-		return nil, ErrInvalidData
-	}
+// Create, Update and Delete record a task event in the outbox, an entry
+// in the task history and, unless the task is gone, a revision snapshot
+// within the same transaction as the change, so all of them exist exactly
+// when the change is committed. Create and Update return the row as
+// stored, read back with RETURNING.
+func (r *RepositoryDB) Create(ctx context.Context, title, description, owner string) (*model.Model, error) {
+	return r.CreateTask(ctx, model.NewTask{Title: title, Description: description, Owner: owner})
+}
 
-	query := `INSERT INTO task (title, description) VALUES (?, ?)`
-	res, err := r.ExecContext(ctx, query, title, description)
+// CreateTask creates t. The insert itself counts the owner's tasks
+// against t.Quota, so concurrent creates cannot exceed it; it fails with
+// ErrQuotaExceeded when the owner is at the quota.
+func (r *RepositoryDB) CreateTask(ctx context.Context, t model.NewTask) (*model.Model, error) {
+	tx, err := r.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback()
 
-	id, err := res.LastInsertId()
+	now := utcNow().UnixMilli()
+	query := `INSERT INTO task (title, description, completed, owner, workspace_id, created_at, updated_at)
+	          SELECT ?, ?, ?, ?, ?, ?, ?
+	          WHERE ? <= 0 OR (SELECT COUNT(*) FROM task WHERE owner = ?) < ?
+	          RETURNING ` + taskColumns
+	task, err := scanTask(tx.QueryRowContext(ctx, query,
+		t.Title, t.Description, t.Completed, t.Owner, t.WorkspaceID, now, now, t.Quota, t.Owner, t.Quota))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrQuotaExceeded
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	task := &model.Model{
-		ID:          id,
-		Title:       title,
-		Description: &description,
-		Completed:   false,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	if err := insertOutboxEvent(ctx, tx, events.New(events.TaskCreated, task)); err != nil {
+		return nil, err
+	}
+	if err := insertHistory(ctx, tx, task.ID, model.ActionCreated, diffTasks(nil, task)); err != nil {
+		return nil, err
+	}
+	if err := insertRevision(ctx, tx, task); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
 
 	return task, nil
 }
 
 func (r *RepositoryDB) GetByID(ctx context.Context, id int64) (*model.Model, error) {
-	task := &model.Model{}
-	query := `SELECT id, title, description, completed, created_at, updated_at FROM task WHERE id = ?`
+	query := `SELECT ` + taskColumns + ` FROM task WHERE id = ?`
 
-	err := r.QueryRowContext(ctx, query, id).Scan(
-		&task.ID, &task.Title, &task.Description,
-		&task.Completed, &task.CreatedAt, &task.UpdatedAt,
-	)
+	task, err := scanTask(r.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -65,27 +81,42 @@ func (r *RepositoryDB) GetByID(ctx context.Context, id int64) (*model.Model, err
 }
 
 func (r *RepositoryDB) List(ctx context.Context, filter string) ([]*model.Model, error) {
+	return r.SearchTasks(ctx, model.TaskQuery{Text: filter})
+}
+
+// SearchTasks returns the tasks matching q, newest first.
+func (r *RepositoryDB) SearchTasks(ctx context.Context, q model.TaskQuery) ([]*model.Model, error) {
 	tasks := []*model.Model{}
-	query := `SELECT id, title, description, completed, created_at, updated_at
+	query := `SELECT ` + taskColumns + `
 	          FROM task
-	          WHERE title LIKE ? OR description LIKE ?
-	          ORDER BY created_at DESC`
+	          WHERE (title LIKE ? OR description LIKE ?)`
+	args := []any{"%" + q.Text + "%", "%" + q.Text + "%"}
 
-	rows, err := r.QueryContext(ctx, query, "%"+filter+"%", "%"+filter+"%")
+	if q.WorkspaceID != 0 {
+		query += ` AND workspace_id = ?`
+		args = append(args, q.WorkspaceID)
+	}
+	if q.Assignee != "" {
+		query += ` AND EXISTS (SELECT 1 FROM task_assignee WHERE task_id = task.id AND assignee = ?)`
+		args = append(args, q.Assignee)
+	}
+	if q.Unassigned {
+		query += ` AND NOT EXISTS (SELECT 1 FROM task_assignee WHERE task_id = task.id)`
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := r.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		task := model.Model{}
-		if err := rows.Scan(
-			&task.ID, &task.Title, &task.Description,
-			&task.Completed, &task.CreatedAt, &task.UpdatedAt,
-		); err != nil {
+		task, err := scanTask(rows)
+		if err != nil {
 			return nil, err
 		}
-		tasks = append(tasks, &task)
+		tasks = append(tasks, task)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -95,43 +126,155 @@ func (r *RepositoryDB) List(ctx context.Context, filter string) ([]*model.Model,
 	return tasks, nil
 }
 
-func (r *RepositoryDB) Update(ctx context.Context, task *model.Model) error {
-	task.UpdatedAt = time.Now()
-	query := `UPDATE task SET title = ?, description = ?, completed = ?, updated_at = ? WHERE id = ?`
+func (r *RepositoryDB) CountByOwner(ctx context.Context, owner string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM task WHERE owner = ?`
 
-	res, err := r.ExecContext(ctx, query,
-		task.Title, task.Description, task.Completed, task.UpdatedAt, task.ID,
-	)
+	if err := r.QueryRowContext(ctx, query, owner).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Update records task.completed instead of task.updated when the change
+// marks an open task as done.
+func (r *RepositoryDB) Update(ctx context.Context, task *model.Model) (*model.Model, error) {
+	tx, err := r.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	count, err := res.RowsAffected()
+	prev, err := scanTask(tx.QueryRowContext(ctx,
+		`SELECT `+taskColumns+` FROM task WHERE id = ?`, task.ID))
 	if err != nil {
-		return err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
 	}
-	if count == 0 {
-		return ErrNotFound
+
+	query := `UPDATE task SET title = ?, description = ?, completed = ?, updated_at = ? WHERE id = ?
+	          RETURNING ` + taskColumns
+	updated, err := scanTask(tx.QueryRowContext(ctx, query,
+		task.Title, task.Description, task.Completed, utcNow().UnixMilli(), task.ID,
+	))
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	eventType := events.TaskUpdated
+	if updated.Completed && !prev.Completed {
+		eventType = events.TaskCompleted
+	}
+	if err := insertOutboxEvent(ctx, tx, events.New(eventType, updated)); err != nil {
+		return nil, err
+	}
+	if err := insertHistory(ctx, tx, updated.ID, model.ActionUpdated, diffTasks(prev, updated)); err != nil {
+		return nil, err
+	}
+	if err := insertRevision(ctx, tx, updated); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 func (r *RepositoryDB) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM task WHERE id = ?`
-
-	res, err := r.ExecContext(ctx, query, id)
+	tx, err := r.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	count, err := res.RowsAffected()
+	task, err := scanTask(tx.QueryRowContext(ctx,
+		`SELECT `+taskColumns+` FROM task WHERE id = ?`, id))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task WHERE id = ?`, id); err != nil {
+		return err
+	}
+	// Comments, attachments, assignments and shares go with the task; restoring
+	// it from a revision does not bring them back.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM comment WHERE task_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_assignee WHERE task_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_share WHERE task_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM share_link WHERE task_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE attachment SET deleted_at = ? WHERE task_id = ? AND deleted_at IS NULL`,
+		utcNow().UnixMilli(), id); err != nil {
+		return err
+	}
+	if err := insertOutboxEvent(ctx, tx, events.New(events.TaskDeleted, task)); err != nil {
 		return err
 	}
-	if count == 0 {
-		return ErrNotFound
+	if err := insertHistory(ctx, tx, id, model.ActionDeleted, diffTasks(task, nil)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// taskColumns selects a task row for scanTask, including its assignees
+// joined with the unit separator.
+const taskColumns = `id, title, description, completed, owner, created_at, updated_at, workspace_id,
+	(SELECT group_concat(assignee, char(31)) FROM task_assignee WHERE task_assignee.task_id = task.id)`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(row scanner) (*model.Model, error) {
+	task := &model.Model{}
+	var createdAt, updatedAt int64
+	var assignees sql.NullString
+
+	if err := row.Scan(
+		&task.ID, &task.Title, &task.Description,
+		&task.Completed, &task.Owner, &createdAt, &updatedAt,
+		&task.WorkspaceID, &assignees,
+	); err != nil {
+		return nil, err
+	}
+	if assignees.Valid && assignees.String != "" {
+		task.AssigneeIDs = strings.Split(assignees.String, "\x1f")
+		slices.Sort(task.AssigneeIDs)
 	}
+	task.CreatedAt = fromMillis(createdAt)
+	task.UpdatedAt = fromMillis(updatedAt)
+
+	return task, nil
+}
+
+// Timestamps are stored as UTC Unix milliseconds. utcNow returns the
+// current time as it reads back from the database.
+func utcNow() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.UnixMilli(ms).UTC()
+}
 
-	return nil
+// VacuumInto writes a compacted, transactionally consistent copy of the
+// database to path, which must not exist.
+func (r *RepositoryDB) VacuumInto(ctx context.Context, path string) error {
+	_, err := r.ExecContext(ctx, `VACUUM INTO ?`, path)
+	return err
 }
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+func newTestRepo(t *testing.T) *RepositoryDB {
+	t.Helper()
+	pool, err := db.Open(filepath.Join(t.TempDir(), "todo.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return &RepositoryDB{Pool: pool}
+}
+
+func TestCreateAndUpdateReturnStoredRow(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepo(t)
+
+	ws := &model.Workspace{Name: "Home", CreatedBy: "alice"}
+	if err := repo.CreateWorkspace(ctx, ws); err != nil {
+		t.Fatal(err)
+	}
+	created, err := repo.CreateTask(ctx, model.NewTask{WorkspaceID: ws.ID, Title: "Buy milk", Description: "2 litres", Owner: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertStored(t, repo, created)
+
+	if _, err := repo.SetAssignees(ctx, created.ID, []string{"bob", "alice"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Only the edited fields are set, as a client sending a partial
+	// update would; the rest must come back from the database.
+	desc := "3 litres"
+	updated, err := repo.Update(ctx, &model.Model{ID: created.ID, Title: "Buy oat milk", Description: &desc, Completed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Owner != "alice" || updated.WorkspaceID != ws.ID || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("expected stored owner, workspace and created_at, got %+v", updated)
+	}
+	if !reflect.DeepEqual(updated.AssigneeIDs, []string{"alice", "bob"}) {
+		t.Errorf("expected assignees [alice bob], got %v", updated.AssigneeIDs)
+	}
+	assertStored(t, repo, updated)
+}
+
+func assertStored(t *testing.T, repo *RepositoryDB, returned *model.Model) {
+	t.Helper()
+	stored, err := repo.GetByID(context.Background(), returned.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(returned, stored) {
+		t.Errorf("returned task differs from stored one:\nreturned %+v\nstored   %+v", returned, stored)
+	}
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// ErrTaskExists is returned when restoring a task that was not deleted.
+var ErrTaskExists = errors.New("task exists")
+
+// insertRevision snapshots task as the next revision of it.
+func insertRevision(ctx context.Context, tx *Tx, task *model.Model) error {
+	actor, _ := auth.PrincipalFrom(ctx)
+	query := `INSERT INTO task_revision
+	              (task_id, revision, title, description, completed, owner, created_at, updated_at, workspace_id, actor, revised_at)
+	          SELECT ?, COALESCE(MAX(revision), 0) + 1, ?, ?, ?, ?, ?, ?, ?, ?, ?
+	          FROM task_revision WHERE task_id = ?`
+
+	_, err := tx.ExecContext(ctx, query,
+		task.ID, task.Title, task.Description, task.Completed, task.Owner,
+		task.CreatedAt.UnixMilli(), task.UpdatedAt.UnixMilli(), task.WorkspaceID, actor, utcNow().UnixMilli(), task.ID,
+	)
+	return err
+}
+
+// ListRevisions returns the revisions of a task, newest first. They are
+// kept after the task is deleted.
+func (r *RepositoryDB) ListRevisions(ctx context.Context, taskID int64) ([]*model.TaskRevision, error) {
+	query := `SELECT revision, task_id, title, description, completed, owner, created_at, updated_at, workspace_id, actor, revised_at
+	          FROM task_revision
+	          WHERE task_id = ?
+	          ORDER BY revision DESC`
+
+	rows, err := r.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*model.TaskRevision{}
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// Revert sets the title, description and completion of a task back to the
+// given revision. A deleted task is recreated with its original ID. The
+// result is saved as a new revision.
+func (r *RepositoryDB) Revert(ctx context.Context, taskID, revision int64) (*model.Model, error) {
+	return r.revert(ctx, taskID, revision, false)
+}
+
+// Restore recreates a deleted task from its last revision. It returns
+// ErrTaskExists if the task was not deleted.
+func (r *RepositoryDB) Restore(ctx context.Context, taskID int64) (*model.Model, error) {
+	return r.revert(ctx, taskID, 0, true)
+}
+
+// revert applies the given revision, or the latest one when revision is 0.
+func (r *RepositoryDB) revert(ctx context.Context, taskID, revision int64, restoreOnly bool) (*model.Model, error) {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT revision, task_id, title, description, completed, owner, created_at, updated_at, workspace_id, actor, revised_at
+	          FROM task_revision
+	          WHERE task_id = ? AND (revision = ? OR ? = 0)
+	          ORDER BY revision DESC
+	          LIMIT 1`
+	rev, err := scanRevision(tx.QueryRowContext(ctx, query, taskID, revision, revision))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	prev, err := scanTask(tx.QueryRowContext(ctx,
+		`SELECT `+taskColumns+` FROM task WHERE id = ?`, taskID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if prev != nil && restoreOnly {
+		return nil, ErrTaskExists
+	}
+
+	task := rev.Task
+	task.UpdatedAt = utcNow()
+
+	var (
+		eventType = events.TaskUpdated
+		action    = model.ActionReverted
+	)
+	if prev == nil {
+		eventType, action = events.TaskCreated, model.ActionRestored
+		query := `INSERT INTO task (id, title, description, completed, owner, created_at, updated_at, workspace_id)
+		          VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		if _, err := tx.ExecContext(ctx, query,
+			task.ID, task.Title, task.Description, task.Completed, task.Owner, task.CreatedAt.UnixMilli(), task.UpdatedAt.UnixMilli(), task.WorkspaceID,
+		); err != nil {
+			return nil, err
+		}
+	} else {
+		if task.Completed && !prev.Completed {
+			eventType = events.TaskCompleted
+		}
+		task.Owner, task.CreatedAt = prev.Owner, prev.CreatedAt
+		task.WorkspaceID, task.AssigneeIDs = prev.WorkspaceID, prev.AssigneeIDs
+		query := `UPDATE task SET title = ?, description = ?, completed = ?, updated_at = ? WHERE id = ?`
+		if _, err := tx.ExecContext(ctx, query,
+			task.Title, task.Description, task.Completed, task.UpdatedAt.UnixMilli(), task.ID,
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := insertOutboxEvent(ctx, tx, events.New(eventType, task)); err != nil {
+		return nil, err
+	}
+	if err := insertHistory(ctx, tx, task.ID, action, diffTasks(prev, task)); err != nil {
+		return nil, err
+	}
+	if err := insertRevision(ctx, tx, task); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func scanRevision(row scanner) (*model.TaskRevision, error) {
+	rev := &model.TaskRevision{Task: &model.Model{}}
+	var createdAt, updatedAt, revisedAt int64
+
+	if err := row.Scan(
+		&rev.Revision, &rev.Task.ID, &rev.Task.Title, &rev.Task.Description, &rev.Task.Completed,
+		&rev.Task.Owner, &createdAt, &updatedAt, &rev.Task.WorkspaceID, &rev.Actor, &revisedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	rev.Task.CreatedAt = fromMillis(createdAt)
+	rev.Task.UpdatedAt = fromMillis(updatedAt)
+	rev.RevisedAt = fromMillis(revisedAt)
+
+	return rev, nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// ShareTask grants a user a role on a task, replacing an earlier grant.
+// It returns ErrNotFound if the task does not exist.
+func (r *RepositoryDB) ShareTask(ctx context.Context, share *model.TaskShare) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := taskInTx(ctx, tx, share.TaskID); err != nil {
+		return err
+	}
+
+	share.GrantedAt = utcNow()
+	query := `INSERT INTO task_share (task_id, user, role, granted_by, granted_at) VALUES (?, ?, ?, ?, ?)
+	          ON CONFLICT (task_id, user) DO UPDATE
+	          SET role = excluded.role, granted_by = excluded.granted_by, granted_at = excluded.granted_at`
+	if _, err := tx.ExecContext(ctx, query,
+		share.TaskID, share.User, share.Role, share.GrantedBy, share.GrantedAt.UnixMilli(),
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UnshareTask removes a user's grant on a task. It returns ErrNotFound if
+// there is none.
+func (r *RepositoryDB) UnshareTask(ctx context.Context, taskID int64, user string) error {
+	res, err := r.ExecContext(ctx, `DELETE FROM task_share WHERE task_id = ? AND user = ?`, taskID, user)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RepositoryDB) ListTaskShares(ctx context.Context, taskID int64) ([]*model.TaskShare, error) {
+	query := `SELECT task_id, user, role, granted_by, granted_at
+	          FROM task_share
+	          WHERE task_id = ?
+	          ORDER BY user`
+
+	rows, err := r.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := []*model.TaskShare{}
+	for rows.Next() {
+		s := &model.TaskShare{}
+		var grantedAt int64
+		if err := rows.Scan(&s.TaskID, &s.User, &s.Role, &s.GrantedBy, &grantedAt); err != nil {
+			return nil, err
+		}
+		s.GrantedAt = fromMillis(grantedAt)
+		shares = append(shares, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return shares, nil
+}
+
+// SharedWith returns the roles user has been granted, by task ID.
+func (r *RepositoryDB) SharedWith(ctx context.Context, user string) (map[int64]string, error) {
+	rows, err := r.QueryContext(ctx, `SELECT task_id, role FROM task_share WHERE user = ?`, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make(map[int64]string)
+	for rows.Next() {
+		var taskID int64
+		var role string
+		if err := rows.Scan(&taskID, &role); err != nil {
+			return nil, err
+		}
+		roles[taskID] = role
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// TaskShareRole returns the role user has been granted on a task, or ""
+// if there is none.
+func (r *RepositoryDB) TaskShareRole(ctx context.Context, taskID int64, user string) (string, error) {
+	var role string
+	err := r.QueryRowContext(ctx,
+		`SELECT role FROM task_share WHERE task_id = ? AND user = ?`, taskID, user,
+	).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
+// CreateShareLink stores a share link under the hash of its token. It
+// returns ErrNotFound if the task does not exist.
+func (r *RepositoryDB) CreateShareLink(ctx context.Context, link *model.ShareLink, tokenHash string) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := taskInTx(ctx, tx, link.TaskID); err != nil {
+		return err
+	}
+
+	link.CreatedAt = utcNow()
+	query := `INSERT INTO share_link (task_id, token_hash, created_by, created_at, expires_at) VALUES (?, ?, ?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query,
+		link.TaskID, tokenHash, link.CreatedBy, link.CreatedAt.UnixMilli(), link.ExpiresAt.UnixMilli())
+	if err != nil {
+		return err
+	}
+	if link.ID, err = res.LastInsertId(); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *RepositoryDB) GetShareLink(ctx context.Context, id int64) (*model.ShareLink, error) {
+	query := `SELECT id, task_id, created_by, created_at, expires_at, revoked_at FROM share_link WHERE id = ?`
+
+	link, err := scanShareLink(r.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// ListShareLinks returns the links of a task, including expired and
+// revoked ones, newest first.
+func (r *RepositoryDB) ListShareLinks(ctx context.Context, taskID int64) ([]*model.ShareLink, error) {
+	query := `SELECT id, task_id, created_by, created_at, expires_at, revoked_at
+	          FROM share_link
+	          WHERE task_id = ?
+	          ORDER BY id DESC`
+
+	rows, err := r.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*model.ShareLink{}
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// RevokeShareLink marks a link revoked. Revoking a revoked link keeps the
+// original time.
+func (r *RepositoryDB) RevokeShareLink(ctx context.Context, id int64) (*model.ShareLink, error) {
+	if _, err := r.ExecContext(ctx, `UPDATE share_link SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`,
+		utcNow().UnixMilli(), id); err != nil {
+		return nil, err
+	}
+
+	return r.GetShareLink(ctx, id)
+}
+
+// SharedTaskID resolves the hash of a share token to its task. It returns
+// ErrNotFound for unknown, expired and revoked links.
+func (r *RepositoryDB) SharedTaskID(ctx context.Context, tokenHash string, now time.Time) (int64, error) {
+	var taskID int64
+	query := `SELECT task_id FROM share_link WHERE token_hash = ? AND revoked_at IS NULL AND expires_at > ?`
+
+	if err := r.QueryRowContext(ctx, query, tokenHash, now.UnixMilli()).Scan(&taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, err
+	}
+
+	return taskID, nil
+}
+
+func scanShareLink(row scanner) (*model.ShareLink, error) {
+	link := &model.ShareLink{}
+	var createdAt, expiresAt int64
+	var revokedAt sql.NullInt64
+	if err := row.Scan(&link.ID, &link.TaskID, &link.CreatedBy, &createdAt, &expiresAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	link.CreatedAt = fromMillis(createdAt)
+	link.ExpiresAt = fromMillis(expiresAt)
+	if revokedAt.Valid {
+		t := fromMillis(revokedAt.Int64)
+		link.RevokedAt = &t
+	}
+
+	return link, nil
+}
@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+func (r *RepositoryDB) CreateWebhook(ctx context.Context, hook *model.Webhook) error {
+	hook.CreatedAt = utcNow()
+	query := `INSERT INTO webhook (url, secret, events, owner, created_at) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.ExecContext(ctx, query,
+		hook.URL, hook.Secret, strings.Join(hook.Events, ","), hook.Owner, hook.CreatedAt.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	if hook.ID, err = res.LastInsertId(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryDB) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	hooks := []*model.Webhook{}
+	query := `SELECT id, url, secret, events, owner, created_at FROM webhook ORDER BY id`
+
+	rows, err := r.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		hook := &model.Webhook{}
+		var eventList string
+		var createdAt int64
+		if err := rows.Scan(&hook.ID, &hook.URL, &hook.Secret, &eventList, &hook.Owner, &createdAt); err != nil {
+			return nil, err
+		}
+		if eventList != "" {
+			hook.Events = strings.Split(eventList, ",")
+		}
+		hook.CreatedAt = fromMillis(createdAt)
+		hooks = append(hooks, hook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hooks, nil
+}
+
+// DeleteWebhook removes the subscription together with its deliveries
+// and their log.
+func (r *RepositoryDB) DeleteWebhook(ctx context.Context, id int64) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM webhook WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_attempt WHERE delivery_id IN
+	          (SELECT id FROM webhook_delivery WHERE webhook_id = ?)`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM webhook_delivery WHERE webhook_id = ?`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// EnqueueWebhookDeliveries queues the event for every webhook subscribed
+// to its type and returns how many deliveries were created. Enqueuing an
+// event again does not create duplicates.
+func (r *RepositoryDB) EnqueueWebhookDeliveries(ctx context.Context, eventID, eventType string, payload []byte, at time.Time) (int64, error) {
+	query := `INSERT OR IGNORE INTO webhook_delivery (webhook_id, event_id, event_type, payload, next_attempt_at, created_at)
+	          SELECT id, ?, ?, ?, ?, ? FROM webhook
+	          WHERE events = '' OR instr(',' || events || ',', ',' || ? || ',') > 0`
+
+	res, err := r.ExecContext(ctx, query, eventID, eventType, payload, at.UnixMilli(), utcNow().UnixMilli(), eventType)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// DueWebhookDeliveries returns pending deliveries whose next attempt is
+// not after now, oldest first.
+func (r *RepositoryDB) DueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	query := `SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	                 d.next_attempt_at, d.created_at, w.url, w.secret
+	          FROM webhook_delivery d JOIN webhook w ON w.id = d.webhook_id
+	          WHERE d.status = ? AND d.next_attempt_at <= ?
+	          ORDER BY d.next_attempt_at, d.id
+	          LIMIT ?`
+
+	rows, err := r.QueryContext(ctx, query, model.DeliveryPending, now.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*model.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows, true)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RecordWebhookAttempt stores the outcome of an attempt: the delivery's
+// new status, attempt count and next attempt time, plus a log entry.
+func (r *RepositoryDB) RecordWebhookAttempt(ctx context.Context, d *model.WebhookDelivery, a model.WebhookAttempt) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE webhook_delivery SET status = ?, attempts = ?, next_attempt_at = ? WHERE id = ?`,
+		d.Status, d.Attempts, d.NextAttemptAt.UnixMilli(), d.ID,
+	); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO webhook_attempt (delivery_id, attempted_at, status_code, error, duration_ms) VALUES (?, ?, ?, ?, ?)`,
+		d.ID, a.AttemptedAt.UnixMilli(), a.StatusCode, a.Error, a.Duration.Milliseconds(),
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListWebhookDeliveries returns the newest deliveries of a webhook with
+// their attempt log.
+func (r *RepositoryDB) ListWebhookDeliveries(ctx context.Context, webhookID int64, limit int) ([]*model.WebhookDelivery, error) {
+	query := `SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at
+	          FROM webhook_delivery
+	          WHERE webhook_id = ?
+	          ORDER BY id DESC
+	          LIMIT ?`
+
+	rows, err := r.QueryContext(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := []*model.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows, false)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, d := range deliveries {
+		if d.Log, err = r.webhookAttempts(ctx, d.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return deliveries, nil
+}
+
+// RetryWebhookDelivery makes a delivery pending again with a fresh
+// attempt budget.
+func (r *RepositoryDB) RetryWebhookDelivery(ctx context.Context, id int64, at time.Time) (*model.WebhookDelivery, error) {
+	query := `UPDATE webhook_delivery SET status = ?, attempts = 0, next_attempt_at = ? WHERE id = ?`
+
+	res, err := r.ExecContext(ctx, query, model.DeliveryPending, at.UnixMilli(), id)
+	if err != nil {
+		return nil, err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrNotFound
+	}
+
+	d, err := scanDelivery(r.QueryRowContext(ctx,
+		`SELECT id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at
+		 FROM webhook_delivery WHERE id = ?`, id), false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if d.Log, err = r.webhookAttempts(ctx, d.ID); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (r *RepositoryDB) webhookAttempts(ctx context.Context, deliveryID int64) ([]model.WebhookAttempt, error) {
+	query := `SELECT attempted_at, status_code, error, duration_ms
+	          FROM webhook_attempt WHERE delivery_id = ? ORDER BY id`
+
+	rows, err := r.QueryContext(ctx, query, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []model.WebhookAttempt
+	for rows.Next() {
+		var a model.WebhookAttempt
+		var attemptedAt, durationMs int64
+		if err := rows.Scan(&attemptedAt, &a.StatusCode, &a.Error, &durationMs); err != nil {
+			return nil, err
+		}
+		a.AttemptedAt = fromMillis(attemptedAt)
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+
+	return attempts, rows.Err()
+}
+
+func scanDelivery(row scanner, withTarget bool) (*model.WebhookDelivery, error) {
+	d := &model.WebhookDelivery{}
+	var nextAttemptAt, createdAt int64
+
+	dest := []any{
+		&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload,
+		&d.Status, &d.Attempts, &nextAttemptAt, &createdAt,
+	}
+	if withTarget {
+		dest = append(dest, &d.URL, &d.Secret)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	d.NextAttemptAt = fromMillis(nextAttemptAt)
+	d.CreatedAt = fromMillis(createdAt)
+
+	return d, nil
+}
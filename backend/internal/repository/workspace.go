@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// ErrLastOwner is returned when a change would leave a workspace without
+// an owner.
+var ErrLastOwner = errors.New("workspace must keep an owner")
+
+// CreateWorkspace creates a workspace with its creator as the owner.
+func (r *RepositoryDB) CreateWorkspace(ctx context.Context, ws *model.Workspace) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	ws.CreatedAt = utcNow()
+	res, err := tx.ExecContext(ctx, `INSERT INTO workspace (name, created_by, created_at) VALUES (?, ?, ?)`,
+		ws.Name, ws.CreatedBy, ws.CreatedAt.UnixMilli())
+	if err != nil {
+		return err
+	}
+	if ws.ID, err = res.LastInsertId(); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO workspace_member (workspace_id, member, role, added_at) VALUES (?, ?, ?, ?)`,
+		ws.ID, ws.CreatedBy, model.RoleOwner, ws.CreatedAt.UnixMilli(),
+	); err != nil {
+		return err
+	}
+	ws.Role = model.RoleOwner
+
+	return tx.Commit()
+}
+
+// ListWorkspaces returns the workspaces member belongs to, with the
+// member's role in each.
+func (r *RepositoryDB) ListWorkspaces(ctx context.Context, member string) ([]*model.Workspace, error) {
+	query := `SELECT w.id, w.name, w.created_by, w.created_at, m.role
+	          FROM workspace w
+	          JOIN workspace_member m ON m.workspace_id = w.id
+	          WHERE m.member = ?
+	          ORDER BY w.id`
+
+	rows, err := r.QueryContext(ctx, query, member)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workspaces := []*model.Workspace{}
+	for rows.Next() {
+		ws := &model.Workspace{}
+		var createdAt int64
+		if err := rows.Scan(&ws.ID, &ws.Name, &ws.CreatedBy, &createdAt, &ws.Role); err != nil {
+			return nil, err
+		}
+		ws.CreatedAt = fromMillis(createdAt)
+		workspaces = append(workspaces, ws)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+// WorkspaceRole returns the role of member in a workspace, or "" if the
+// member does not belong to it or it does not exist.
+func (r *RepositoryDB) WorkspaceRole(ctx context.Context, workspaceID int64, member string) (string, error) {
+	var role string
+	err := r.QueryRowContext(ctx,
+		`SELECT role FROM workspace_member WHERE workspace_id = ? AND member = ?`, workspaceID, member,
+	).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return role, err
+}
+
+// SetWorkspaceMember adds a member or changes the role of an existing one.
+func (r *RepositoryDB) SetWorkspaceMember(ctx context.Context, m *model.WorkspaceMember) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	m.AddedAt = utcNow()
+	query := `INSERT INTO workspace_member (workspace_id, member, role, added_at) VALUES (?, ?, ?, ?)
+	          ON CONFLICT (workspace_id, member) DO UPDATE SET role = excluded.role`
+	if _, err := tx.ExecContext(ctx, query, m.WorkspaceID, m.Member, m.Role, m.AddedAt.UnixMilli()); err != nil {
+		return err
+	}
+	if err := checkOwners(ctx, tx, m.WorkspaceID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveWorkspaceMember removes a member and unassigns them from the
+// workspace's tasks.
+func (r *RepositoryDB) RemoveWorkspaceMember(ctx context.Context, workspaceID int64, member string) error {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM workspace_member WHERE workspace_id = ? AND member = ?`, workspaceID, member)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	if err := checkOwners(ctx, tx, workspaceID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM task_assignee
+		 WHERE assignee = ? AND task_id IN (SELECT id FROM task WHERE workspace_id = ?)`, member, workspaceID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *RepositoryDB) ListWorkspaceMembers(ctx context.Context, workspaceID int64) ([]*model.WorkspaceMember, error) {
+	query := `SELECT workspace_id, member, role, added_at
+	          FROM workspace_member
+	          WHERE workspace_id = ?
+	          ORDER BY member`
+
+	rows, err := r.QueryContext(ctx, query, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*model.WorkspaceMember{}
+	for rows.Next() {
+		m := &model.WorkspaceMember{}
+		var addedAt int64
+		if err := rows.Scan(&m.WorkspaceID, &m.Member, &m.Role, &addedAt); err != nil {
+			return nil, err
+		}
+		m.AddedAt = fromMillis(addedAt)
+		members = append(members, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func checkOwners(ctx context.Context, tx *Tx, workspaceID int64) error {
+	var owners int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM workspace_member WHERE workspace_id = ? AND role = ?`, workspaceID, model.RoleOwner,
+	).Scan(&owners); err != nil {
+		return err
+	}
+	if owners == 0 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// SetAssignees adds and removes assignees of a task. A change is recorded
+// as a task.updated event and in the task history.
+func (r *RepositoryDB) SetAssignees(ctx context.Context, taskID int64, assign, unassign []string) (*model.Model, error) {
+	tx, err := r.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	prev, err := taskInTx(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range assign {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO task_assignee (task_id, assignee) VALUES (?, ?)`, taskID, a); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range unassign {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM task_assignee WHERE task_id = ? AND assignee = ?`, taskID, a); err != nil {
+			return nil, err
+		}
+	}
+
+	task, err := taskInTx(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Equal(prev.AssigneeIDs, task.AssigneeIDs) {
+		return task, nil
+	}
+
+	task.UpdatedAt = utcNow()
+	if _, err := tx.ExecContext(ctx, `UPDATE task SET updated_at = ? WHERE id = ?`, task.UpdatedAt.UnixMilli(), taskID); err != nil {
+		return nil, err
+	}
+	if err := insertOutboxEvent(ctx, tx, events.New(events.TaskUpdated, task)); err != nil {
+		return nil, err
+	}
+	oldAssignees, newAssignees := strings.Join(prev.AssigneeIDs, ","), strings.Join(task.AssigneeIDs, ",")
+	change := []model.FieldChange{{Field: "assignees", Old: &oldAssignees, New: &newAssignees}}
+	if err := insertHistory(ctx, tx, taskID, model.ActionUpdated, change); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
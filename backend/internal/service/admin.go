@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/monitor"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrUnknownLogLevel = errors.New("unknown log level")
+
+type AdminRepository interface {
+	DatabaseStats(ctx context.Context) (*model.DatabaseStats, error)
+	DatabaseSize(ctx context.Context) (int64, error)
+	Vacuum(ctx context.Context) error
+	Analyze(ctx context.Context) error
+	CheckIntegrity(ctx context.Context, quick bool) ([]string, error)
+}
+
+// AdminService runs maintenance operations on the database of the caller
+// and inspects the running server. Every method is admin only.
+type AdminService struct {
+	repo    AdminRepository
+	monitor *monitor.Monitor
+	admins  []string
+}
+
+func NewAdminService(repo AdminRepository, m *monitor.Monitor, admins []string) *AdminService {
+	return &AdminService{repo: repo, monitor: m, admins: admins}
+}
+
+func (s *AdminService) DatabaseStats(ctx context.Context) (*model.DatabaseStats, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.DatabaseStats(ctx)
+}
+
+// Vacuum rebuilds the database and returns its size before and after.
+func (s *AdminService) Vacuum(ctx context.Context) (before, after int64, err error) {
+	if err := s.authorize(ctx); err != nil {
+		return 0, 0, err
+	}
+	if before, err = s.repo.DatabaseSize(ctx); err != nil {
+		return 0, 0, err
+	}
+	if err := s.repo.Vacuum(ctx); err != nil {
+		return 0, 0, err
+	}
+	if after, err = s.repo.DatabaseSize(ctx); err != nil {
+		return 0, 0, err
+	}
+	return before, after, nil
+}
+
+func (s *AdminService) Analyze(ctx context.Context) error {
+	if err := s.authorize(ctx); err != nil {
+		return err
+	}
+	return s.repo.Analyze(ctx)
+}
+
+// CheckIntegrity returns the problems found in the database, or none if
+// it is intact.
+func (s *AdminService) CheckIntegrity(ctx context.Context, quick bool) ([]string, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.CheckIntegrity(ctx, quick)
+}
+
+func (s *AdminService) LogLevel(ctx context.Context) (string, error) {
+	if err := s.authorize(ctx); err != nil {
+		return "", err
+	}
+	return log.L().GetLevel().String(), nil
+}
+
+// SetLogLevel changes the level of the server log until it restarts.
+func (s *AdminService) SetLogLevel(ctx context.Context, level string) (string, error) {
+	if err := s.authorize(ctx); err != nil {
+		return "", err
+	}
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return "", ErrUnknownLogLevel
+	}
+
+	principal, _ := auth.PrincipalFrom(ctx)
+	log.L().Warnf("Log level changed from %s to %s by %s", log.L().GetLevel(), lvl, principal)
+	log.L().SetLevel(lvl)
+	return lvl.String(), nil
+}
+
+// Connections returns the open connections and the calls in progress,
+// streams included.
+func (s *AdminService) Connections(ctx context.Context) ([]monitor.Connection, []monitor.Call, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, nil, err
+	}
+	return s.monitor.Connections(), s.monitor.Calls(), nil
+}
+
+func (s *AdminService) authorize(ctx context.Context) error {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !slices.Contains(s.admins, principal) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/monitor"
+)
+
+func TestAdminService(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	m := monitor.New()
+	admin := NewAdminService(repo, m, []string{"admin"})
+	ctx := auth.WithPrincipal(context.Background(), "admin")
+
+	tasks := NewTaskService(repo)
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	for _, title := range []string{"Buy milk", "Buy bread"} {
+		if _, err := tasks.CreateTask(alice, title, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := admin.DatabaseStats(alice); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied for a non-admin, got %v", err)
+	}
+	if _, err := admin.DatabaseStats(context.Background()); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated for an anonymous caller, got %v", err)
+	}
+
+	stats, err := admin.DatabaseStats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(stats.Tables, model.TableStats{Name: "task", RowCount: 2}) {
+		t.Errorf("expected 2 rows in task, got %v", stats.Tables)
+	}
+	if stats.FileSize == 0 || stats.PageSize == 0 || stats.JournalMode != "wal" {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	if _, _, err := admin.Vacuum(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.Analyze(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, quick := range []bool{false, true} {
+		problems, err := admin.CheckIntegrity(ctx, quick)
+		if err != nil || len(problems) > 0 {
+			t.Errorf("expected an intact database (quick=%t), got %v, %v", quick, problems, err)
+		}
+	}
+
+	defer log.L().SetLevel(log.L().GetLevel())
+	if level, err := admin.SetLogLevel(ctx, "DEBUG"); err != nil || level != "debug" {
+		t.Errorf("expected level debug, got %q, %v", level, err)
+	}
+	if level, _ := admin.LogLevel(ctx); level != "debug" {
+		t.Errorf("expected level debug, got %q", level)
+	}
+	if _, err := admin.SetLogLevel(ctx, "loud"); !errors.Is(err, ErrUnknownLogLevel) {
+		t.Errorf("expected ErrUnknownLogLevel, got %v", err)
+	}
+
+	end := m.Begin(alice, "/todoService.TodoService/StreamTasks", "alice", true)
+	_, calls, err := admin.Connections(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 || calls[0].Principal != "alice" || !calls[0].Streaming {
+		t.Errorf("expected the stream to be listed, got %+v", calls)
+	}
+	end()
+	if _, calls, _ := admin.Connections(ctx); len(calls) != 0 {
+		t.Errorf("expected no calls after the stream ended, got %+v", calls)
+	}
+}
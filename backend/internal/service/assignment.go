@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+var (
+	ErrWorkspacesDisabled = errors.New("workspaces are not configured")
+	ErrAssigneeNotMember  = errors.New("assignee is not a member of the task's workspace")
+)
+
+// AssignmentRepository finds workspace tasks and stores their assignees.
+type AssignmentRepository interface {
+	SearchTasks(ctx context.Context, q model.TaskQuery) ([]*model.Model, error)
+	WorkspaceRole(ctx context.Context, workspaceID int64, member string) (string, error)
+	SetAssignees(ctx context.Context, taskID int64, assign, unassign []string) (*model.Model, error)
+}
+
+func WithWorkspaceRepository(r AssignmentRepository) Option {
+	return func(s *TaskService) {
+		s.workspaces = r
+	}
+}
+
+// AssigneeFilter narrows a task listing by assignment.
+type AssigneeFilter int
+
+const (
+	AnyAssignee AssigneeFilter = iota
+	AssignedToMe
+	Unassigned
+)
+
+// SearchTasks lists tasks matching filter, optionally restricted to a
+// workspace the caller belongs to and by assignment.
+func (s *TaskService) SearchTasks(ctx context.Context, filter string, workspaceID int64, assignee AssigneeFilter) ([]*model.Model, error) {
+	if workspaceID == 0 && assignee == AnyAssignee {
+		return s.ListTask(ctx, filter)
+	}
+	if s.workspaces == nil {
+		return nil, ErrWorkspacesDisabled
+	}
+
+	q := model.TaskQuery{Text: filter, WorkspaceID: workspaceID}
+	if workspaceID != 0 {
+		if _, err := memberRole(ctx, s.workspaces, workspaceID); err != nil {
+			return nil, err
+		}
+	}
+	switch assignee {
+	case AssignedToMe:
+		principal, ok := auth.PrincipalFrom(ctx)
+		if !ok {
+			return nil, ErrUnauthenticated
+		}
+		q.Assignee = principal
+	case Unassigned:
+		q.Unassigned = true
+	}
+
+	tasks, err := s.workspaces.SearchTasks(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readable(ctx, tasks)
+}
+
+// AssignTask adds assignees to a task. Assignees of a workspace task must
+// be members of the workspace; a personal task can only be assigned to
+// its owner.
+func (s *TaskService) AssignTask(ctx context.Context, taskID int64, users []string) (*model.Model, error) {
+	task, err := s.assignableTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if task.WorkspaceID == 0 {
+			if user != task.Owner {
+				return nil, ErrAssigneeNotMember
+			}
+			continue
+		}
+		role, err := s.workspaces.WorkspaceRole(ctx, task.WorkspaceID, user)
+		if err != nil {
+			return nil, err
+		}
+		if role == "" {
+			return nil, ErrAssigneeNotMember
+		}
+	}
+
+	return s.setAssignees(ctx, taskID, users, nil)
+}
+
+// UnassignTask removes assignees from a task.
+func (s *TaskService) UnassignTask(ctx context.Context, taskID int64, users []string) (*model.Model, error) {
+	if _, err := s.assignableTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.setAssignees(ctx, taskID, nil, users)
+}
+
+// assignableTask loads a task whose assignees the caller may change.
+func (s *TaskService) assignableTask(ctx context.Context, taskID int64) (*model.Model, error) {
+	if s.workspaces == nil {
+		return nil, ErrWorkspacesDisabled
+	}
+
+	return s.authorizeID(ctx, taskID, rbac.TaskAssign)
+}
+
+func (s *TaskService) setAssignees(ctx context.Context, taskID int64, assign, unassign []string) (*model.Model, error) {
+	task, err := s.workspaces.SetAssignees(ctx, taskID, assign, unassign)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
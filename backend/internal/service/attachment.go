@@ -0,0 +1,391 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+const sniffLen = 512
+
+var (
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+	ErrAttachmentTooLarge    = errors.New("attachment is too large")
+	ErrContentTypeNotAllowed = errors.New("content type is not allowed")
+	ErrContentTypeMismatch   = errors.New("content does not match the content type")
+	ErrSizeMismatch          = errors.New("attachment size does not match the declared size")
+	ErrChecksumMismatch      = errors.New("attachment checksum mismatch")
+)
+
+// DefaultContentTypes are the attachment types accepted unless configured
+// otherwise.
+var DefaultContentTypes = []string{
+	"image/png", "image/jpeg", "image/gif", "image/webp",
+	"application/pdf", "text/plain", "text/markdown", "text/csv",
+}
+
+// sniffableTypes are recognized by http.DetectContentType, so uploads
+// declaring them must start with a matching signature.
+var sniffableTypes = map[string]bool{
+	"image/png": true, "image/jpeg": true, "image/gif": true, "image/webp": true,
+	"image/bmp": true, "application/pdf": true, "application/zip": true,
+}
+
+// BlobStore holds attachment contents.
+type BlobStore interface {
+	// Put stores exactly size bytes read from r under key.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob; a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, a *model.Attachment) error
+	GetAttachment(ctx context.Context, id int64) (*model.Attachment, error)
+	ListAttachments(ctx context.Context, taskID int64) ([]*model.Attachment, error)
+	DeleteAttachment(ctx context.Context, id int64) error
+	DeletedAttachments(ctx context.Context, limit int) ([]*model.Attachment, error)
+	PurgeAttachment(ctx context.Context, id int64) error
+}
+
+// AttachmentUpload is the metadata a client sends ahead of the contents.
+// Size and SHA256 are optional; when given they are checked.
+type AttachmentUpload struct {
+	TaskID      int64
+	Filename    string
+	ContentType string
+	Size        int64
+	SHA256      string
+}
+
+// AttachmentService stores files attached to tasks. Listing and
+// downloading need task.read on the task, uploading and deleting
+// task.update.
+type AttachmentService struct {
+	repo          AttachmentRepository
+	tasks         TaskAuthorizer
+	store         BlobStore
+	maxSize       int64
+	contentTypes  []string
+	sweepInterval time.Duration
+}
+
+type AttachmentOption func(*AttachmentService)
+
+// WithMaxAttachmentSize caps the size of a single attachment in bytes.
+func WithMaxAttachmentSize(n int64) AttachmentOption {
+	return func(s *AttachmentService) {
+		s.maxSize = n
+	}
+}
+
+// WithContentTypes sets the accepted media types. A "type/*" entry
+// accepts every subtype.
+func WithContentTypes(types []string) AttachmentOption {
+	return func(s *AttachmentService) {
+		s.contentTypes = types
+	}
+}
+
+func WithSweepInterval(d time.Duration) AttachmentOption {
+	return func(s *AttachmentService) {
+		s.sweepInterval = d
+	}
+}
+
+func NewAttachmentService(repo AttachmentRepository, tasks TaskAuthorizer, store BlobStore, opts ...AttachmentOption) *AttachmentService {
+	s := &AttachmentService{
+		repo:          repo,
+		tasks:         tasks,
+		store:         store,
+		maxSize:       10 << 20,
+		contentTypes:  DefaultContentTypes,
+		sweepInterval: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Upload reads the contents from r into a temporary file while checking
+// the size limit and computing the checksum, verifies them and the content
+// type, and only then hands the file to the blob store.
+func (s *AttachmentService) Upload(ctx context.Context, info AttachmentUpload, r io.Reader) (*model.Attachment, error) {
+	contentType, err := s.checkContentType(info.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if _, err := s.tasks.AuthorizeTask(ctx, info.TaskID, rbac.TaskUpdate); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "todo-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	head := &prefixBuffer{limit: sniffLen}
+	size, err := io.Copy(io.MultiWriter(tmp, h, head), io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if info.Size > 0 && size != info.Size {
+		return nil, ErrSizeMismatch
+	}
+	checksum := hex.EncodeToString(h.Sum(nil))
+	if info.SHA256 != "" && !strings.EqualFold(info.SHA256, checksum) {
+		return nil, ErrChecksumMismatch
+	}
+	if !contentMatches(contentType, head.Bytes()) {
+		return nil, ErrContentTypeMismatch
+	}
+
+	key, err := newStorageKey()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(ctx, key, tmp, size); err != nil {
+		return nil, err
+	}
+
+	uploader, _ := auth.PrincipalFrom(ctx)
+	a := &model.Attachment{
+		TaskID:      info.TaskID,
+		Filename:    cleanFilename(info.Filename),
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      checksum,
+		StorageKey:  key,
+		Uploader:    uploader,
+	}
+	if err := s.repo.CreateAttachment(ctx, a); err != nil {
+		// The blob has no metadata pointing at it; do not leak it.
+		s.store.Delete(context.WithoutCancel(ctx), key)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Open returns an attachment and a reader over its contents. The reader
+// reports ErrChecksumMismatch instead of io.EOF if the stored contents no
+// longer match the checksum taken at upload.
+func (s *AttachmentService) Open(ctx context.Context, id int64) (*model.Attachment, io.ReadCloser, error) {
+	a, err := s.authorizeAttachment(ctx, id, rbac.TaskRead)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := s.store.Get(ctx, a.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a, &verifyingReader{rc: rc, hash: sha256.New(), want: a}, nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, taskID int64) ([]*model.Attachment, error) {
+	if _, err := s.tasks.AuthorizeTask(ctx, taskID, rbac.TaskRead); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListAttachments(ctx, taskID)
+}
+
+// DeleteAttachment hides the attachment at once; its blob is removed by
+// the sweeper.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, id int64) error {
+	if _, err := s.authorizeAttachment(ctx, id, rbac.TaskUpdate); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAttachment(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// authorizeAttachment loads an attachment and authorizes action on its
+// task.
+func (s *AttachmentService) authorizeAttachment(ctx context.Context, id int64, action rbac.Action) (*model.Attachment, error) {
+	a, err := s.repo.GetAttachment(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.tasks.AuthorizeTask(ctx, a.TaskID, action); err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// Run removes the blobs of deleted attachments, including those of
+// deleted tasks, until ctx is cancelled.
+func (s *AttachmentService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SweepDeleted(ctx); err != nil && ctx.Err() == nil {
+			log.L().Errorf("Attachment sweep failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SweepDeleted deletes the blobs of deleted attachments and purges their
+// rows.
+func (s *AttachmentService) SweepDeleted(ctx context.Context) error {
+	for {
+		deleted, err := s.repo.DeletedAttachments(ctx, 100)
+		if err != nil {
+			return err
+		}
+		if len(deleted) == 0 {
+			return nil
+		}
+
+		for _, a := range deleted {
+			if err := s.store.Delete(ctx, a.StorageKey); err != nil {
+				return fmt.Errorf("delete blob of attachment %d: %w", a.ID, err)
+			}
+			if err := s.repo.PurgeAttachment(ctx, a.ID); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkContentType normalizes the declared media type and checks it
+// against the accepted ones.
+func (s *AttachmentService) checkContentType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrContentTypeNotAllowed
+	}
+	for _, allowed := range s.contentTypes {
+		if allowed == mediaType {
+			return mediaType, nil
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok && strings.HasSuffix(prefix, "/") && strings.HasPrefix(mediaType, prefix) {
+			return mediaType, nil
+		}
+	}
+	return "", ErrContentTypeNotAllowed
+}
+
+// contentMatches checks the first bytes of the contents against the
+// declared type where the type can be recognized from them.
+func contentMatches(mediaType string, head []byte) bool {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	switch {
+	case sniffableTypes[mediaType]:
+		return detected == mediaType
+	case strings.HasPrefix(mediaType, "text/"):
+		return strings.HasPrefix(detected, "text/")
+	}
+	return true
+}
+
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+}
+
+func newStorageKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// prefixBuffer keeps the first limit bytes written to it.
+type prefixBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *prefixBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
+
+type verifyingReader struct {
+	rc   io.ReadCloser
+	hash hash.Hash
+	n    int64
+	want *model.Attachment
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	r.hash.Write(p[:n])
+	r.n += int64(n)
+	if errors.Is(err, io.EOF) {
+		if r.n != r.want.Size || hex.EncodeToString(r.hash.Sum(nil)) != r.want.SHA256 {
+			return n, ErrChecksumMismatch
+		}
+	}
+	return n, err
+}
+
+func (r *verifyingReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/blob"
+)
+
+var pngData = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+func TestAttachments(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	store, err := blob.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := NewTaskService(repo)
+	attachments := NewAttachmentService(repo, tasks, store, WithMaxAttachmentSize(1024))
+	ctx := auth.WithPrincipal(context.Background(), "alice")
+	bob := auth.WithPrincipal(context.Background(), "bob")
+
+	task, err := tasks.CreateTask(ctx, "Fix layout", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(pngData)
+	checksum := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name string
+		info AttachmentUpload
+		data []byte
+		err  error
+	}{
+		{"type not allowed", AttachmentUpload{ContentType: "application/x-msdownload"}, pngData, ErrContentTypeNotAllowed},
+		{"content mismatch", AttachmentUpload{ContentType: "image/png"}, []byte("plain text"), ErrContentTypeMismatch},
+		{"too large", AttachmentUpload{ContentType: "text/plain"}, bytes.Repeat([]byte("a"), 1025), ErrAttachmentTooLarge},
+		{"declared too large", AttachmentUpload{ContentType: "text/plain", Size: 2048}, []byte("a"), ErrAttachmentTooLarge},
+		{"size mismatch", AttachmentUpload{ContentType: "image/png", Size: 10}, pngData, ErrSizeMismatch},
+		{"checksum mismatch", AttachmentUpload{ContentType: "image/png", SHA256: strings.Repeat("0", 64)}, pngData, ErrChecksumMismatch},
+		{"unknown task", AttachmentUpload{TaskID: task.ID + 1, ContentType: "image/png"}, pngData, ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.info.TaskID == 0 {
+				tt.info.TaskID = task.ID
+			}
+			tt.info.Filename = "shot.png"
+			if _, err := attachments.Upload(ctx, tt.info, bytes.NewReader(tt.data)); !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+
+	a, err := attachments.Upload(ctx, AttachmentUpload{
+		TaskID:      task.ID,
+		Filename:    `C:\Users\me\shot.png`,
+		ContentType: "image/png",
+		Size:        int64(len(pngData)),
+		SHA256:      strings.ToUpper(checksum),
+	}, bytes.NewReader(pngData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Filename != "shot.png" || a.SHA256 != checksum || a.Size != int64(len(pngData)) {
+		t.Errorf("unexpected attachment: %+v", a)
+	}
+
+	if _, err := attachments.Upload(bob, AttachmentUpload{TaskID: task.ID, ContentType: "image/png"}, bytes.NewReader(pngData)); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied uploading to another user's task, got %v", err)
+	}
+	if _, err := attachments.ListAttachments(bob, task.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied listing another user's attachments, got %v", err)
+	}
+	if _, _, err := attachments.Open(bob, a.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied downloading another user's attachment, got %v", err)
+	}
+	if err := attachments.DeleteAttachment(bob, a.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied deleting another user's attachment, got %v", err)
+	}
+
+	_, rc, err := attachments.Open(ctx, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil || !bytes.Equal(got, pngData) {
+		t.Fatalf("download mismatch: %d bytes, %v", len(got), err)
+	}
+
+	tampered := bytes.Clone(pngData)
+	tampered[50] = 1
+	if err := store.Put(ctx, a.StorageKey, bytes.NewReader(tampered), int64(len(tampered))); err != nil {
+		t.Fatal(err)
+	}
+	_, rc, err = attachments.Open(ctx, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(rc); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch for tampered contents, got %v", err)
+	}
+	rc.Close()
+
+	if err := tasks.DeleteTask(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := attachments.Open(ctx, a.ID); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("expected ErrAttachmentNotFound after task deletion, got %v", err)
+	}
+	if err := attachments.SweepDeleted(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, a.StorageKey); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("blob survived the sweep: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+)
+
+// roleRank orders roles so that a task share can only raise the role a
+// caller already has through the task's workspace.
+var roleRank = map[string]int{
+	model.RoleViewer: 1,
+	model.RoleEditor: 2,
+	model.RoleAdmin:  3,
+	model.RoleOwner:  4,
+}
+
+// taskRole returns the caller's role on a task: their workspace role for
+// workspace tasks, owner for their own personal tasks, or the role the
+// task was shared with them under, whichever is stronger. Callers with no
+// relation to the task get the empty role.
+func (s *TaskService) taskRole(ctx context.Context, task *model.Model) (string, error) {
+	role, err := s.baseRole(ctx, task)
+	caller, ok := auth.PrincipalFrom(ctx)
+	if err != nil || !ok || s.shares == nil {
+		return role, err
+	}
+
+	shared, err := s.shares.TaskShareRole(ctx, task.ID, caller)
+	if err != nil {
+		return "", err
+	}
+
+	return strongerRole(role, shared), nil
+}
+
+// baseRole is taskRole without shares. Anonymous callers have no role.
+// Personal tasks without an owner predate owners; only admins own them.
+func (s *TaskService) baseRole(ctx context.Context, task *model.Model) (string, error) {
+	caller, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return "", nil
+	}
+	if task.WorkspaceID == 0 {
+		if task.Owner == caller || task.Owner == "" && slices.Contains(s.admins, caller) {
+			return model.RoleOwner, nil
+		}
+		return "", nil
+	}
+	if s.workspaces == nil {
+		return "", nil
+	}
+
+	return s.workspaces.WorkspaceRole(ctx, task.WorkspaceID, caller)
+}
+
+func strongerRole(a, b string) string {
+	if roleRank[b] > roleRank[a] {
+		return b
+	}
+	return a
+}
+
+// authorize checks that the policy lets the caller perform action on task.
+func (s *TaskService) authorize(ctx context.Context, task *model.Model, action rbac.Action) error {
+	role, err := s.taskRole(ctx, task)
+	if err != nil {
+		return err
+	}
+	if !s.policy.Allows(role, action) {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// authorizeID loads a task and authorizes action on it.
+func (s *TaskService) authorizeID(ctx context.Context, id int64, action rbac.Action) (*model.Model, error) {
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	if err := s.authorize(ctx, task, action); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// TaskAuthorizer checks the caller's access to a task on behalf of the
+// services that keep data attached to tasks.
+type TaskAuthorizer interface {
+	// AuthorizeTask loads a task and checks that the caller may perform
+	// action on it.
+	AuthorizeTask(ctx context.Context, id int64, action rbac.Action) (*model.Model, error)
+}
+
+func (s *TaskService) AuthorizeTask(ctx context.Context, id int64, action rbac.Action) (*model.Model, error) {
+	return s.authorizeID(ctx, id, action)
+}
+
+// AuthorizeHistory checks that the caller may read a task, judged by its
+// last revision once it is deleted. Callers who may not read it get
+// ErrTaskNotFound, so task IDs cannot be probed.
+func (s *TaskService) AuthorizeHistory(ctx context.Context, id int64) error {
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if task == nil && s.revisions != nil {
+		revisions, err := s.revisions.ListRevisions(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(revisions) > 0 {
+			task = revisions[0].Task
+		}
+	}
+	if task == nil {
+		return ErrTaskNotFound
+	}
+	if err := s.authorize(ctx, task, rbac.TaskRead); err != nil {
+		if errors.Is(err, ErrPermissionDenied) {
+			return ErrTaskNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// readable keeps the tasks the caller may read, looking up the caller's
+// role once per workspace and their shares once per call.
+func (s *TaskService) readable(ctx context.Context, tasks []*model.Model) ([]*model.Model, error) {
+	var shared map[int64]string
+	if s.shares != nil {
+		caller, _ := auth.PrincipalFrom(ctx)
+		var err error
+		if shared, err = s.shares.SharedWith(ctx, caller); err != nil {
+			return nil, err
+		}
+	}
+
+	roles := make(map[int64]string)
+	visible := tasks[:0]
+	for _, task := range tasks {
+		role, ok := roles[task.WorkspaceID]
+		if !ok || task.WorkspaceID == 0 {
+			var err error
+			if role, err = s.baseRole(ctx, task); err != nil {
+				return nil, err
+			}
+			roles[task.WorkspaceID] = role
+		}
+		if s.policy.Allows(strongerRole(role, shared[task.ID]), rbac.TaskRead) {
+			visible = append(visible, task)
+		}
+	}
+
+	return visible, nil
+}
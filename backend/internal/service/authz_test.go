@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+)
+
+func TestTaskPolicyMatrix(t *testing.T) {
+	ops := []struct {
+		name string
+		run  func(ctx context.Context, s *TaskService, task *model.Model) error
+	}{
+		{"get", func(ctx context.Context, s *TaskService, task *model.Model) error {
+			_, err := s.GetTask(ctx, task.ID)
+			return err
+		}},
+		{"update", func(ctx context.Context, s *TaskService, task *model.Model) error {
+			renamed := *task
+			renamed.Title = "Renamed"
+			_, err := s.UpdateTask(ctx, &renamed)
+			return err
+		}},
+		{"assign", func(ctx context.Context, s *TaskService, task *model.Model) error {
+			_, err := s.AssignTask(ctx, task.ID, []string{"alice"})
+			return err
+		}},
+		{"delete", func(ctx context.Context, s *TaskService, task *model.Model) error {
+			return s.DeleteTask(ctx, task.ID)
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		caller  string
+		policy  string
+		legacy  bool // a personal task without an owner
+		allowed map[string]bool
+	}{
+		{name: "anonymous", caller: "", allowed: map[string]bool{}},
+		{name: "anonymous on legacy task", caller: "", legacy: true, allowed: map[string]bool{}},
+		{name: "user on legacy task", caller: "alice", legacy: true, allowed: map[string]bool{}},
+		{name: "server admin", caller: "root", allowed: map[string]bool{}},
+		{name: "owner", caller: "alice", allowed: map[string]bool{"get": true, "update": true, "assign": true, "delete": true}},
+		{name: "admin", caller: "adam", allowed: map[string]bool{"get": true, "update": true, "assign": true, "delete": true}},
+		{name: "editor", caller: "eve", allowed: map[string]bool{"get": true, "update": true, "assign": true}},
+		{name: "viewer", caller: "vic", allowed: map[string]bool{"get": true}},
+		{name: "outsider", caller: "oscar", allowed: map[string]bool{}},
+		{name: "viewer with custom policy", caller: "vic", policy: "roles: {owner: ['*'], viewer: [task.read, task.update]}",
+			allowed: map[string]bool{"get": true, "update": true}},
+		{name: "editor with custom policy", caller: "eve", policy: "roles: {owner: ['*'], editor: [task.*]}",
+			allowed: map[string]bool{"get": true, "update": true, "assign": true, "delete": true}},
+	}
+	for _, tt := range tests {
+		for _, op := range ops {
+			t.Run(tt.name+"/"+op.name, func(t *testing.T) {
+				repo := newSQLiteRepo(t)
+				policy := rbac.Default()
+				if tt.policy != "" {
+					var err error
+					if policy, err = rbac.Parse([]byte(tt.policy)); err != nil {
+						t.Fatal(err)
+					}
+				}
+				tasks := NewTaskService(repo, WithWorkspaceRepository(repo), WithPolicy(policy), WithAdmins([]string{"root"}))
+				alice := auth.WithPrincipal(context.Background(), "alice")
+
+				ws, err := NewWorkspaceService(repo, nil).CreateWorkspace(alice, "Team")
+				if err != nil {
+					t.Fatal(err)
+				}
+				for member, role := range map[string]string{"adam": model.RoleAdmin, "eve": model.RoleEditor, "vic": model.RoleViewer} {
+					if _, err := NewWorkspaceService(repo, nil).SetMember(alice, ws.ID, member, role); err != nil {
+						t.Fatal(err)
+					}
+				}
+				task, err := tasks.CreateTaskIn(alice, ws.ID, "Shared", "")
+				if tt.legacy {
+					task, err = repo.Create(context.Background(), "Legacy", "", "")
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = op.run(auth.WithPrincipal(context.Background(), tt.caller), tasks, task)
+				if tt.allowed[op.name] && err != nil {
+					t.Errorf("expected %s to be allowed, got %v", op.name, err)
+				}
+				if !tt.allowed[op.name] && !errors.Is(err, ErrPermissionDenied) {
+					t.Errorf("expected ErrPermissionDenied for %s, got %v", op.name, err)
+				}
+			})
+		}
+	}
+}
+
+func TestAdminsOwnLegacyTasks(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithAdmins([]string{"root"}))
+	root := auth.WithPrincipal(context.Background(), "root")
+
+	legacy, err := repo.Create(context.Background(), "Legacy", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.CreateTask(auth.WithPrincipal(context.Background(), "alice"), "Diary", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if list, err := tasks.ListTask(root, ""); err != nil || len(list) != 1 || list[0].ID != legacy.ID {
+		t.Fatalf("expected root to see only the legacy task, got %v, %v", list, err)
+	}
+	legacy.Title = "Renamed"
+	if _, err := tasks.UpdateTask(root, legacy); err != nil {
+		t.Errorf("expected root to update the legacy task, got %v", err)
+	}
+	if err := tasks.DeleteTask(root, legacy.ID); err != nil {
+		t.Errorf("expected root to delete the legacy task, got %v", err)
+	}
+}
+
+func TestPersonalTasksArePrivate(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo)
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	bob := auth.WithPrincipal(context.Background(), "bob")
+
+	task, err := tasks.CreateTask(alice, "Diary", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.GetTask(bob, task.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if err := tasks.DeleteTask(bob, task.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if list, err := tasks.ListTask(bob, ""); err != nil || len(list) != 0 {
+		t.Errorf("expected bob to see no tasks, got %d, %v", len(list), err)
+	}
+	if list, err := tasks.ListTask(alice, ""); err != nil || len(list) != 1 {
+		t.Errorf("expected alice to see her task, got %d, %v", len(list), err)
+	}
+}
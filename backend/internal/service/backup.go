@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/backup"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+)
+
+type BackupRepository interface {
+	// VacuumInto writes a consistent copy of the database to path.
+	VacuumInto(ctx context.Context, path string) error
+}
+
+// BackupService takes snapshots of the database on demand and on a
+// schedule, keeping the newest few. Tenant databases are backed up into a
+// subdirectory per tenant.
+type BackupService struct {
+	repo   BackupRepository
+	dir    string
+	keep   int
+	admins []string
+
+	mu sync.Mutex
+}
+
+// NewBackupService writes snapshots to dir, keeping the newest keep of
+// them. Only admins may request a backup.
+func NewBackupService(repo BackupRepository, dir string, keep int, admins []string) *BackupService {
+	return &BackupService{repo: repo, dir: dir, keep: keep, admins: admins}
+}
+
+// Backup takes a snapshot now.
+func (s *BackupService) Backup(ctx context.Context) (*backup.Snapshot, error) {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !slices.Contains(s.admins, principal) {
+		return nil, ErrPermissionDenied
+	}
+	return s.snapshot(ctx)
+}
+
+// Run takes a snapshot every interval until ctx is cancelled.
+func (s *BackupService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		snap, err := s.snapshot(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.L().Errorf("Scheduled backup failed: %v", err)
+			}
+			continue
+		}
+		log.L().Infof("Scheduled backup written: %s", snap.Path)
+	}
+}
+
+// BackupIfDue takes a snapshot unless one was taken within the last
+// interval. It catches up on the schedule of tenant databases that were
+// closed when their backup came due.
+func (s *BackupService) BackupIfDue(ctx context.Context, interval time.Duration) (*backup.Snapshot, error) {
+	latest, err := backup.Latest(s.dirFor(ctx), time.Now())
+	if err == nil && time.Since(latest.CreatedAt) < interval {
+		return nil, nil
+	}
+	if err != nil && !errors.Is(err, backup.ErrNoBackup) {
+		return nil, err
+	}
+	return s.snapshot(ctx)
+}
+
+func (s *BackupService) snapshot(ctx context.Context) (*backup.Snapshot, error) {
+	// Snapshot names have millisecond precision; one at a time keeps them
+	// unique.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return backup.Create(ctx, s.dirFor(ctx), s.keep, s.repo.VacuumInto)
+}
+
+// dirFor is where the snapshots of the database in ctx are kept.
+func (s *BackupService) dirFor(ctx context.Context) string {
+	if id, ok := tenant.IDFrom(ctx); ok {
+		return filepath.Join(s.dir, id)
+	}
+	return s.dir
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/backup"
+	"github.com/Elmar006/todo_grpc/internal/tenant"
+)
+
+func TestScheduledBackupRunsAfterEviction(t *testing.T) {
+	dir := t.TempDir()
+	backups := NewBackupService(newSQLiteRepo(t), filepath.Join(dir, "backups"), 0, nil)
+	tenants, err := tenant.NewManager(filepath.Join(dir, "tenants"),
+		tenant.WithIdleTimeout(20*time.Millisecond),
+		tenant.WithWake(20*time.Millisecond, func(ctx context.Context) {
+			if _, err := backups.BackupIfDue(ctx, time.Hour); err != nil {
+				t.Error(err)
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tenants.Close()
+	if err := tenants.Create("acme"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, release, err := tenants.Acquire(ctx, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	go tenants.Run(ctx)
+
+	waitFor(t, "the idle tenant to be closed", func() bool { return len(tenants.Open()) == 0 })
+	waitFor(t, "a backup of the closed tenant", func() bool {
+		snapshots, err := backup.List(filepath.Join(dir, "backups", "acme"))
+		return err == nil && len(snapshots) > 0
+	})
+
+	// Later wakes find the backup fresh and leave it alone.
+	time.Sleep(100 * time.Millisecond)
+	snapshots, err := backup.List(filepath.Join(dir, "backups", "acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 {
+		t.Errorf("expected one backup within the interval, got %d", len(snapshots))
+	}
+}
+
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+
+type CommentRepository interface {
+	AddComment(ctx context.Context, comment *model.Comment) error
+	GetComment(ctx context.Context, id int64) (*model.Comment, error)
+	ListComments(ctx context.Context, taskID, afterID int64, limit int) ([]*model.Comment, error)
+	UpdateComment(ctx context.Context, comment *model.Comment) error
+	DeleteComment(ctx context.Context, id int64) error
+}
+
+// CommentPage is one page of comments. NextPageToken is empty on the last
+// page.
+type CommentPage struct {
+	Comments      []*model.Comment
+	NextPageToken string
+}
+
+// CommentService manages the comments on tasks. Reading comments needs
+// task.read on the task, adding one task.update.
+type CommentService struct {
+	repo      CommentRepository
+	tasks     TaskAuthorizer
+	validator *validation.Validator
+}
+
+func NewCommentService(repo CommentRepository, tasks TaskAuthorizer, v *validation.Validator) *CommentService {
+	if v == nil {
+		v = validation.New(validation.DefaultLimits())
+	}
+	return &CommentService{repo: repo, tasks: tasks, validator: v}
+}
+
+// AddComment attaches a comment by the calling user to a task.
+func (s *CommentService) AddComment(ctx context.Context, taskID int64, body string) (*model.Comment, error) {
+	if err := s.validator.Comment(body); err != nil {
+		return nil, err
+	}
+	if _, err := s.tasks.AuthorizeTask(ctx, taskID, rbac.TaskUpdate); err != nil {
+		return nil, err
+	}
+
+	author, _ := auth.PrincipalFrom(ctx)
+	comment := &model.Comment{TaskID: taskID, Author: author, Body: body}
+	if err := s.repo.AddComment(ctx, comment); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// ListComments returns a page of the comments on a task, oldest first.
+func (s *CommentService) ListComments(ctx context.Context, taskID int64, pageSize int, pageToken string) (*CommentPage, error) {
+	afterID, limit, err := pageParams(pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.tasks.AuthorizeTask(ctx, taskID, rbac.TaskRead); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.ListComments(ctx, taskID, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CommentPage{}
+	page.Comments, page.NextPageToken = trimPage(comments, limit, commentID)
+	return page, nil
+}
+
+// EditComment replaces the body of a comment written by the calling user.
+func (s *CommentService) EditComment(ctx context.Context, id int64, body string) (*model.Comment, error) {
+	if err := s.validator.Comment(body); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.ownComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Body = body
+	if err := s.repo.UpdateComment(ctx, comment); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment removes a comment written by the calling user.
+func (s *CommentService) DeleteComment(ctx context.Context, id int64) error {
+	if _, err := s.ownComment(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteComment(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ownComment loads a comment and checks that the caller wrote it.
+func (s *CommentService) ownComment(ctx context.Context, id int64) (*model.Comment, error) {
+	comment, err := s.repo.GetComment(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	caller, _ := auth.PrincipalFrom(ctx)
+	if comment.Author != caller {
+		return nil, ErrPermissionDenied
+	}
+
+	return comment, nil
+}
+
+func commentID(c *model.Comment) int64 { return c.ID }
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/events"
+)
+
+func TestComments(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo)
+	comments := NewCommentService(repo, tasks, nil)
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	bob := auth.WithPrincipal(context.Background(), "bob")
+
+	task, err := tasks.CreateTask(alice, "Write report", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, body := range []string{"first", "**second**", "third"} {
+		if _, err := comments.AddComment(alice, task.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := comments.AddComment(alice, task.ID, strings.Repeat("x", 5001)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("expected ErrInvalidData for a long body, got %v", err)
+	}
+	if _, err := comments.AddComment(alice, task.ID+1, "lost"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	page, err := comments.ListComments(alice, task.ID, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Comments) != 2 || page.NextPageToken == "" || page.Comments[0].Author != "alice" {
+		t.Fatalf("unexpected first page: %d comments, token %q", len(page.Comments), page.NextPageToken)
+	}
+	page, err = comments.ListComments(alice, task.ID, 2, page.NextPageToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Comments) != 1 || page.Comments[0].Body != "third" || page.NextPageToken != "" {
+		t.Fatalf("unexpected last page: %+v", page)
+	}
+
+	if _, err := comments.AddComment(bob, task.ID, "spam"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied commenting on another user's task, got %v", err)
+	}
+	if _, err := comments.ListComments(bob, task.ID, 0, ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied listing another user's comments, got %v", err)
+	}
+
+	id := page.Comments[0].ID
+	if _, err := comments.EditComment(bob, id, "hijacked"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if err := comments.DeleteComment(bob, id); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if edited, err := comments.EditComment(alice, id, "third, edited"); err != nil || edited.Body != "third, edited" {
+		t.Errorf("edit failed: %+v, %v", edited, err)
+	}
+
+	pending, err := repo.PendingOutboxEvents(context.Background(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := pending[len(pending)-1]
+	if last.Type != events.CommentEdited || last.Comment == nil || last.Comment.ID != id || last.Task == nil || last.Task.ID != task.ID {
+		t.Errorf("unexpected last event: %+v", last.Event)
+	}
+
+	if err := tasks.DeleteTask(alice, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetComment(context.Background(), id); err == nil {
+		t.Error("comment survived the deletion of its task")
+	}
+	if _, err := comments.ListComments(alice, task.ID, 0, ""); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrFeedsDisabled   = errors.New("calendar feeds are not configured")
+	ErrFeedNotFound    = errors.New("calendar feed not found")
+)
+
+// FeedRepository stores the secret tokens behind per-user calendar feeds.
+type FeedRepository interface {
+	FeedToken(ctx context.Context, owner string) (string, error)
+	SaveFeedToken(ctx context.Context, owner, token string) error
+	FeedOwner(ctx context.Context, token string) (string, error)
+	ListByOwner(ctx context.Context, owner string) ([]*model.Model, error)
+}
+
+func WithFeedRepository(r FeedRepository) Option {
+	return func(s *TaskService) {
+		s.feeds = r
+	}
+}
+
+// CalendarFeedToken returns the feed token of the calling user, creating
+// one on first use. With rotate a new token replaces the current one.
+func (s *TaskService) CalendarFeedToken(ctx context.Context, rotate bool) (string, error) {
+	if s.feeds == nil {
+		return "", ErrFeedsDisabled
+	}
+	owner, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+
+	if !rotate {
+		token, err := s.feeds.FeedToken(ctx, owner)
+		if err != nil || token != "" {
+			return token, err
+		}
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.feeds.SaveFeedToken(ctx, owner, token); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// CalendarFeed returns the tasks of the user a feed token belongs to that
+// the user may still read, so leaving a workspace also drops its tasks
+// from the feed.
+func (s *TaskService) CalendarFeed(ctx context.Context, token string) ([]*model.Model, error) {
+	if s.feeds == nil {
+		return nil, ErrFeedsDisabled
+	}
+
+	owner, err := s.feeds.FeedOwner(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+
+	tasks, err := s.feeds.ListByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readable(auth.WithPrincipal(ctx, owner), tasks)
+}
+
+// newToken returns a random URL-safe secret.
+func newToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
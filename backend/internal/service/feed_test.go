@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+func TestCalendarFeedOnlyListsReadableTasks(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithWorkspaceRepository(repo), WithFeedRepository(repo))
+	workspaces := NewWorkspaceService(repo, nil)
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	bob := auth.WithPrincipal(context.Background(), "bob")
+
+	ws, err := workspaces.CreateWorkspace(alice, "Home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := workspaces.SetMember(alice, ws.ID, "bob", model.RoleEditor); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.CreateTaskIn(bob, ws.ID, "Shared chore", ""); err != nil {
+		t.Fatal(err)
+	}
+	personal, err := tasks.CreateTask(bob, "Own chore", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := tasks.CalendarFeedToken(bob, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := tasks.CalendarFeed(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(feed) != 2 {
+		t.Fatalf("expected 2 tasks while bob is a member, got %d", len(feed))
+	}
+
+	if err := workspaces.RemoveMember(alice, ws.ID, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	feed, err = tasks.CalendarFeed(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(feed) != 1 || feed[0].ID != personal.ID {
+		t.Errorf("expected only bob's personal task after removal, got %+v", feed)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+)
+
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNoHistory        = errors.New("task has no history")
+)
+
+type HistoryRepository interface {
+	TaskHistory(ctx context.Context, taskID, afterID int64, limit int) ([]*model.TaskChange, error)
+	ChangesBetween(ctx context.Context, from, to time.Time, afterID int64, limit int) ([]*model.TaskChange, error)
+}
+
+// HistoryAuthorizer checks that the caller may read the history of a
+// task, which outlives the task itself.
+type HistoryAuthorizer interface {
+	AuthorizeHistory(ctx context.Context, taskID int64) error
+}
+
+// HistoryPage is one page of task changes. NextPageToken is empty on the
+// last page.
+type HistoryPage struct {
+	Changes       []*model.TaskChange
+	NextPageToken string
+}
+
+// HistoryService reads the task history that the repository records with
+// every change.
+type HistoryService struct {
+	repo   HistoryRepository
+	tasks  HistoryAuthorizer
+	admins []string
+}
+
+// NewHistoryService returns a service that shows a task's history to
+// those who may read the task, and lets the given principals list changes
+// across all tasks.
+func NewHistoryService(repo HistoryRepository, tasks HistoryAuthorizer, admins []string) *HistoryService {
+	return &HistoryService{repo: repo, tasks: tasks, admins: admins}
+}
+
+// TaskHistory returns a page of the changes to a task, oldest first. The
+// history outlives the task, so deleted tasks can still be inspected by
+// those who could read them.
+func (s *HistoryService) TaskHistory(ctx context.Context, taskID int64, pageSize int, pageToken string) (*HistoryPage, error) {
+	afterID, limit, err := pageParams(pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tasks.AuthorizeHistory(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.repo.TaskHistory(ctx, taskID, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 && afterID == 0 {
+		return nil, ErrNoHistory
+	}
+
+	page := &HistoryPage{}
+	page.Changes, page.NextPageToken = trimPage(changes, limit, changeID)
+	return page, nil
+}
+
+// ChangesBetween returns a page of the changes to all tasks made in
+// [from, to). Only admins may call it.
+func (s *HistoryService) ChangesBetween(ctx context.Context, from, to time.Time, pageSize int, pageToken string) (*HistoryPage, error) {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !slices.Contains(s.admins, principal) {
+		return nil, ErrPermissionDenied
+	}
+
+	if !from.Before(to) {
+		return nil, &validation.Error{Violations: []validation.Violation{
+			{Field: "end_time", Description: "must be after start_time"},
+		}}
+	}
+	afterID, limit, err := pageParams(pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.repo.ChangesBetween(ctx, from, to, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &HistoryPage{}
+	page.Changes, page.NextPageToken = trimPage(changes, limit, changeID)
+	return page, nil
+}
+
+func changeID(c *model.TaskChange) int64 { return c.ID }
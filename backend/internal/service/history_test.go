@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+func newSQLiteRepo(t *testing.T) *repository.RepositoryDB {
+	t.Helper()
+	pool, err := db.Open(filepath.Join(t.TempDir(), "todo.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return &repository.RepositoryDB{Pool: pool}
+}
+
+func TestTaskHistoryRecordsEveryChange(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithWorkspaceRepository(repo), WithRevisionRepository(repo))
+	history := NewHistoryService(repo, tasks, []string{"admin"})
+	ctx := auth.WithPrincipal(context.Background(), "alice")
+
+	ws, err := NewWorkspaceService(repo, nil).CreateWorkspace(ctx, "Home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewWorkspaceService(repo, nil).SetMember(ctx, ws.ID, "bob", model.RoleEditor); err != nil {
+		t.Fatal(err)
+	}
+	task, err := tasks.CreateTaskIn(ctx, ws.ID, "Buy milk", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.Title = "Buy oat milk"
+	if _, err := tasks.UpdateTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	task.Completed = true
+	if _, err := tasks.UpdateTask(auth.WithPrincipal(context.Background(), "bob"), task); err != nil {
+		t.Fatal(err)
+	}
+	if err := tasks.DeleteTask(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []*model.TaskChange
+	token := ""
+	for pages := 0; ; pages++ {
+		if pages > 2 {
+			t.Fatal("pagination does not terminate")
+		}
+		page, err := history.TaskHistory(ctx, task.ID, 3, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changes = append(changes, page.Changes...)
+		if token = page.NextPageToken; token == "" {
+			break
+		}
+	}
+
+	if len(changes) != 4 {
+		t.Fatalf("expected 4 changes, got %d", len(changes))
+	}
+	wantActions := []string{model.ActionCreated, model.ActionUpdated, model.ActionUpdated, model.ActionDeleted}
+	wantActors := []string{"alice", "alice", "bob", "alice"}
+	for i, c := range changes {
+		if c.Action != wantActions[i] || c.Actor != wantActors[i] {
+			t.Errorf("change %d: got %s by %q, want %s by %q", i, c.Action, c.Actor, wantActions[i], wantActors[i])
+		}
+	}
+
+	if len(changes[0].Changes) != 3 || changes[0].Changes[0].Old != nil {
+		t.Errorf("created entry should list every field as new: %+v", changes[0].Changes)
+	}
+	rename := changes[1].Changes
+	if len(rename) != 1 || rename[0].Field != "title" || *rename[0].Old != "Buy milk" || *rename[0].New != "Buy oat milk" {
+		t.Errorf("unexpected rename diff: %+v", rename)
+	}
+	complete := changes[2].Changes
+	if len(complete) != 1 || complete[0].Field != "completed" || *complete[0].New != "true" {
+		t.Errorf("unexpected completion diff: %+v", complete)
+	}
+	if len(changes[3].Changes) != 3 || changes[3].Changes[0].New != nil {
+		t.Errorf("deleted entry should list every field as old: %+v", changes[3].Changes)
+	}
+
+	if _, err := history.TaskHistory(ctx, task.ID+1, 0, ""); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+	if _, err := history.TaskHistory(ctx, task.ID, 0, "not a token"); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("expected ErrInvalidData for a bad token, got %v", err)
+	}
+}
+
+func TestTaskHistoryRequiresRead(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithRevisionRepository(repo))
+	history := NewHistoryService(repo, tasks, nil)
+	alice := auth.WithPrincipal(context.Background(), "alice")
+
+	task, err := tasks.CreateTask(alice, "Buy milk", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tasks.DeleteTask(alice, task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{"owner after delete", alice, nil},
+		{"other user", auth.WithPrincipal(context.Background(), "bob"), ErrTaskNotFound},
+		{"anonymous", context.Background(), ErrTaskNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := history.TaskHistory(tt.ctx, task.ID, 0, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && len(page.Changes) != 2 {
+				t.Errorf("expected the create and delete, got %d changes", len(page.Changes))
+			}
+		})
+	}
+}
+
+func TestChangesBetweenRequiresAdmin(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	history := NewHistoryService(repo, NewTaskService(repo), []string{"admin"})
+
+	if _, err := NewTaskService(repo).CreateTask(auth.WithPrincipal(context.Background(), "alice"), "Task", ""); err != nil {
+		t.Fatal(err)
+	}
+	from, to := time.Now().Add(-time.Minute), time.Now().Add(time.Minute)
+
+	if _, err := history.ChangesBetween(context.Background(), from, to, 0, ""); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+	if _, err := history.ChangesBetween(auth.WithPrincipal(context.Background(), "alice"), from, to, 0, ""); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	admin := auth.WithPrincipal(context.Background(), "admin")
+	page, err := history.ChangesBetween(admin, from, to, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 1 {
+		t.Errorf("expected 1 change in range, got %d", len(page.Changes))
+	}
+
+	page, err = history.ChangesBetween(admin, to, to.Add(time.Hour), 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Changes) != 0 {
+		t.Errorf("expected no changes after the range, got %d", len(page.Changes))
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+const (
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// idempotencyStaleAfter bounds how long a call may hold a key. A key
+	// still running after it, for example because the server stopped
+	// mid-call, can be claimed again.
+	idempotencyStaleAfter = time.Minute
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+type IdempotencyRepository interface {
+	ClaimIdempotencyKey(ctx context.Context, k *model.IdempotencyKey, staleBefore time.Time) (*model.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, k *model.IdempotencyKey) error
+	ReleaseIdempotencyKey(ctx context.Context, k *model.IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+// IdempotencyService remembers the responses of mutating calls by a key
+// the client chooses, so a retried call returns the first response
+// instead of running again. Keys are scoped to the caller and method and
+// expire after a TTL.
+type IdempotencyService struct {
+	repo          IdempotencyRepository
+	ttl           time.Duration
+	sweepInterval time.Duration
+}
+
+type IdempotencyOption func(*IdempotencyService)
+
+// WithIdempotencyTTL sets how long a key and its response are kept.
+func WithIdempotencyTTL(d time.Duration) IdempotencyOption {
+	return func(s *IdempotencyService) {
+		s.ttl = d
+	}
+}
+
+// WithIdempotencySweepInterval sets how often expired keys are deleted.
+func WithIdempotencySweepInterval(d time.Duration) IdempotencyOption {
+	return func(s *IdempotencyService) {
+		s.sweepInterval = d
+	}
+}
+
+func NewIdempotencyService(repo IdempotencyRepository, opts ...IdempotencyOption) *IdempotencyService {
+	s := &IdempotencyService{repo: repo, ttl: DefaultIdempotencyTTL, sweepInterval: 10 * time.Minute}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Claim starts a call of method with key for a request with the given
+// hash. If the key completed earlier with the same request, the stored
+// entry is returned and the caller replays its response. Otherwise it
+// returns nil and the caller runs the request, then calls Complete or
+// Release with the returned claim. Anonymous callers cannot use keys,
+// since they would all share them.
+func (s *IdempotencyService) Claim(ctx context.Context, method, key, requestHash string) (claim, done *model.IdempotencyKey, err error) {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil, nil, ErrUnauthenticated
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	k := &model.IdempotencyKey{
+		Principal:   principal,
+		Method:      method,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+
+	existing, err := s.repo.ClaimIdempotencyKey(ctx, k, now.Add(-idempotencyStaleAfter))
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case existing == nil:
+		return k, nil, nil
+	case existing.RequestHash != requestHash:
+		return nil, nil, ErrIdempotencyKeyReused
+	case !existing.Completed():
+		return nil, nil, ErrIdempotencyKeyInProgress
+	}
+	return nil, existing, nil
+}
+
+// Complete stores the response of a claimed call.
+func (s *IdempotencyService) Complete(ctx context.Context, claim *model.IdempotencyKey, responseType string, response []byte) error {
+	claim.ResponseType, claim.Response = responseType, response
+	return s.repo.CompleteIdempotencyKey(ctx, claim)
+}
+
+// Release frees the key of a claimed call that failed, so a retry runs
+// it again.
+func (s *IdempotencyService) Release(ctx context.Context, claim *model.IdempotencyKey) error {
+	return s.repo.ReleaseIdempotencyKey(ctx, claim)
+}
+
+// Run deletes expired keys until ctx is cancelled.
+func (s *IdempotencyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SweepExpired(ctx); err != nil && ctx.Err() == nil {
+			log.L().Errorf("Idempotency key sweep failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SweepExpired deletes expired keys once.
+func (s *IdempotencyService) SweepExpired(ctx context.Context) error {
+	n, err := s.repo.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+	if err == nil && n > 0 {
+		log.L().Infof("Idempotency key sweep: deleted %d expired keys", n)
+	}
+	return err
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"github.com/Elmar006/todo_grpc/internal/validation"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// pageParams decodes a page token, which holds the ID of the last item on
+// the previous page, and clamps the page size. Callers fetch one item
+// more than the returned limit so that trimPage can tell whether another
+// page follows.
+func pageParams(pageSize int, pageToken string) (int64, int, error) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	if pageToken == "" {
+		return 0, pageSize, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err == nil {
+		var afterID int64
+		if afterID, err = strconv.ParseInt(string(raw), 10, 64); err == nil && afterID > 0 {
+			return afterID, pageSize, nil
+		}
+	}
+	return 0, 0, &validation.Error{Violations: []validation.Violation{
+		{Field: "page_token", Description: "is not a valid page token"},
+	}}
+}
+
+// trimPage cuts items down to limit and returns the token of the next
+// page, which is empty when there is none.
+func trimPage[T any](items []T, limit int, id func(T) int64) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	last := id(items[limit-1])
+	return items, base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(last, 10)))
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+var (
+	ErrRevisionsDisabled = errors.New("task revisions are not configured")
+	ErrRevisionNotFound  = errors.New("task revision not found")
+	ErrTaskNotDeleted    = errors.New("task is not deleted")
+)
+
+// RevisionRepository keeps a snapshot of a task after every change.
+type RevisionRepository interface {
+	ListRevisions(ctx context.Context, taskID int64) ([]*model.TaskRevision, error)
+	Revert(ctx context.Context, taskID, revision int64) (*model.Model, error)
+	Restore(ctx context.Context, taskID int64) (*model.Model, error)
+}
+
+func WithRevisionRepository(r RevisionRepository) Option {
+	return func(s *TaskService) {
+		s.revisions = r
+	}
+}
+
+// ListTaskRevisions returns the revisions of a task, newest first,
+// including those of a deleted task.
+func (s *TaskService) ListTaskRevisions(ctx context.Context, taskID int64) ([]*model.TaskRevision, error) {
+	if s.revisions == nil {
+		return nil, ErrRevisionsDisabled
+	}
+
+	return s.authorizeRevisions(ctx, taskID, rbac.TaskRead)
+}
+
+// RevertTask restores the fields of a task to the given revision,
+// recreating the task if it has been deleted.
+func (s *TaskService) RevertTask(ctx context.Context, taskID, revision int64) (*model.Model, error) {
+	if s.revisions == nil {
+		return nil, ErrRevisionsDisabled
+	}
+	if _, err := s.authorizeRevisions(ctx, taskID, rbac.TaskUpdate); err != nil {
+		return nil, err
+	}
+
+	task, err := s.revisions.Revert(ctx, taskID, revision)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// RestoreTask recreates a deleted task from its last revision.
+func (s *TaskService) RestoreTask(ctx context.Context, taskID int64) (*model.Model, error) {
+	if s.revisions == nil {
+		return nil, ErrRevisionsDisabled
+	}
+	if _, err := s.authorizeRevisions(ctx, taskID, rbac.TaskDelete); err != nil {
+		return nil, err
+	}
+
+	task, err := s.revisions.Restore(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		if errors.Is(err, repository.ErrTaskExists) {
+			return nil, ErrTaskNotDeleted
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// authorizeRevisions loads the revisions of a task and authorizes action
+// against the newest one, which also covers deleted tasks.
+func (s *TaskService) authorizeRevisions(ctx context.Context, taskID int64, action rbac.Action) ([]*model.TaskRevision, error) {
+	revisions, err := s.revisions.ListRevisions(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, ErrTaskNotFound
+	}
+	if err := s.authorize(ctx, revisions[0].Task, action); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
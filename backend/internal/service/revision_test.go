@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+)
+
+func TestRevertAndRestoreTask(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithRevisionRepository(repo))
+	ctx := auth.WithPrincipal(context.Background(), "alice")
+
+	task, err := tasks.CreateTask(ctx, "Buy milk", "2 litres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.Title, task.Completed = "garbage", true
+	if _, err := tasks.UpdateTask(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+
+	reverted, err := tasks.RevertTask(ctx, task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reverted.Title != "Buy milk" || *reverted.Description != "2 litres" || reverted.Completed {
+		t.Errorf("task not reverted: %+v", reverted)
+	}
+	got, err := tasks.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Buy milk" || got.Completed || got.Owner != "alice" {
+		t.Errorf("stored task not reverted: %+v", got)
+	}
+
+	revisions, err := tasks.ListTaskRevisions(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 3 || revisions[0].Revision != 3 || revisions[0].Task.Title != "Buy milk" {
+		t.Fatalf("expected the revert as revision 3, got %d revisions", len(revisions))
+	}
+
+	if _, err := tasks.RevertTask(ctx, task.ID, 9); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("expected ErrRevisionNotFound, got %v", err)
+	}
+	if _, err := tasks.RestoreTask(ctx, task.ID); !errors.Is(err, ErrTaskNotDeleted) {
+		t.Errorf("expected ErrTaskNotDeleted, got %v", err)
+	}
+
+	if err := tasks.DeleteTask(ctx, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := tasks.RestoreTask(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.ID != task.ID || restored.Title != "Buy milk" || restored.Owner != "alice" {
+		t.Errorf("unexpected restored task: %+v", restored)
+	}
+	if got, err := tasks.GetTask(ctx, task.ID); err != nil || got.Title != "Buy milk" {
+		t.Errorf("restored task not stored: %+v, %v", got, err)
+	}
+
+	if _, err := tasks.ListTaskRevisions(ctx, task.ID+1); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
@@ -4,77 +4,162 @@ import (
 	"context"
 	"errors"
 
+	"github.com/Elmar006/todo_grpc/internal/auth"
 	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
 	"github.com/Elmar006/todo_grpc/internal/repository"
+	"github.com/Elmar006/todo_grpc/internal/validation"
 )
 
 var (
-	ErrInvalidData  = errors.New("invalid data")
-	ErrTaskNotFound = errors.New("task not found")
+	ErrInvalidData   = validation.ErrInvalidData
+	ErrTaskNotFound  = errors.New("task not found")
+	ErrQuotaExceeded = repository.ErrQuotaExceeded
 )
 
 type TaskRepository interface {
-	Create(ctx context.Context, title, description string) (*model.Model, error)
+	CreateTask(ctx context.Context, t model.NewTask) (*model.Model, error)
 	GetByID(ctx context.Context, id int64) (*model.Model, error)
 	List(ctx context.Context, filter string) ([]*model.Model, error)
-	Update(ctx context.Context, task *model.Model) error
+	Update(ctx context.Context, task *model.Model) (*model.Model, error)
 	Delete(ctx context.Context, id int64) error
 }
 
 type TaskService struct {
-	repo TaskRepository
+	repo       TaskRepository
+	validator  *validation.Validator
+	taskQuota  int
+	feeds      FeedRepository
+	revisions  RevisionRepository
+	workspaces AssignmentRepository
+	shares     ShareRepository
+	owners     OwnerCounter
+	policy     *rbac.Policy
+	admins     []string
 }
 
-func NewTaskService(repo TaskRepository) *TaskService {
-	return &TaskService{repo: repo}
+type Option func(*TaskService)
+
+func WithValidator(v *validation.Validator) Option {
+	return func(s *TaskService) {
+		s.validator = v
+	}
 }
 
-func (s *TaskService) CreateTask(ctx context.Context, title, description string) (*model.Model, error) {
-	if title == "" {
-		return nil, ErrInvalidData
+// WithTaskQuota caps how many tasks a single owner may store, counted
+// when a task is inserted. Zero means unlimited.
+func WithTaskQuota(n int) Option {
+	return func(s *TaskService) {
+		s.taskQuota = n
 	}
+}
 
-	task, err := s.repo.Create(ctx, title, description)
-	if err != nil {
-		return nil, err
+// WithPolicy replaces the built-in RBAC policy.
+func WithPolicy(p *rbac.Policy) Option {
+	return func(s *TaskService) {
+		s.policy = p
 	}
+}
 
-	return task, nil
+// WithAdmins names the principals who own personal tasks without an
+// owner, which predate owners and would otherwise be unreachable.
+func WithAdmins(admins []string) Option {
+	return func(s *TaskService) {
+		s.admins = admins
+	}
 }
 
-func (s *TaskService) GetTask(ctx context.Context, id int64) (*model.Model, error) {
-	task, err := s.repo.GetByID(ctx, id)
-	if err != nil {
+func NewTaskService(repo TaskRepository, opts ...Option) *TaskService {
+	s := &TaskService{
+		repo:      repo,
+		validator: validation.New(validation.DefaultLimits()),
+		policy:    rbac.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *TaskService) CreateTask(ctx context.Context, title, description string) (*model.Model, error) {
+	return s.CreateTaskIn(ctx, 0, title, description)
+}
+
+// CreateTaskIn creates a task in a workspace the caller belongs to, or a
+// personal task when workspaceID is 0. Anonymous callers cannot create
+// tasks: they would have no role on them and would share one quota.
+func (s *TaskService) CreateTaskIn(ctx context.Context, workspaceID int64, title, description string) (*model.Model, error) {
+	return s.createTask(ctx, model.NewTask{WorkspaceID: workspaceID, Title: title, Description: description})
+}
+
+// createTask validates t, checks that the caller may create it and
+// stores it as theirs within the quota.
+func (s *TaskService) createTask(ctx context.Context, t model.NewTask) (*model.Model, error) {
+	if err := s.validator.Task(t.Title, &t.Description); err != nil {
 		return nil, err
 	}
-	if task == nil {
-		return nil, ErrTaskNotFound
+
+	owner, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
 	}
+	if t.WorkspaceID != 0 {
+		if s.workspaces == nil {
+			return nil, ErrWorkspacesDisabled
+		}
+		role, err := memberRole(ctx, s.workspaces, t.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		if !s.policy.Allows(role, rbac.TaskCreate) {
+			return nil, ErrPermissionDenied
+		}
+	}
+
+	t.Owner, t.Quota = owner, s.taskQuota
+	return s.repo.CreateTask(ctx, t)
+}
 
-	return task, nil
+func (s *TaskService) GetTask(ctx context.Context, id int64) (*model.Model, error) {
+	return s.authorizeID(ctx, id, rbac.TaskRead)
 }
 
+// ListTask returns the tasks matching filter that the caller may read.
 func (s *TaskService) ListTask(ctx context.Context, filter string) ([]*model.Model, error) {
 	tasks, err := s.repo.List(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return tasks, nil
+	return s.readable(ctx, tasks)
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, task *model.Model) error {
-	if err := s.repo.Update(ctx, task); err != nil {
+// UpdateTask saves task and returns it as stored.
+func (s *TaskService) UpdateTask(ctx context.Context, task *model.Model) (*model.Model, error) {
+	if err := s.validator.Task(task.Title, task.Description); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeID(ctx, task.ID, rbac.TaskUpdate); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, task)
+	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return ErrTaskNotFound
+			return nil, ErrTaskNotFound
 		}
-		return err
+		return nil, err
 	}
 
-	return nil
+	return updated, nil
 }
 
 func (s *TaskService) DeleteTask(ctx context.Context, id int64) error {
+	if _, err := s.authorizeID(ctx, id, rbac.TaskDelete); err != nil {
+		return err
+	}
+
 	if err := s.repo.Delete(ctx, id); err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return ErrTaskNotFound
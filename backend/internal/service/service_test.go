@@ -3,22 +3,26 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
+	"github.com/Elmar006/todo_grpc/internal/auth"
 	"github.com/Elmar006/todo_grpc/internal/model"
 	"github.com/Elmar006/todo_grpc/internal/repository"
+	"github.com/Elmar006/todo_grpc/internal/validation"
 )
 
 type fakeRepo struct {
-	createFunc  func(ctx context.Context, title, description string) (*model.Model, error)
+	createFunc  func(ctx context.Context, t model.NewTask) (*model.Model, error)
 	getByIdFunc func(ctx context.Context, id int64) (*model.Model, error)
 	listFunc    func(ctx context.Context, filter string) ([]*model.Model, error)
-	updateFunc  func(ctx context.Context, task *model.Model) error
+	updateFunc  func(ctx context.Context, task *model.Model) (*model.Model, error)
 	deleteFunc  func(ctx context.Context, id int64) error
 }
 
-func (f *fakeRepo) Create(ctx context.Context, title, description string) (*model.Model, error) {
-	return f.createFunc(ctx, title, description)
+func (f *fakeRepo) CreateTask(ctx context.Context, t model.NewTask) (*model.Model, error) {
+	return f.createFunc(ctx, t)
 }
 
 func (f *fakeRepo) GetByID(ctx context.Context, id int64) (*model.Model, error) {
@@ -29,7 +33,7 @@ func (f *fakeRepo) List(ctx context.Context, filter string) ([]*model.Model, err
 	return f.listFunc(ctx, filter)
 }
 
-func (f *fakeRepo) Update(ctx context.Context, task *model.Model) error {
+func (f *fakeRepo) Update(ctx context.Context, task *model.Model) (*model.Model, error) {
 	return f.updateFunc(ctx, task)
 }
 
@@ -39,25 +43,29 @@ func (f *fakeRepo) Delete(ctx context.Context, id int64) error {
 
 func TestCreateTaskCorrected(t *testing.T) {
 	taskCheck := &fakeRepo{
-		createFunc: func(ctx context.Context, title, description string) (*model.Model, error) {
-			if title != "Test Task" {
-				t.Errorf("Expected title 'Test Task', got %q", title)
+		createFunc: func(ctx context.Context, nt model.NewTask) (*model.Model, error) {
+			if nt.Title != "Test Task" {
+				t.Errorf("Expected title 'Test Task', got %q", nt.Title)
 			}
-			if description != "Test Desc" {
-				t.Errorf("Expected description 'Test Desc', got %q", description)
+			if nt.Description != "Test Desc" {
+				t.Errorf("Expected description 'Test Desc', got %q", nt.Description)
+			}
+			if nt.Owner != "alice" {
+				t.Errorf("Expected owner 'alice', got %q", nt.Owner)
 			}
 			var id int64 = 123
 			task := model.Model{
 				ID:          id,
-				Title:       title,
-				Description: &description,
+				Title:       nt.Title,
+				Description: &nt.Description,
+				Owner:       nt.Owner,
 			}
 			return &task, nil
 		},
 	}
 
 	service := NewTaskService(taskCheck)
-	task, err := service.CreateTask(context.Background(), "Test Task", "Test Desc")
+	task, err := service.CreateTask(auth.WithPrincipal(context.Background(), "alice"), "Test Task", "Test Desc")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -78,10 +86,86 @@ func TestCreateTaskInvalid(t *testing.T) {
 	}
 }
 
+func TestCreateTaskViolations(t *testing.T) {
+	service := NewTaskService(&fakeRepo{}, WithValidator(validation.New(validation.Limits{
+		TitleMaxLength:       200,
+		DescriptionMaxLength: 5,
+	})))
+
+	_, err := service.CreateTask(context.Background(), " ", "too long")
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidData) {
+		t.Errorf("expected ErrInvalidData, got %v", err)
+	}
+
+	fields := map[string]bool{}
+	for _, v := range verr.Violations {
+		fields[v.Field] = true
+	}
+	if !fields["title"] || !fields["description"] {
+		t.Errorf("expected title and description violations, got %+v", verr.Violations)
+	}
+}
+
+func TestCreateTaskQuotaExceeded(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	service := NewTaskService(repo, WithTaskQuota(3))
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	bob := auth.WithPrincipal(context.Background(), "bob")
+
+	// Creates race each other; the quota still holds.
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.CreateTask(alice, fmt.Sprintf("Task %d", i), "")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrQuotaExceeded):
+			t.Errorf("expected ErrQuotaExceeded, got %v", err)
+		}
+	}
+	if created != 3 {
+		t.Errorf("expected 3 tasks within the quota, got %d", created)
+	}
+	if _, err := service.CreateTask(bob, "Task", ""); err != nil {
+		t.Errorf("expected the quota to be per owner, got %v", err)
+	}
+}
+
+func TestCreateTaskAnonymous(t *testing.T) {
+	taskCheck := &fakeRepo{
+		createFunc: func(ctx context.Context, nt model.NewTask) (*model.Model, error) {
+			t.Error("CreateTask must not reach the repository for an anonymous caller")
+			return nil, nil
+		},
+	}
+
+	service := NewTaskService(taskCheck)
+	if _, err := service.CreateTask(context.Background(), "Test Task", ""); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+}
+
 func TestGetTaskCorrected(t *testing.T) {
 	testTaskRequest := &model.Model{
 		ID:    123,
 		Title: "Test Title",
+		Owner: "alice",
 	}
 
 	taskCheck := &fakeRepo{
@@ -95,7 +179,7 @@ func TestGetTaskCorrected(t *testing.T) {
 	}
 
 	service := NewTaskService(taskCheck)
-	task, err := service.GetTask(context.Background(), 123)
+	task, err := service.GetTask(auth.WithPrincipal(context.Background(), "alice"), 123)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -137,8 +221,8 @@ func TestGetTaskRepoError(t *testing.T) {
 }
 func TestListTask(t *testing.T) {
 	tasksTest := []*model.Model{
-		{ID: 1},
-		{ID: 2},
+		{ID: 1, Owner: "alice"},
+		{ID: 2, Owner: "alice"},
 	}
 	taskCheck := &fakeRepo{
 		listFunc: func(ctx context.Context, filter string) ([]*model.Model, error) {
@@ -151,7 +235,7 @@ func TestListTask(t *testing.T) {
 	}
 
 	service := NewTaskService(taskCheck)
-	tasks, err := service.ListTask(context.Background(), "test")
+	tasks, err := service.ListTask(auth.WithPrincipal(context.Background(), "alice"), "test")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -163,18 +247,21 @@ func TestListTask(t *testing.T) {
 func TestUpdateTaskSuccess(t *testing.T) {
 	called := false
 	taskCheck := &fakeRepo{
-		updateFunc: func(ctx context.Context, task *model.Model) error {
+		getByIdFunc: func(ctx context.Context, id int64) (*model.Model, error) {
+			return &model.Model{ID: id, Owner: "alice"}, nil
+		},
+		updateFunc: func(ctx context.Context, task *model.Model) (*model.Model, error) {
 			called = true
 			if task.ID != 123 {
 				t.Errorf("expected id 123, got %d", task.ID)
 			}
 
-			return nil
+			return task, nil
 		},
 	}
 
 	service := NewTaskService(taskCheck)
-	if err := service.UpdateTask(context.Background(), &model.Model{ID: 123}); err != nil {
+	if _, err := service.UpdateTask(auth.WithPrincipal(context.Background(), "alice"), &model.Model{ID: 123, Title: "Test Title"}); err != nil {
 		t.Fatal(err)
 	}
 	if !called {
@@ -184,13 +271,16 @@ func TestUpdateTaskSuccess(t *testing.T) {
 
 func TestUpdateTaskNotFound(t *testing.T) {
 	taskCheck := &fakeRepo{
-		updateFunc: func(ctx context.Context, task *model.Model) error {
-			return repository.ErrNotFound
+		getByIdFunc: func(ctx context.Context, id int64) (*model.Model, error) {
+			return nil, nil
+		},
+		updateFunc: func(ctx context.Context, task *model.Model) (*model.Model, error) {
+			return nil, repository.ErrNotFound
 		},
 	}
 
 	service := NewTaskService(taskCheck)
-	if err := service.UpdateTask(context.Background(), &model.Model{ID: 123}); err != nil {
+	if _, err := service.UpdateTask(context.Background(), &model.Model{ID: 123, Title: "Test Title"}); err != nil {
 		if !errors.Is(err, ErrTaskNotFound) {
 			t.Errorf("expected ErrTaskNotFound, got %v", err)
 		}
@@ -200,6 +290,9 @@ func TestUpdateTaskNotFound(t *testing.T) {
 func TestDeleteTaskSuccess(t *testing.T) {
 	called := false
 	taskCheck := &fakeRepo{
+		getByIdFunc: func(ctx context.Context, id int64) (*model.Model, error) {
+			return &model.Model{ID: id, Owner: "alice"}, nil
+		},
 		deleteFunc: func(ctx context.Context, id int64) error {
 			called = true
 			if id != 123 {
@@ -211,7 +304,7 @@ func TestDeleteTaskSuccess(t *testing.T) {
 	}
 
 	service := NewTaskService(taskCheck)
-	if err := service.DeleteTask(context.Background(), 123); err != nil {
+	if err := service.DeleteTask(auth.WithPrincipal(context.Background(), "alice"), 123); err != nil {
 		t.Fatal(err)
 	}
 	if !called {
@@ -221,6 +314,9 @@ func TestDeleteTaskSuccess(t *testing.T) {
 
 func TestDeleteTaskNotFound(t *testing.T) {
 	taskCheck := &fakeRepo{
+		getByIdFunc: func(ctx context.Context, id int64) (*model.Model, error) {
+			return nil, nil
+		},
 		deleteFunc: func(ctx context.Context, id int64) error {
 			return repository.ErrNotFound
 		},
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+)
+
+const (
+	DefaultShareLinkTTL = 7 * 24 * time.Hour
+	MaxShareLinkTTL     = 90 * 24 * time.Hour
+)
+
+var (
+	ErrSharesDisabled    = errors.New("task sharing is not configured")
+	ErrShareNotFound     = errors.New("task share not found")
+	ErrShareLinkNotFound = errors.New("share link not found")
+)
+
+// ShareRepository stores per-task grants and share links.
+type ShareRepository interface {
+	ShareTask(ctx context.Context, share *model.TaskShare) error
+	UnshareTask(ctx context.Context, taskID int64, user string) error
+	ListTaskShares(ctx context.Context, taskID int64) ([]*model.TaskShare, error)
+	SharedWith(ctx context.Context, user string) (map[int64]string, error)
+	TaskShareRole(ctx context.Context, taskID int64, user string) (string, error)
+	CreateShareLink(ctx context.Context, link *model.ShareLink, tokenHash string) error
+	GetShareLink(ctx context.Context, id int64) (*model.ShareLink, error)
+	ListShareLinks(ctx context.Context, taskID int64) ([]*model.ShareLink, error)
+	RevokeShareLink(ctx context.Context, id int64) (*model.ShareLink, error)
+	SharedTaskID(ctx context.Context, tokenHash string, now time.Time) (int64, error)
+}
+
+func WithShareRepository(r ShareRepository) Option {
+	return func(s *TaskService) {
+		s.shares = r
+	}
+}
+
+// ShareTask grants a user the viewer or editor role on a single task.
+func (s *TaskService) ShareTask(ctx context.Context, taskID int64, user, role string) (*model.TaskShare, error) {
+	if s.shares == nil {
+		return nil, ErrSharesDisabled
+	}
+	if role != model.RoleViewer && role != model.RoleEditor {
+		return nil, &validation.Error{Violations: []validation.Violation{
+			{Field: "role", Description: "must be viewer or editor"},
+		}}
+	}
+	if _, err := s.authorizeID(ctx, taskID, rbac.TaskShare); err != nil {
+		return nil, err
+	}
+
+	grantedBy, _ := auth.PrincipalFrom(ctx)
+	share := &model.TaskShare{TaskID: taskID, User: user, Role: role, GrantedBy: grantedBy}
+	if err := s.shares.ShareTask(ctx, share); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return share, nil
+}
+
+// UnshareTask revokes a user's grant on a task.
+func (s *TaskService) UnshareTask(ctx context.Context, taskID int64, user string) error {
+	if s.shares == nil {
+		return ErrSharesDisabled
+	}
+	if _, err := s.authorizeID(ctx, taskID, rbac.TaskShare); err != nil {
+		return err
+	}
+
+	if err := s.shares.UnshareTask(ctx, taskID, user); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrShareNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *TaskService) ListTaskShares(ctx context.Context, taskID int64) ([]*model.TaskShare, error) {
+	if s.shares == nil {
+		return nil, ErrSharesDisabled
+	}
+	if _, err := s.authorizeID(ctx, taskID, rbac.TaskShare); err != nil {
+		return nil, err
+	}
+
+	return s.shares.ListTaskShares(ctx, taskID)
+}
+
+// CreateShareLink creates a link that grants read-only access to a task
+// for ttl, or DefaultShareLinkTTL when ttl is zero. The returned link is
+// the only one that carries the token.
+func (s *TaskService) CreateShareLink(ctx context.Context, taskID int64, ttl time.Duration) (*model.ShareLink, error) {
+	if s.shares == nil {
+		return nil, ErrSharesDisabled
+	}
+	if ttl == 0 {
+		ttl = DefaultShareLinkTTL
+	}
+	if ttl < 0 || ttl > MaxShareLinkTTL {
+		return nil, &validation.Error{Violations: []validation.Violation{
+			{Field: "ttl_seconds", Description: fmt.Sprintf("must be between 0 and %d", int64(MaxShareLinkTTL.Seconds()))},
+		}}
+	}
+	if _, err := s.authorizeID(ctx, taskID, rbac.TaskShare); err != nil {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	createdBy, _ := auth.PrincipalFrom(ctx)
+	link := &model.ShareLink{TaskID: taskID, Token: token, CreatedBy: createdBy, ExpiresAt: time.Now().Add(ttl)}
+	if err := s.shares.CreateShareLink(ctx, link, hashToken(token)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// ListShareLinks returns the links of a task, including expired and
+// revoked ones, without their tokens.
+func (s *TaskService) ListShareLinks(ctx context.Context, taskID int64) ([]*model.ShareLink, error) {
+	if s.shares == nil {
+		return nil, ErrSharesDisabled
+	}
+	if _, err := s.authorizeID(ctx, taskID, rbac.TaskShare); err != nil {
+		return nil, err
+	}
+
+	return s.shares.ListShareLinks(ctx, taskID)
+}
+
+func (s *TaskService) RevokeShareLink(ctx context.Context, id int64) (*model.ShareLink, error) {
+	if s.shares == nil {
+		return nil, ErrSharesDisabled
+	}
+
+	link, err := s.shares.GetShareLink(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.authorizeID(ctx, link.TaskID, rbac.TaskShare); err != nil {
+		return nil, err
+	}
+
+	return s.shares.RevokeShareLink(ctx, id)
+}
+
+// SharedTask returns the task a share token grants access to. Anyone
+// holding a valid token may call it, authenticated or not.
+func (s *TaskService) SharedTask(ctx context.Context, token string) (*model.Model, error) {
+	if s.shares == nil {
+		return nil, ErrSharesDisabled
+	}
+
+	taskID, err := s.shares.SharedTaskID(ctx, hashToken(token), time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+
+	task, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrShareLinkNotFound
+	}
+
+	return task, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
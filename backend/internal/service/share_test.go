@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+func TestTaskShares(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithShareRepository(repo))
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	bob := auth.WithPrincipal(context.Background(), "bob")
+
+	task, err := tasks.CreateTask(alice, "Plan trip", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.GetTask(bob, task.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("expected ErrPermissionDenied before sharing, got %v", err)
+	}
+
+	if _, err := tasks.ShareTask(alice, task.ID, "bob", model.RoleOwner); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("expected ErrInvalidData for sharing ownership, got %v", err)
+	}
+	if _, err := tasks.ShareTask(alice, task.ID, "bob", model.RoleViewer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.GetTask(bob, task.ID); err != nil {
+		t.Errorf("viewer should read the task: %v", err)
+	}
+	if list, err := tasks.ListTask(bob, ""); err != nil || len(list) != 1 {
+		t.Errorf("expected the shared task in bob's list, got %d, %v", len(list), err)
+	}
+	renamed := *task
+	renamed.Title = "Plan the trip"
+	if _, err := tasks.UpdateTask(bob, &renamed); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied for a viewer update, got %v", err)
+	}
+	if _, err := tasks.ShareTask(bob, task.ID, "carol", model.RoleViewer); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied for a viewer sharing, got %v", err)
+	}
+
+	if _, err := tasks.ShareTask(alice, task.ID, "bob", model.RoleEditor); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.UpdateTask(bob, &renamed); err != nil {
+		t.Errorf("editor should update the task: %v", err)
+	}
+	if err := tasks.DeleteTask(bob, task.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied for an editor delete, got %v", err)
+	}
+
+	if err := tasks.UnshareTask(alice, task.ID, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tasks.UnshareTask(alice, task.ID, "bob"); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("expected ErrShareNotFound, got %v", err)
+	}
+	if _, err := tasks.GetTask(bob, task.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied after unsharing, got %v", err)
+	}
+}
+
+func TestShareLinks(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithShareRepository(repo))
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	anonymous := context.Background()
+
+	task, err := tasks.CreateTask(alice, "Party", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.CreateShareLink(alice, task.ID, MaxShareLinkTTL+time.Hour); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("expected ErrInvalidData for a long TTL, got %v", err)
+	}
+	if _, err := tasks.CreateShareLink(anonymous, task.ID, 0); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied for a stranger, got %v", err)
+	}
+
+	link, err := tasks.CreateShareLink(alice, task.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Token == "" || link.ExpiresAt.Before(time.Now().Add(DefaultShareLinkTTL-time.Minute)) {
+		t.Fatalf("unexpected link %+v", link)
+	}
+	shared, err := tasks.SharedTask(anonymous, link.Token)
+	if err != nil || shared.ID != task.ID {
+		t.Fatalf("token should grant access to the task: %+v, %v", shared, err)
+	}
+	if _, err := tasks.SharedTask(anonymous, link.Token+"x"); !errors.Is(err, ErrShareLinkNotFound) {
+		t.Errorf("expected ErrShareLinkNotFound for a wrong token, got %v", err)
+	}
+
+	links, err := tasks.ListShareLinks(alice, task.ID)
+	if err != nil || len(links) != 1 || links[0].Token != "" {
+		t.Fatalf("expected one link without its token, got %+v, %v", links, err)
+	}
+	revoked, err := tasks.RevokeShareLink(alice, link.ID)
+	if err != nil || revoked.RevokedAt == nil {
+		t.Fatalf("revoke failed: %+v, %v", revoked, err)
+	}
+	if _, err := tasks.SharedTask(anonymous, link.Token); !errors.Is(err, ErrShareLinkNotFound) {
+		t.Errorf("expected ErrShareLinkNotFound for a revoked link, got %v", err)
+	}
+
+	expiring, err := tasks.CreateShareLink(alice, task.ID, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := tasks.SharedTask(anonymous, expiring.Token); !errors.Is(err, ErrShareLinkNotFound) {
+		t.Errorf("expected ErrShareLinkNotFound for an expired link, got %v", err)
+	}
+}
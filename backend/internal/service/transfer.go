@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/taskio"
+)
+
+// DuplicatePolicy decides what an import does with a row whose title
+// matches an existing task or an earlier row.
+type DuplicatePolicy int
+
+const (
+	DuplicateSkip DuplicatePolicy = iota
+	DuplicateAllow
+	DuplicateReject
+)
+
+type ImportOptions struct {
+	DryRun      bool
+	OnDuplicate DuplicatePolicy
+}
+
+type ImportRowError struct {
+	Row     int
+	Message string
+}
+
+type ImportResult struct {
+	Total    int
+	Imported int
+	Skipped  int
+	Errors   []ImportRowError
+	DryRun   bool
+}
+
+// ExportTasks writes every task matching filter that the caller may read
+// through enc.
+func (s *TaskService) ExportTasks(ctx context.Context, filter string, enc taskio.Encoder) error {
+	tasks, err := s.ListTask(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if err := enc.Encode(task); err != nil {
+			return err
+		}
+	}
+	return enc.Flush()
+}
+
+// OwnerCounter counts the tasks of an owner. Only dry-run imports need
+// it: real inserts check the quota themselves, but a dry run inserts
+// nothing and has to count.
+type OwnerCounter interface {
+	CountByOwner(ctx context.Context, owner string) (int, error)
+}
+
+// WithOwnerCounter lets dry-run imports check rows against the quota.
+func WithOwnerCounter(c OwnerCounter) Option {
+	return func(s *TaskService) {
+		s.owners = c
+	}
+}
+
+// ImportTasks creates a task for each decoded row. Rows that fail to
+// decode, fail validation or exceed the quota are reported and skipped;
+// any other error aborts the import. In dry-run mode nothing is written,
+// but with an OwnerCounter rows are still checked against the quota.
+func (s *TaskService) ImportTasks(ctx context.Context, dec taskio.Decoder, opts ImportOptions) (*ImportResult, error) {
+	owner, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	existing, err := s.ListTask(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	// owned counts the caller's tasks as a dry run goes along.
+	var owned *int
+	if opts.DryRun && s.taskQuota > 0 && s.owners != nil {
+		n, err := s.owners.CountByOwner(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		owned = &n
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, task := range existing {
+		seen[task.Title] = true
+	}
+
+	res := &ImportResult{DryRun: opts.DryRun}
+	for {
+		rec, row, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		res.Total++
+
+		var rowErr *taskio.RowError
+		if errors.As(err, &rowErr) {
+			res.Errors = append(res.Errors, ImportRowError{Row: rowErr.Row, Message: rowErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[rec.Title] {
+			switch opts.OnDuplicate {
+			case DuplicateSkip:
+				res.Skipped++
+				continue
+			case DuplicateReject:
+				res.Errors = append(res.Errors, ImportRowError{Row: row, Message: "duplicate title"})
+				continue
+			}
+		}
+
+		if err := s.importRecord(ctx, rec, opts.DryRun, owned); err != nil {
+			if errors.Is(err, ErrInvalidData) || errors.Is(err, ErrQuotaExceeded) {
+				res.Errors = append(res.Errors, ImportRowError{Row: row, Message: err.Error()})
+				continue
+			}
+			return nil, err
+		}
+
+		seen[rec.Title] = true
+		res.Imported++
+	}
+}
+
+// importRecord creates a task for rec, completed or not, in a single
+// insert. A dry run only validates rec and, unless owned is nil, checks
+// the quota against owned, which it advances.
+func (s *TaskService) importRecord(ctx context.Context, rec taskio.Record, dryRun bool, owned *int) error {
+	if !dryRun {
+		_, err := s.createTask(ctx, model.NewTask{Title: rec.Title, Description: rec.Description, Completed: rec.Completed})
+		return err
+	}
+
+	if err := s.validator.Task(rec.Title, &rec.Description); err != nil {
+		return err
+	}
+	if owned == nil {
+		return nil
+	}
+	if *owned >= s.taskQuota {
+		return ErrQuotaExceeded
+	}
+	*owned++
+	return nil
+}
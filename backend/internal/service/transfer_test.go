@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/taskio"
+)
+
+func TestImportTasksDuplicatesAndRowErrors(t *testing.T) {
+	input := `{"title":"Existing"}
+{"title":"New"}
+{"title":"New"}
+{"title":""}
+not json
+`
+	var created []string
+	taskCheck := &fakeRepo{
+		listFunc: func(ctx context.Context, filter string) ([]*model.Model, error) {
+			return []*model.Model{{ID: 1, Title: "Existing", Owner: "alice"}}, nil
+		},
+		createFunc: func(ctx context.Context, nt model.NewTask) (*model.Model, error) {
+			created = append(created, nt.Title)
+			return &model.Model{ID: int64(len(created) + 1), Title: nt.Title, Description: &nt.Description, Owner: nt.Owner}, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		opts     ImportOptions
+		imported int
+		skipped  int
+		errors   int
+		created  int
+	}{
+		{name: "skip", opts: ImportOptions{OnDuplicate: DuplicateSkip}, imported: 1, skipped: 2, errors: 2, created: 1},
+		{name: "reject", opts: ImportOptions{OnDuplicate: DuplicateReject}, imported: 1, skipped: 0, errors: 4, created: 1},
+		{name: "allow", opts: ImportOptions{OnDuplicate: DuplicateAllow}, imported: 3, skipped: 0, errors: 2, created: 3},
+		{name: "dry run", opts: ImportOptions{DryRun: true}, imported: 1, skipped: 2, errors: 2, created: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created = nil
+			dec, err := taskio.NewDecoder(taskio.JSONLines, strings.NewReader(input), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := NewTaskService(taskCheck).ImportTasks(auth.WithPrincipal(context.Background(), "alice"), dec, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Total != 5 || res.Imported != tt.imported || res.Skipped != tt.skipped || len(res.Errors) != tt.errors {
+				t.Errorf("unexpected result: %+v", res)
+			}
+			if len(created) != tt.created {
+				t.Errorf("expected %d tasks created, got %d", tt.created, len(created))
+			}
+		})
+	}
+}
+
+func TestImportTasksQuotaAndCompleted(t *testing.T) {
+	input := `{"title":"Done","completed":true}
+{"title":"Second"}
+{"title":"Third"}
+`
+	for _, dryRun := range []bool{true, false} {
+		t.Run(fmt.Sprintf("dry run %t", dryRun), func(t *testing.T) {
+			repo := newSQLiteRepo(t)
+			tasks := NewTaskService(repo, WithTaskQuota(2), WithRevisionRepository(repo), WithOwnerCounter(repo))
+			ctx := auth.WithPrincipal(context.Background(), "alice")
+			if _, err := tasks.CreateTask(ctx, "Existing", ""); err != nil {
+				t.Fatal(err)
+			}
+			dec, err := taskio.NewDecoder(taskio.JSONLines, strings.NewReader(input), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := tasks.ImportTasks(ctx, dec, ImportOptions{DryRun: dryRun})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Imported != 1 || len(res.Errors) != 2 {
+				t.Fatalf("expected 1 import and 2 rows over the quota, got %+v", res)
+			}
+			for _, e := range res.Errors {
+				if e.Message != ErrQuotaExceeded.Error() {
+					t.Errorf("expected a quota error, got %+v", e)
+				}
+			}
+			if dryRun {
+				if n, _ := repo.CountByOwner(ctx, "alice"); n != 1 {
+					t.Errorf("expected a dry run to write nothing, got %d tasks", n)
+				}
+				return
+			}
+
+			all, err := tasks.ListTask(ctx, "Done")
+			if err != nil || len(all) != 1 || !all[0].Completed {
+				t.Fatalf("expected the completed task, got %v, %v", all, err)
+			}
+			revisions, err := tasks.ListTaskRevisions(ctx, all[0].ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(revisions) != 1 {
+				t.Errorf("expected the task to be created in one step, got %d revisions", len(revisions))
+			}
+			pending, err := repo.PendingOutboxEvents(ctx, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var types []string
+			for _, e := range pending {
+				if e.Task.ID == all[0].ID {
+					types = append(types, e.Type)
+				}
+			}
+			if len(types) != 1 || types[0] != events.TaskCreated {
+				t.Errorf("expected a single task.created event, got %v", types)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+	"github.com/Elmar006/todo_grpc/internal/webhook"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, hook *model.Webhook) error
+	ListWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhookDeliveries(ctx context.Context, webhookID int64, limit int) ([]*model.WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, id int64, at time.Time) (*model.WebhookDelivery, error)
+}
+
+// WebhookService manages webhook subscriptions. A webhook receives the
+// events of every task, so only admins may manage them.
+type WebhookService struct {
+	repo   WebhookRepository
+	admins []string
+}
+
+func NewWebhookService(repo WebhookRepository, admins []string) *WebhookService {
+	return &WebhookService{repo: repo, admins: admins}
+}
+
+// CreateWebhook subscribes rawURL to the given event types, or to every
+// event when none are given. An empty secret is replaced by a random one.
+// URLs on private addresses are rejected.
+func (s *WebhookService) CreateWebhook(ctx context.Context, rawURL string, eventTypes []string, secret string) (*model.Webhook, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	var violations []validation.Violation
+	if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		violations = append(violations, validation.Violation{Field: "url", Description: "must be an absolute http or https URL"})
+	} else if err := webhook.CheckHost(ctx, u.Hostname()); err != nil {
+		if !errors.Is(err, webhook.ErrPrivateAddress) {
+			err = errors.New("host cannot be resolved")
+		}
+		violations = append(violations, validation.Violation{Field: "url", Description: err.Error()})
+	}
+	for _, t := range eventTypes {
+		if !events.Known(t) {
+			violations = append(violations, validation.Violation{Field: "events", Description: "unknown event type " + t})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, &validation.Error{Violations: violations}
+	}
+
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	eventTypes = slices.Clone(eventTypes)
+	slices.Sort(eventTypes)
+	owner, _ := auth.PrincipalFrom(ctx)
+	hook := &model.Webhook{
+		URL:    rawURL,
+		Secret: secret,
+		Events: slices.Compact(eventTypes),
+		Owner:  owner,
+	}
+	if err := s.repo.CreateWebhook(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.ListWebhooks(ctx)
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := s.authorize(ctx); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWebhookNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries returns the delivery log of a webhook, newest
+// first. limit defaults to 50 and is capped at 200.
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, webhookID int64, limit int) ([]*model.WebhookDelivery, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	return s.repo.ListWebhookDeliveries(ctx, webhookID, min(limit, maxDeliveryLimit))
+}
+
+// RetryWebhookDelivery queues a delivery again with a fresh attempt budget.
+func (s *WebhookService) RetryWebhookDelivery(ctx context.Context, id int64) (*model.WebhookDelivery, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	d, err := s.repo.RetryWebhookDelivery(ctx, id, time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (s *WebhookService) authorize(ctx context.Context) error {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !slices.Contains(s.admins, principal) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/validation"
+)
+
+func TestWebhooksAreAdminOnly(t *testing.T) {
+	s := NewWebhookService(newSQLiteRepo(t), []string{"admin"})
+	admin := auth.WithPrincipal(context.Background(), "admin")
+
+	for name, ctx := range map[string]context.Context{
+		"anonymous": context.Background(),
+		"alice":     auth.WithPrincipal(context.Background(), "alice"),
+	} {
+		want := ErrPermissionDenied
+		if name == "anonymous" {
+			want = ErrUnauthenticated
+		}
+		if _, err := s.CreateWebhook(ctx, "https://93.184.216.34/hook", nil, ""); !errors.Is(err, want) {
+			t.Errorf("%s: expected %v creating a webhook, got %v", name, want, err)
+		}
+		if _, err := s.ListWebhooks(ctx); !errors.Is(err, want) {
+			t.Errorf("%s: expected %v listing webhooks, got %v", name, want, err)
+		}
+		if err := s.DeleteWebhook(ctx, 1); !errors.Is(err, want) {
+			t.Errorf("%s: expected %v deleting a webhook, got %v", name, want, err)
+		}
+		if _, err := s.ListWebhookDeliveries(ctx, 1, 0); !errors.Is(err, want) {
+			t.Errorf("%s: expected %v listing deliveries, got %v", name, want, err)
+		}
+		if _, err := s.RetryWebhookDelivery(ctx, 1); !errors.Is(err, want) {
+			t.Errorf("%s: expected %v retrying a delivery, got %v", name, want, err)
+		}
+	}
+
+	for _, url := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest", "http://[::1]/hook"} {
+		var verr *validation.Error
+		if _, err := s.CreateWebhook(admin, url, nil, ""); !errors.As(err, &verr) {
+			t.Errorf("expected a validation error for %s, got %v", url, err)
+		}
+	}
+	if _, err := s.CreateWebhook(admin, "https://93.184.216.34/hook", nil, ""); err != nil {
+		t.Errorf("expected a public address to be accepted, got %v", err)
+	}
+}
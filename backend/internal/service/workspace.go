@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/rbac"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+var (
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	ErrMemberNotFound    = errors.New("workspace member not found")
+	ErrLastOwner         = repository.ErrLastOwner
+)
+
+type WorkspaceRepository interface {
+	CreateWorkspace(ctx context.Context, ws *model.Workspace) error
+	ListWorkspaces(ctx context.Context, member string) ([]*model.Workspace, error)
+	WorkspaceRole(ctx context.Context, workspaceID int64, member string) (string, error)
+	SetWorkspaceMember(ctx context.Context, m *model.WorkspaceMember) error
+	RemoveWorkspaceMember(ctx context.Context, workspaceID int64, member string) error
+	ListWorkspaceMembers(ctx context.Context, workspaceID int64) ([]*model.WorkspaceMember, error)
+}
+
+// WorkspaceService manages workspaces and their members. Workspaces are
+// only visible to their members; who may manage membership is decided by
+// the RBAC policy.
+type WorkspaceService struct {
+	repo   WorkspaceRepository
+	policy *rbac.Policy
+}
+
+// NewWorkspaceService returns a workspace service; a nil policy selects
+// the built-in one.
+func NewWorkspaceService(repo WorkspaceRepository, policy *rbac.Policy) *WorkspaceService {
+	if policy == nil {
+		policy = rbac.Default()
+	}
+	return &WorkspaceService{repo: repo, policy: policy}
+}
+
+// CreateWorkspace creates a workspace owned by the caller.
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, name string) (*model.Workspace, error) {
+	creator, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	ws := &model.Workspace{Name: name, CreatedBy: creator}
+	if err := s.repo.CreateWorkspace(ctx, ws); err != nil {
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+// ListWorkspaces returns the workspaces the caller belongs to.
+func (s *WorkspaceService) ListWorkspaces(ctx context.Context) ([]*model.Workspace, error) {
+	member, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	return s.repo.ListWorkspaces(ctx, member)
+}
+
+// ListMembers returns the members of a workspace the caller belongs to.
+func (s *WorkspaceService) ListMembers(ctx context.Context, workspaceID int64) ([]*model.WorkspaceMember, error) {
+	if _, err := memberRole(ctx, s.repo, workspaceID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListWorkspaceMembers(ctx, workspaceID)
+}
+
+// SetMember adds a member to a workspace or changes their role.
+func (s *WorkspaceService) SetMember(ctx context.Context, workspaceID int64, member, role string) (*model.WorkspaceMember, error) {
+	caller, err := memberRole(ctx, s.repo, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.repo.WorkspaceRole(ctx, workspaceID, member)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canManage(caller, current) || !s.canManage(caller, role) {
+		return nil, ErrPermissionDenied
+	}
+
+	m := &model.WorkspaceMember{WorkspaceID: workspaceID, Member: member, Role: role}
+	if err := s.repo.SetWorkspaceMember(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// RemoveMember removes a member from a workspace. Members may always
+// leave on their own.
+func (s *WorkspaceService) RemoveMember(ctx context.Context, workspaceID int64, member string) error {
+	caller, err := memberRole(ctx, s.repo, workspaceID)
+	if err != nil {
+		return err
+	}
+	current, err := s.repo.WorkspaceRole(ctx, workspaceID, member)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return ErrMemberNotFound
+	}
+	if self, _ := auth.PrincipalFrom(ctx); self != member && !s.canManage(caller, current) {
+		return ErrPermissionDenied
+	}
+
+	if err := s.repo.RemoveWorkspaceMember(ctx, workspaceID, member); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+type roleLookup interface {
+	WorkspaceRole(ctx context.Context, workspaceID int64, member string) (string, error)
+}
+
+// memberRole returns the caller's role in a workspace. Workspaces the
+// caller does not belong to are reported as not found; anonymous callers
+// belong to none.
+func memberRole(ctx context.Context, repo roleLookup, workspaceID int64) (string, error) {
+	caller, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	role, err := repo.WorkspaceRole(ctx, workspaceID, caller)
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		return "", ErrWorkspaceNotFound
+	}
+
+	return role, nil
+}
+
+// canManage reports whether a member with role caller may grant or revoke
+// role target. An empty target is a user who is not a member yet.
+func (s *WorkspaceService) canManage(caller, target string) bool {
+	if target == model.RoleOwner {
+		return s.policy.Allows(caller, rbac.ManageOwners)
+	}
+	return s.policy.Allows(caller, rbac.ManageMembers)
+}
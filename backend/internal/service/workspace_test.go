@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+func TestWorkspaceAssignments(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithWorkspaceRepository(repo))
+	workspaces := NewWorkspaceService(repo, nil)
+	alice := auth.WithPrincipal(context.Background(), "alice")
+	bob := auth.WithPrincipal(context.Background(), "bob")
+	carol := auth.WithPrincipal(context.Background(), "carol")
+
+	ws, err := workspaces.CreateWorkspace(alice, "Team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := workspaces.SetMember(alice, ws.ID, "bob", model.RoleEditor); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := workspaces.SetMember(bob, ws.ID, "carol", model.RoleEditor); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied for a member adding members, got %v", err)
+	}
+	if err := workspaces.RemoveMember(alice, ws.ID, "alice"); !errors.Is(err, ErrLastOwner) {
+		t.Errorf("expected ErrLastOwner, got %v", err)
+	}
+
+	if _, err := tasks.CreateTaskIn(carol, ws.ID, "Sneak in", ""); !errors.Is(err, ErrWorkspaceNotFound) {
+		t.Errorf("expected ErrWorkspaceNotFound for a non-member, got %v", err)
+	}
+	task, err := tasks.CreateTaskIn(alice, ws.ID, "Plan sprint", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tasks.CreateTaskIn(bob, ws.ID, "Review", ""); err != nil {
+		t.Fatal(err)
+	}
+	personal, err := tasks.CreateTask(alice, "Groceries", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tasks.AssignTask(alice, task.ID, []string{"bob", "carol"}); !errors.Is(err, ErrAssigneeNotMember) {
+		t.Errorf("expected ErrAssigneeNotMember, got %v", err)
+	}
+	if _, err := tasks.AssignTask(carol, task.ID, []string{"bob"}); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied for a non-member, got %v", err)
+	}
+	if _, err := tasks.AssignTask(alice, personal.ID, []string{"bob"}); !errors.Is(err, ErrAssigneeNotMember) {
+		t.Errorf("expected ErrAssigneeNotMember on a personal task, got %v", err)
+	}
+	task, err = tasks.AssignTask(alice, task.ID, []string{"bob", "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(task.AssigneeIDs, []string{"alice", "bob"}) {
+		t.Errorf("unexpected assignees %v", task.AssigneeIDs)
+	}
+
+	mine, err := tasks.SearchTasks(bob, "", 0, AssignedToMe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mine) != 1 || mine[0].ID != task.ID {
+		t.Errorf("expected only task %d assigned to bob, got %d tasks", task.ID, len(mine))
+	}
+	unassigned, err := tasks.SearchTasks(bob, "", ws.ID, Unassigned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unassigned) != 1 || unassigned[0].Title != "Review" {
+		t.Errorf("expected only the review task unassigned, got %d tasks", len(unassigned))
+	}
+	if _, err := tasks.SearchTasks(carol, "", ws.ID, AnyAssignee); !errors.Is(err, ErrWorkspaceNotFound) {
+		t.Errorf("expected ErrWorkspaceNotFound for a non-member, got %v", err)
+	}
+
+	if err := workspaces.RemoveMember(bob, ws.ID, "bob"); err != nil {
+		t.Fatalf("members should be able to leave: %v", err)
+	}
+	task, err = tasks.GetTask(alice, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(task.AssigneeIDs, []string{"alice"}) {
+		t.Errorf("expected bob to be unassigned after leaving, got %v", task.AssigneeIDs)
+	}
+
+	task, err = tasks.UnassignTask(alice, task.ID, []string{"alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(task.AssigneeIDs) != 0 {
+		t.Errorf("expected no assignees, got %v", task.AssigneeIDs)
+	}
+}
+
+func TestCreateWorkspaceRequiresPrincipal(t *testing.T) {
+	workspaces := NewWorkspaceService(newSQLiteRepo(t), nil)
+	if _, err := workspaces.CreateWorkspace(context.Background(), "Team"); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("expected ErrUnauthenticated, got %v", err)
+	}
+}
+
+func TestWorkspacesRejectAnonymousCallers(t *testing.T) {
+	repo := newSQLiteRepo(t)
+	tasks := NewTaskService(repo, WithWorkspaceRepository(repo))
+	workspaces := NewWorkspaceService(repo, nil)
+	anonymous := context.Background()
+
+	// A workspace created anonymously before principals were required has
+	// an owner row for the empty principal.
+	ws := &model.Workspace{Name: "Legacy"}
+	if err := repo.CreateWorkspace(anonymous, ws); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := map[string]func() error{
+		"list workspaces": func() error { _, err := workspaces.ListWorkspaces(anonymous); return err },
+		"list members":    func() error { _, err := workspaces.ListMembers(anonymous, ws.ID); return err },
+		"set member": func() error {
+			_, err := workspaces.SetMember(anonymous, ws.ID, "mallory", model.RoleOwner)
+			return err
+		},
+		"remove member": func() error { return workspaces.RemoveMember(anonymous, ws.ID, "") },
+		"search tasks": func() error {
+			_, err := tasks.SearchTasks(anonymous, "", ws.ID, AnyAssignee)
+			return err
+		},
+	}
+	for name, check := range checks {
+		t.Run(name, func(t *testing.T) {
+			if err := check(); !errors.Is(err, ErrUnauthenticated) {
+				t.Errorf("expected ErrUnauthenticated, got %v", err)
+			}
+		})
+	}
+}
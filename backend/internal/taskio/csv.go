@@ -0,0 +1,109 @@
+package taskio
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+var csvHeader = []string{"id", "title", "description", "completed", "created_at", "updated_at"}
+
+type csvEncoder struct {
+	w           *csv.Writer
+	loc         *time.Location
+	wroteHeader bool
+}
+
+func newCSVEncoder(w io.Writer, loc *time.Location) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w), loc: loc}
+}
+
+func (e *csvEncoder) Encode(task *model.Model) error {
+	if !e.wroteHeader {
+		if err := e.w.Write(csvHeader); err != nil {
+			return err
+		}
+		e.wroteHeader = true
+	}
+
+	return e.w.Write([]string{
+		strconv.FormatInt(task.ID, 10),
+		task.Title,
+		description(task),
+		strconv.FormatBool(task.Completed),
+		task.CreatedAt.In(e.loc).Format(time.RFC3339),
+		task.UpdatedAt.In(e.loc).Format(time.RFC3339),
+	})
+}
+
+// Flush writes the header even when no task was exported.
+func (e *csvEncoder) Flush() error {
+	if !e.wroteHeader {
+		if err := e.w.Write(csvHeader); err != nil {
+			return err
+		}
+		e.wroteHeader = true
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvDecoder expects a header row naming the columns.
+type csvDecoder struct {
+	r       *csv.Reader
+	mapping map[string]string
+	fields  []string
+	row     int
+}
+
+func newCSVDecoder(r io.Reader, mapping map[string]string) *csvDecoder {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	return &csvDecoder{r: cr, mapping: mapping}
+}
+
+func (d *csvDecoder) Decode() (Record, int, error) {
+	if d.fields == nil {
+		header, err := d.r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return Record{}, 0, io.EOF
+			}
+			return Record{}, 0, err
+		}
+		d.row++
+		d.fields = make([]string, len(header))
+		for i, name := range header {
+			d.fields[i] = targetField(d.mapping, name)
+		}
+	}
+
+	values, err := d.r.Read()
+	if errors.Is(err, io.EOF) {
+		return Record{}, d.row, io.EOF
+	}
+	d.row++
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return Record{}, d.row, &RowError{Row: d.row, Err: parseErr.Err}
+	}
+	if err != nil {
+		return Record{}, d.row, err
+	}
+
+	var rec Record
+	for i, value := range values {
+		if i >= len(d.fields) {
+			break
+		}
+		if err := rec.set(d.fields[i], value); err != nil {
+			return Record{}, d.row, &RowError{Row: d.row, Err: err}
+		}
+	}
+	return rec, d.row, nil
+}
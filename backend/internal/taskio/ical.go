@@ -0,0 +1,270 @@
+package taskio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// iCalendar (RFC 5545) limits content lines to 75 octets and expects
+// UTC timestamps in the basic format.
+const (
+	icalLineLimit  = 75
+	icalTimeLayout = "20060102T150405Z"
+	icalProdID     = "-//todo_grpc//Todo Service//EN"
+)
+
+type icalEncoder struct {
+	w      *bufio.Writer
+	opened bool
+}
+
+func newICalEncoder(w io.Writer) *icalEncoder {
+	return &icalEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes the task as a VTODO component. The calendar header is
+// written before the first task and the footer by Flush.
+func (e *icalEncoder) Encode(task *model.Model) error {
+	e.open()
+
+	e.line("BEGIN:VTODO")
+	e.line("UID:" + TaskUID(task.ID))
+	e.line("DTSTAMP:" + icalTime(task.UpdatedAt))
+	e.line("CREATED:" + icalTime(task.CreatedAt))
+	e.line("LAST-MODIFIED:" + icalTime(task.UpdatedAt))
+	e.line("SUMMARY:" + icalEscape(task.Title))
+	if desc := description(task); desc != "" {
+		e.line("DESCRIPTION:" + icalEscape(desc))
+	}
+	if task.Completed {
+		e.line("STATUS:COMPLETED")
+		e.line("COMPLETED:" + icalTime(task.UpdatedAt))
+	} else {
+		e.line("STATUS:NEEDS-ACTION")
+	}
+	e.line("END:VTODO")
+
+	return nil
+}
+
+func (e *icalEncoder) Flush() error {
+	e.open()
+	e.line("END:VCALENDAR")
+	return e.w.Flush()
+}
+
+func (e *icalEncoder) open() {
+	if e.opened {
+		return
+	}
+	e.opened = true
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + icalProdID)
+}
+
+// line writes a content line, folding it at icalLineLimit octets without
+// splitting UTF-8 sequences. Write errors surface from Flush.
+func (e *icalEncoder) line(s string) {
+	limit := icalLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space that counts towards the limit.
+		limit = icalLineLimit - 1
+	}
+	e.w.WriteString(s + "\r\n")
+}
+
+// TaskUID is the iCalendar UID of a task, stable across exports so that
+// calendar clients update entries instead of duplicating them.
+func TaskUID(id int64) string {
+	return "task-" + strconv.FormatInt(id, 10) + "@todo-grpc"
+}
+
+func icalTime(t time.Time) string {
+	return t.UTC().Format(icalTimeLayout)
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icalEscape(s string) string {
+	return icalEscaper.Replace(s)
+}
+
+func icalUnescape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			sb.WriteByte('\n')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+type icalDecoder struct {
+	scanner *bufio.Scanner
+	mapping map[string]string
+	row     int
+	next    string
+	nextRow int
+	hasNext bool
+}
+
+func newICalDecoder(r io.Reader, mapping map[string]string) *icalDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), maxLineSize)
+	names := make(map[string]string, len(mapping))
+	for source, target := range mapping {
+		names[strings.ToUpper(source)] = target
+	}
+	return &icalDecoder{scanner: s, mapping: names}
+}
+
+// Decode returns one record per VTODO component; other components are
+// skipped. The row is the line on which the component begins. Property
+// names can be mapped onto task fields; SUMMARY and DESCRIPTION map to
+// title and description by default, and STATUS:COMPLETED or a COMPLETED
+// property marks the task done.
+func (d *icalDecoder) Decode() (Record, int, error) {
+	var (
+		rec    Record
+		start  int
+		inTodo bool
+		rowErr error
+	)
+
+	for {
+		line, row, ok := d.readLine()
+		if !ok {
+			break
+		}
+		if line == "" {
+			continue
+		}
+
+		name, value, err := parseICalLine(line)
+		if err != nil {
+			if inTodo && rowErr == nil {
+				rowErr = err
+			}
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VTODO"):
+			rec, start, inTodo, rowErr = Record{}, row, true, nil
+		case name == "END" && strings.EqualFold(value, "VTODO") && inTodo:
+			if rowErr != nil {
+				return Record{}, start, &RowError{Row: start, Err: rowErr}
+			}
+			return rec, start, nil
+		case inTodo:
+			if err := d.set(&rec, name, value); err != nil && rowErr == nil {
+				rowErr = err
+			}
+		}
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return Record{}, d.row, err
+	}
+	if inTodo {
+		return Record{}, start, &RowError{Row: start, Err: errors.New("VTODO is not terminated")}
+	}
+	return Record{}, d.row, io.EOF
+}
+
+func (d *icalDecoder) set(rec *Record, name, value string) error {
+	if target, ok := d.mapping[name]; ok {
+		return rec.set(target, icalUnescape(value))
+	}
+
+	switch name {
+	case "SUMMARY":
+		rec.Title = icalUnescape(value)
+	case "DESCRIPTION":
+		rec.Description = icalUnescape(value)
+	case "STATUS":
+		rec.Completed = strings.EqualFold(value, "COMPLETED")
+	case "COMPLETED":
+		if _, err := time.Parse(icalTimeLayout, value); err != nil {
+			return fmt.Errorf("COMPLETED: %q is not a UTC date-time", value)
+		}
+		rec.Completed = true
+	}
+	return nil
+}
+
+// readLine returns the next unfolded content line and the number of the
+// physical line it starts on.
+func (d *icalDecoder) readLine() (string, int, bool) {
+	var (
+		line string
+		row  int
+	)
+	if d.hasNext {
+		line, row, d.hasNext = d.next, d.nextRow, false
+	} else {
+		if !d.scanner.Scan() {
+			return "", 0, false
+		}
+		d.row++
+		line, row = strings.TrimRight(d.scanner.Text(), "\r"), d.row
+	}
+
+	for d.scanner.Scan() {
+		d.row++
+		next := strings.TrimRight(d.scanner.Text(), "\r")
+		if next != "" && (next[0] == ' ' || next[0] == '\t') {
+			line += next[1:]
+			continue
+		}
+		d.next, d.nextRow, d.hasNext = next, d.row, true
+		break
+	}
+	return line, row, true
+}
+
+// parseICalLine splits "NAME;PARAM=x:VALUE" into the upper-cased name and
+// the raw value. Parameters are ignored.
+func parseICalLine(line string) (string, string, error) {
+	colon := -1
+	quoted := false
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return "", "", fmt.Errorf("content line %q has no value", line)
+	}
+
+	name, _, _ := strings.Cut(line[:colon], ";")
+	return strings.ToUpper(name), line[colon+1:], nil
+}
@@ -0,0 +1,86 @@
+package taskio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+func TestICalEncoderFoldsAndEscapes(t *testing.T) {
+	title := strings.Repeat("Задача, с запятой; ", 8)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	enc := newICalEncoder(&buf)
+	if err := enc.Encode(&model.Model{ID: 7, Title: title, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > icalLineLimit {
+			t.Errorf("line longer than %d octets: %q", icalLineLimit, line)
+		}
+	}
+	if !strings.Contains(buf.String(), "UID:task-7@todo-grpc\r\n") {
+		t.Errorf("missing UID in %q", buf.String())
+	}
+
+	rec, _, err := newICalDecoder(&buf, nil).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Title != title {
+		t.Errorf("title did not round-trip: got %q", rec.Title)
+	}
+}
+
+func TestICalDecoderForeignCalendar(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Not a task",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		`SUMMARY;LANGUAGE=en:Pay "rent"`,
+		"X-NOTES:first\\nsecond",
+		"COMPLETED:20240501T100000Z",
+		"DUE;VALUE=DATE:20240601",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"SUMMARY:Broken",
+		"COMPLETED:yesterday",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	dec, err := NewDecoder(ICalendar, strings.NewReader(input), map[string]string{"x-notes": FieldDescription})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, row, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row != 5 || rec.Title != `Pay "rent"` || rec.Description != "first\nsecond" || !rec.Completed {
+		t.Errorf("unexpected record on row %d: %+v", row, rec)
+	}
+
+	_, row, err = dec.Decode()
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || row != 11 {
+		t.Errorf("expected row error on row 11, got row %d, err %v", row, err)
+	}
+
+	if _, _, err := dec.Decode(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
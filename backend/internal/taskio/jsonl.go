@@ -0,0 +1,100 @@
+package taskio
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+const maxLineSize = 1 << 20
+
+type jsonTask struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Completed   bool      `json:"completed"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type jsonEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+	loc *time.Location
+}
+
+func newJSONEncoder(w io.Writer, loc *time.Location) *jsonEncoder {
+	bw := bufio.NewWriter(w)
+	return &jsonEncoder{w: bw, enc: json.NewEncoder(bw), loc: loc}
+}
+
+func (e *jsonEncoder) Encode(task *model.Model) error {
+	return e.enc.Encode(jsonTask{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: description(task),
+		Completed:   task.Completed,
+		CreatedAt:   task.CreatedAt.In(e.loc),
+		UpdatedAt:   task.UpdatedAt.In(e.loc),
+	})
+}
+
+func (e *jsonEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+type jsonDecoder struct {
+	scanner *bufio.Scanner
+	mapping map[string]string
+	row     int
+}
+
+func newJSONDecoder(r io.Reader, mapping map[string]string) *jsonDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &jsonDecoder{scanner: s, mapping: mapping}
+}
+
+func (d *jsonDecoder) Decode() (Record, int, error) {
+	for d.scanner.Scan() {
+		d.row++
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var fields map[string]any
+		if err := json.Unmarshal(line, &fields); err != nil {
+			return Record{}, d.row, &RowError{Row: d.row, Err: err}
+		}
+
+		var rec Record
+		for key, value := range fields {
+			var text string
+			switch v := value.(type) {
+			case string:
+				text = v
+			case bool:
+				text = strconv.FormatBool(v)
+			case nil:
+				continue
+			default:
+				text = fmt.Sprint(v)
+			}
+			if err := rec.set(targetField(d.mapping, key), text); err != nil {
+				return Record{}, d.row, &RowError{Row: d.row, Err: err}
+			}
+		}
+		return rec, d.row, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return Record{}, d.row, err
+	}
+	return Record{}, d.row, io.EOF
+}
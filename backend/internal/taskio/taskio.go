@@ -0,0 +1,144 @@
+package taskio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+type Format int
+
+const (
+	JSONLines Format = iota + 1
+	CSV
+	TodoTxt
+	ICalendar
+)
+
+var ErrUnknownFormat = errors.New("unknown task format")
+
+// Task fields that imports can map source fields onto.
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldCompleted   = "completed"
+)
+
+// Record is one decoded task.
+type Record struct {
+	Title       string
+	Description string
+	Completed   bool
+}
+
+// RowError reports a row that could not be decoded. Decoding continues
+// with the next row.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type Encoder interface {
+	Encode(task *model.Model) error
+	Flush() error
+}
+
+// Decoder returns records in file order. Besides io.EOF at the end, it
+// returns *RowError for rows it had to skip.
+type Decoder interface {
+	Decode() (Record, int, error)
+}
+
+func NewEncoder(f Format, w io.Writer) (Encoder, error) {
+	return NewEncoderIn(f, w, time.UTC)
+}
+
+// NewEncoderIn is NewEncoder with timestamps rendered in loc. iCalendar
+// always uses UTC.
+func NewEncoderIn(f Format, w io.Writer, loc *time.Location) (Encoder, error) {
+	switch f {
+	case JSONLines:
+		return newJSONEncoder(w, loc), nil
+	case CSV:
+		return newCSVEncoder(w, loc), nil
+	case TodoTxt:
+		return newTodoTxtEncoder(w, loc), nil
+	case ICalendar:
+		return newICalEncoder(w), nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// NewDecoder reads records in the given format. mapping renames source
+// fields to task fields; unmapped source fields keep their own names.
+// For iCalendar the source fields are property names. todo.txt has a
+// fixed layout and ignores the mapping.
+func NewDecoder(f Format, r io.Reader, mapping map[string]string) (Decoder, error) {
+	for _, target := range mapping {
+		switch target {
+		case FieldTitle, FieldDescription, FieldCompleted:
+		default:
+			return nil, fmt.Errorf("field mapping: unknown task field %q", target)
+		}
+	}
+
+	switch f {
+	case JSONLines:
+		return newJSONDecoder(r, mapping), nil
+	case CSV:
+		return newCSVDecoder(r, mapping), nil
+	case TodoTxt:
+		return newTodoTxtDecoder(r), nil
+	case ICalendar:
+		return newICalDecoder(r, mapping), nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+func targetField(mapping map[string]string, source string) string {
+	if target, ok := mapping[source]; ok {
+		return target
+	}
+	return source
+}
+
+// set assigns a textual source value to the mapped task field. Unknown
+// fields are ignored.
+func (rec *Record) set(field, value string) error {
+	switch field {
+	case FieldTitle:
+		rec.Title = value
+	case FieldDescription:
+		rec.Description = value
+	case FieldCompleted:
+		if value == "" {
+			return nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("completed: %q is not a boolean", value)
+		}
+		rec.Completed = b
+	}
+	return nil
+}
+
+func description(task *model.Model) string {
+	if task.Description == nil {
+		return ""
+	}
+	return *task.Description
+}
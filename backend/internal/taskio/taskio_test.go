@@ -0,0 +1,86 @@
+package taskio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+func TestRoundTrip(t *testing.T) {
+	desc := "line one\nline two, \"quoted\""
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tasks := []*model.Model{
+		{ID: 1, Title: "Buy milk", Description: &desc, Completed: true, CreatedAt: now, UpdatedAt: now},
+		{ID: 2, Title: "Call mom", CreatedAt: now, UpdatedAt: now},
+	}
+
+	for _, f := range []Format{JSONLines, CSV, TodoTxt, ICalendar} {
+		var buf bytes.Buffer
+		enc, err := NewEncoder(f, &buf)
+		if err != nil {
+			t.Fatalf("format %d: NewEncoder: %v", f, err)
+		}
+		for _, task := range tasks {
+			if err := enc.Encode(task); err != nil {
+				t.Fatalf("format %d: Encode: %v", f, err)
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			t.Fatalf("format %d: Flush: %v", f, err)
+		}
+
+		dec, err := NewDecoder(f, &buf, nil)
+		if err != nil {
+			t.Fatalf("format %d: NewDecoder: %v", f, err)
+		}
+		for _, want := range tasks {
+			rec, _, err := dec.Decode()
+			if err != nil {
+				t.Fatalf("format %d: Decode: %v", f, err)
+			}
+			if rec.Title != want.Title || rec.Description != description(want) || rec.Completed != want.Completed {
+				t.Errorf("format %d: got %+v, want %+v", f, rec, want)
+			}
+		}
+		if _, _, err := dec.Decode(); !errors.Is(err, io.EOF) {
+			t.Errorf("format %d: expected io.EOF, got %v", f, err)
+		}
+	}
+}
+
+func TestDecodeFieldMappingAndRowErrors(t *testing.T) {
+	input := "name,notes,done\nFirst,a,yes\nSecond,b,true\n"
+	dec, err := NewDecoder(CSV, strings.NewReader(input), map[string]string{
+		"name":  FieldTitle,
+		"notes": FieldDescription,
+		"done":  FieldCompleted,
+	})
+	if err != nil {
+		t.Fatalf("NewDecoder: %v", err)
+	}
+
+	_, row, err := dec.Decode()
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || row != 2 {
+		t.Fatalf("expected row error on row 2, got row %d, err %v", row, err)
+	}
+
+	rec, row, err := dec.Decode()
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if row != 3 || rec.Title != "Second" || rec.Description != "b" || !rec.Completed {
+		t.Errorf("unexpected record on row %d: %+v", row, rec)
+	}
+}
+
+func TestNewDecoderUnknownTarget(t *testing.T) {
+	if _, err := NewDecoder(JSONLines, strings.NewReader(""), map[string]string{"a": "owner"}); err == nil {
+		t.Error("expected error for unknown mapping target")
+	}
+}
@@ -0,0 +1,117 @@
+package taskio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+// todo.txt has no description field; it travels as a path-escaped
+// "desc:" tag so it survives a round trip.
+const descriptionTag = "desc:"
+
+var todoTxtDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+type todoTxtEncoder struct {
+	w   *bufio.Writer
+	loc *time.Location
+}
+
+func newTodoTxtEncoder(w io.Writer, loc *time.Location) *todoTxtEncoder {
+	return &todoTxtEncoder{w: bufio.NewWriter(w), loc: loc}
+}
+
+// Encode writes "[x <completion date>] <creation date> <title> [desc:...]".
+func (e *todoTxtEncoder) Encode(task *model.Model) error {
+	var sb strings.Builder
+	if task.Completed {
+		fmt.Fprintf(&sb, "x %s ", task.UpdatedAt.In(e.loc).Format("2006-01-02"))
+	}
+	sb.WriteString(task.CreatedAt.In(e.loc).Format("2006-01-02"))
+	sb.WriteByte(' ')
+	sb.WriteString(strings.Join(strings.Fields(task.Title), " "))
+	if desc := description(task); desc != "" {
+		sb.WriteString(" " + descriptionTag + url.PathEscape(desc))
+	}
+	sb.WriteByte('\n')
+
+	_, err := e.w.WriteString(sb.String())
+	return err
+}
+
+func (e *todoTxtEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+type todoTxtDecoder struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+func newTodoTxtDecoder(r io.Reader) *todoTxtDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &todoTxtDecoder{scanner: s}
+}
+
+func (d *todoTxtDecoder) Decode() (Record, int, error) {
+	for d.scanner.Scan() {
+		d.row++
+		line := strings.TrimSpace(d.scanner.Text())
+		if line == "" {
+			continue
+		}
+		rec, err := parseTodoTxt(line)
+		if err != nil {
+			return Record{}, d.row, &RowError{Row: d.row, Err: err}
+		}
+		return rec, d.row, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return Record{}, d.row, err
+	}
+	return Record{}, d.row, io.EOF
+}
+
+func parseTodoTxt(line string) (Record, error) {
+	var rec Record
+	words := strings.Fields(line)
+
+	if len(words) > 0 && words[0] == "x" {
+		rec.Completed = true
+		words = words[1:]
+		// Completion date, then optional creation date.
+		if len(words) > 0 && todoTxtDate.MatchString(words[0]) {
+			words = words[1:]
+		}
+	}
+	if len(words) > 0 && len(words[0]) == 3 && words[0][0] == '(' && words[0][2] == ')' {
+		words = words[1:] // priority
+	}
+	if len(words) > 0 && todoTxtDate.MatchString(words[0]) {
+		words = words[1:]
+	}
+
+	title := make([]string, 0, len(words))
+	for _, w := range words {
+		if escaped, ok := strings.CutPrefix(w, descriptionTag); ok {
+			desc, err := url.PathUnescape(escaped)
+			if err != nil {
+				return Record{}, fmt.Errorf("description: %w", err)
+			}
+			rec.Description = desc
+			continue
+		}
+		title = append(title, w)
+	}
+	rec.Title = strings.Join(title, " ")
+
+	return rec, nil
+}
@@ -0,0 +1,401 @@
+package tenant
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/db"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+)
+
+var (
+	ErrUnknownTenant = errors.New("tenant does not exist")
+	ErrTenantExists  = errors.New("tenant already exists")
+)
+
+const (
+	defaultMaxOpen     = 64
+	defaultIdleTimeout = 10 * time.Minute
+)
+
+// Manager opens tenant databases on first use and closes the least
+// recently used ones once more than MaxOpen are open or they sit idle.
+// Handles in use by a call are never closed.
+type Manager struct {
+	dir         string
+	maxOpen     int
+	idleTimeout time.Duration
+	dbOptions   db.Options
+	onOpen      func(ctx context.Context)
+	wakeEvery   time.Duration
+	onWake      func(ctx context.Context)
+
+	mu      sync.Mutex
+	handles map[string]*list.Element
+	lru     *list.List // front is most recently used
+}
+
+type handle struct {
+	id       string
+	db       *db.Pool
+	err      error
+	ready    chan struct{}
+	refs     int
+	lastUsed time.Time
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+type Option func(*Manager)
+
+// WithMaxOpen caps the number of idle databases kept open.
+func WithMaxOpen(n int) Option {
+	return func(m *Manager) {
+		if n > 0 {
+			m.maxOpen = n
+		}
+	}
+}
+
+// WithIdleTimeout closes databases unused for d.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(m *Manager) {
+		if d > 0 {
+			m.idleTimeout = d
+		}
+	}
+}
+
+// WithDBOptions sets how tenant databases are opened.
+func WithDBOptions(opts db.Options) Option {
+	return func(m *Manager) { m.dbOptions = opts }
+}
+
+// WithOnOpen runs fn for as long as a tenant database stays open. Its
+// context carries the tenant database and is cancelled before the
+// database is closed, which is how background workers follow tenants.
+func WithOnOpen(fn func(ctx context.Context)) Option {
+	return func(m *Manager) { m.onOpen = fn }
+}
+
+// WithWake runs fn every interval against each created tenant whose
+// database is closed, opening it just for the call. Background work
+// started by WithOnOpen stops when a tenant goes idle; fn catches up on
+// work that came due since, such as retries and scheduled backups. While
+// fn runs the tenant's onOpen workers do not, so the two never overlap.
+func WithWake(interval time.Duration, fn func(ctx context.Context)) Option {
+	return func(m *Manager) {
+		m.wakeEvery, m.onWake = interval, fn
+	}
+}
+
+// NewManager keeps tenant databases as <dir>/<tenant>.db.
+func NewManager(dir string, opts ...Option) (*Manager, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		dir:         dir,
+		maxOpen:     defaultMaxOpen,
+		idleTimeout: defaultIdleTimeout,
+		dbOptions:   db.DefaultOptions(),
+		handles:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// Create provisions the database of tenant id. Tenants only come into
+// existence this way; Acquire never creates one.
+func (m *Manager) Create(id string) error {
+	if err := Validate(id); err != nil {
+		return err
+	}
+	path := m.path(id)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return ErrTenantExists
+	}
+	if err != nil {
+		return err
+	}
+	f.Close()
+
+	p, err := db.Open(path, m.dbOptions)
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return p.Close()
+}
+
+// Acquire returns the database of tenant id, opening and migrating it if
+// needed. It fails with ErrUnknownTenant unless the tenant was created.
+// The caller must call release once it no longer uses the handle.
+func (m *Manager) Acquire(ctx context.Context, id string) (*db.Pool, func(), error) {
+	if err := Validate(id); err != nil {
+		return nil, nil, err
+	}
+
+	m.mu.Lock()
+	el, ok := m.handles[id]
+	if !ok {
+		el = m.lru.PushFront(&handle{id: id, ready: make(chan struct{})})
+		m.handles[id] = el
+	} else {
+		m.lru.MoveToFront(el)
+	}
+	h := el.Value.(*handle)
+	h.refs++
+	m.mu.Unlock()
+
+	if !ok {
+		m.openHandle(h, true)
+	}
+
+	select {
+	case <-h.ready:
+	case <-ctx.Done():
+		m.release(h)
+		return nil, nil, ctx.Err()
+	}
+	if h.err != nil {
+		m.release(h)
+		return nil, nil, h.err
+	}
+
+	var once sync.Once
+	return h.db, func() { once.Do(func() { m.release(h) }) }, nil
+}
+
+// openHandle opens the database of h and, with startWorkers, runs onOpen
+// for as long as it stays open.
+func (m *Manager) openHandle(h *handle, startWorkers bool) {
+	defer close(h.ready)
+
+	if _, err := os.Stat(m.path(h.id)); errors.Is(err, fs.ErrNotExist) {
+		h.err = ErrUnknownTenant
+	} else {
+		h.db, h.err = db.Open(m.path(h.id), m.dbOptions)
+	}
+	if h.err != nil {
+		if !errors.Is(h.err, ErrUnknownTenant) {
+			log.L().Errorf("Failed to open database of tenant %s: %v", h.id, h.err)
+		}
+		m.mu.Lock()
+		m.remove(h)
+		m.mu.Unlock()
+		return
+	}
+	log.L().Infof("Opened database of tenant %s", h.id)
+
+	if startWorkers {
+		m.startWorkers(h)
+	}
+}
+
+func (m *Manager) startWorkers(h *handle) {
+	if m.onOpen == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(WithDB(context.Background(), h.id, h.db))
+	h.cancel = cancel
+	h.done.Add(1)
+	go func() {
+		defer h.done.Done()
+		m.onOpen(ctx)
+	}()
+}
+
+func (m *Manager) path(id string) string {
+	return filepath.Join(m.dir, id+".db")
+}
+
+func (m *Manager) release(h *handle) {
+	m.mu.Lock()
+	h.refs--
+	h.lastUsed = time.Now()
+	evicted := m.evict(time.Time{})
+	m.mu.Unlock()
+
+	m.closeAll(evicted)
+}
+
+// evict removes unused handles beyond maxOpen, least recently used first,
+// and those last used before idleSince. m.mu must be held.
+func (m *Manager) evict(idleSince time.Time) []*handle {
+	var evicted []*handle
+	excess := m.lru.Len() - m.maxOpen
+	for el := m.lru.Back(); el != nil; {
+		h := el.Value.(*handle)
+		el = el.Prev()
+		if h.refs > 0 || !isReady(h) {
+			continue
+		}
+		if excess > 0 || h.lastUsed.Before(idleSince) {
+			m.remove(h)
+			evicted = append(evicted, h)
+			excess--
+		}
+	}
+	return evicted
+}
+
+// remove forgets h if it is still the handle of its tenant. m.mu must be
+// held.
+func (m *Manager) remove(h *handle) {
+	if el, ok := m.handles[h.id]; ok && el.Value == h {
+		m.lru.Remove(el)
+		delete(m.handles, h.id)
+	}
+}
+
+func (m *Manager) closeAll(handles []*handle) {
+	for _, h := range handles {
+		if h.cancel != nil {
+			h.cancel()
+		}
+		h.done.Wait()
+		if err := h.db.Close(); err != nil {
+			log.L().Errorf("Failed to close database of tenant %s: %v", h.id, err)
+			continue
+		}
+		log.L().Infof("Closed database of tenant %s", h.id)
+	}
+}
+
+func isReady(h *handle) bool {
+	select {
+	case <-h.ready:
+		return h.err == nil
+	default:
+		return false
+	}
+}
+
+// Open lists the tenants whose databases are open, most recently used
+// first.
+func (m *Manager) Open() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]string, 0, m.lru.Len())
+	for el := m.lru.Front(); el != nil; el = el.Next() {
+		ids = append(ids, el.Value.(*handle).id)
+	}
+	return ids
+}
+
+// Created lists the tenants that have a database, whether open or not.
+func (m *Manager) Created() ([]string, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".db")
+		if ok && e.Type().IsRegular() && Validate(id) == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// Run closes idle databases, and wakes closed ones if WithWake is set,
+// until ctx is cancelled.
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.idleTimeout / 2)
+	defer ticker.Stop()
+
+	var wake <-chan time.Time
+	if m.onWake != nil && m.wakeEvery > 0 {
+		wakeTicker := time.NewTicker(m.wakeEvery)
+		defer wakeTicker.Stop()
+		wake = wakeTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			evicted := m.evict(time.Now().Add(-m.idleTimeout))
+			m.mu.Unlock()
+			m.closeAll(evicted)
+		case <-wake:
+			m.wakeAll(ctx)
+		}
+	}
+}
+
+// wakeAll runs onWake against every created tenant that is closed.
+func (m *Manager) wakeAll(ctx context.Context) {
+	ids, err := m.Created()
+	if err != nil {
+		log.L().Errorf("Failed to list tenants: %v", err)
+		return
+	}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		m.wake(id)
+	}
+}
+
+// wake opens the database of tenant id if it is closed, runs onWake
+// against it and closes it again. Calls that acquire the tenant meanwhile
+// share the database; its onOpen workers start once onWake returns.
+func (m *Manager) wake(id string) {
+	m.mu.Lock()
+	if _, ok := m.handles[id]; ok {
+		m.mu.Unlock()
+		return
+	}
+	h := &handle{id: id, ready: make(chan struct{}), refs: 1}
+	m.handles[id] = m.lru.PushBack(h)
+	m.mu.Unlock()
+
+	m.openHandle(h, false)
+	if h.err != nil {
+		return
+	}
+	m.onWake(WithDB(context.Background(), id, h.db))
+
+	m.mu.Lock()
+	h.refs--
+	var evicted []*handle
+	if h.refs == 0 {
+		m.remove(h)
+		evicted = []*handle{h}
+	} else {
+		m.startWorkers(h)
+	}
+	h.lastUsed = time.Now()
+	evicted = append(evicted, m.evict(time.Time{})...)
+	m.mu.Unlock()
+
+	m.closeAll(evicted)
+}
+
+// Close closes every open database that is not in use.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	evicted := m.evict(time.Now().Add(time.Hour))
+	m.mu.Unlock()
+	m.closeAll(evicted)
+}
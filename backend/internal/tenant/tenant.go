@@ -0,0 +1,94 @@
+// Package tenant isolates customers by giving each tenant its own SQLite
+// database file.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+	"github.com/Elmar006/todo_grpc/internal/db"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the metadata key carrying the tenant of a call.
+const Header = "x-tenant-id"
+
+var (
+	ErrUnauthenticated = errors.New("authentication required to choose a tenant")
+	ErrNoTenant        = errors.New("caller is not assigned to a tenant")
+	ErrInvalidTenant   = errors.New("invalid tenant id")
+	ErrWrongTenant     = errors.New("caller belongs to another tenant")
+)
+
+// validID keeps tenant ids usable as file names.
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Validate reports whether id is a well-formed tenant id.
+func Validate(id string) error {
+	if !validID.MatchString(id) {
+		return ErrInvalidTenant
+	}
+	return nil
+}
+
+type idKey struct{}
+type dbKey struct{}
+
+// WithDB attaches the tenant and its database to ctx.
+func WithDB(ctx context.Context, id string, p *db.Pool) context.Context {
+	ctx = context.WithValue(ctx, idKey{}, id)
+	return context.WithValue(ctx, dbKey{}, p)
+}
+
+// IDFrom returns the tenant of ctx, if any.
+func IDFrom(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(idKey{}).(string)
+	return id, ok && id != ""
+}
+
+// DBFrom returns the tenant database attached to ctx, if any.
+func DBFrom(ctx context.Context) (*db.Pool, bool) {
+	p, ok := ctx.Value(dbKey{}).(*db.Pool)
+	return p, ok && p != nil
+}
+
+// Resolver picks the tenant of a call from the tenant its principal is
+// bound to. Anonymous and unbound callers get no tenant, so the only
+// tenants anyone can reach are those of TENANT_USERS.
+type Resolver struct {
+	users map[string]string
+}
+
+// NewResolver binds principals to tenants, e.g. {"alice": "acme"}.
+func NewResolver(users map[string]string) (*Resolver, error) {
+	for user, id := range users {
+		if err := Validate(id); err != nil {
+			return nil, fmt.Errorf("tenant of %q: %w", user, err)
+		}
+	}
+	return &Resolver{users: users}, nil
+}
+
+// Resolve returns the tenant of the principal in ctx. The x-tenant-id
+// header is optional; one naming a different tenant is rejected.
+func (r *Resolver) Resolve(ctx context.Context) (string, error) {
+	principal, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	id, ok := r.users[principal]
+	if !ok {
+		return "", ErrNoTenant
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(Header); len(values) > 0 && values[0] != id {
+			return "", ErrWrongTenant
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,278 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/auth"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestResolve(t *testing.T) {
+	r, err := NewResolver(map[string]string{"alice": "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		principal string
+		header    string
+		want      string
+		err       error
+	}{
+		{name: "bound principal", principal: "alice", want: "acme"},
+		{name: "bound principal, same header", principal: "alice", header: "acme", want: "acme"},
+		{name: "bound principal, other header", principal: "alice", header: "globex", err: ErrWrongTenant},
+		{name: "unbound principal", principal: "bob", header: "globex", err: ErrNoTenant},
+		{name: "unbound principal, no header", principal: "bob", err: ErrNoTenant},
+		{name: "anonymous", header: "globex", err: ErrUnauthenticated},
+		{name: "anonymous, no header", err: ErrUnauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != "" {
+				ctx = auth.WithPrincipal(ctx, tt.principal)
+			}
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(Header, tt.header))
+			}
+
+			got, err := r.Resolve(ctx)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("tenant = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewResolver(map[string]string{"alice": "Acme/1"}); !errors.Is(err, ErrInvalidTenant) {
+		t.Errorf("expected invalid tenant id to be rejected, got %v", err)
+	}
+}
+
+func TestManagerIsolatesTenants(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewManager(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	ctx := context.Background()
+	createTenants(t, m, "acme", "globex")
+
+	acme, release, err := m.Acquire(ctx, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := acme.Writer.ExecContext(ctx, `INSERT INTO task (title, description, owner) VALUES ('a', '', '')`); err != nil {
+		t.Fatalf("expected migrated schema: %v", err)
+	}
+	release()
+
+	globex, release, err := m.Acquire(ctx, "globex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	var n int
+	if err := globex.Reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM task`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("expected globex to see none of acme's tasks, got %d", n)
+	}
+
+	for _, id := range []string{"acme", "globex"} {
+		if _, err := os.Stat(filepath.Join(dir, id+".db")); err != nil {
+			t.Errorf("expected database file of %s: %v", id, err)
+		}
+	}
+}
+
+func TestManagerOpensCreatedTenantsOnly(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewManager(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	ctx := context.Background()
+
+	if _, _, err := m.Acquire(ctx, "acme"); !errors.Is(err, ErrUnknownTenant) {
+		t.Fatalf("expected ErrUnknownTenant before the tenant is created, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "acme.db")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected no database file for an unknown tenant, got %v", err)
+	}
+	if err := m.Create("../acme"); !errors.Is(err, ErrInvalidTenant) {
+		t.Errorf("expected ErrInvalidTenant, got %v", err)
+	}
+
+	createTenants(t, m, "acme")
+	if err := m.Create("acme"); !errors.Is(err, ErrTenantExists) {
+		t.Errorf("expected ErrTenantExists, got %v", err)
+	}
+	_, release, err := m.Acquire(ctx, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func createTenants(t *testing.T, m *Manager, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		if err := m.Create(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestManagerEvictsLeastRecentlyUsed(t *testing.T) {
+	stopped := make(chan string, 4)
+	m, err := NewManager(t.TempDir(), WithMaxOpen(2), WithOnOpen(func(ctx context.Context) {
+		<-ctx.Done()
+		id, _ := IDFrom(ctx)
+		stopped <- id
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	ctx := context.Background()
+	createTenants(t, m, "a", "b", "c")
+
+	_, releaseA, err := m.Acquire(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"b", "c"} {
+		_, release, err := m.Acquire(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+
+	// "a" is the oldest but still in use, so "b" goes instead.
+	if got := m.Open(); !slices.Equal(got, []string{"c", "a"}) {
+		t.Fatalf("open = %v, want [c a]", got)
+	}
+	if id := <-stopped; id != "b" {
+		t.Errorf("expected workers of b to stop, got %s", id)
+	}
+
+	releaseA()
+	if got := m.Open(); !slices.Equal(got, []string{"c", "a"}) {
+		t.Errorf("open = %v, want [c a]", got)
+	}
+}
+
+func TestManagerClosesIdle(t *testing.T) {
+	m, err := NewManager(t.TempDir(), WithIdleTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	createTenants(t, m, "acme")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Run(ctx)
+
+	_, release, err := m.Acquire(ctx, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(m.Open()) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected idle database to be closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	db, release, err := m.Acquire(ctx, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if err := db.Reader.PingContext(ctx); err != nil {
+		t.Errorf("expected database to reopen: %v", err)
+	}
+}
+
+func TestManagerWakesClosedTenants(t *testing.T) {
+	woken := make(chan string, 4)
+	started := make(chan string, 4)
+	m, err := NewManager(t.TempDir(),
+		WithOnOpen(func(ctx context.Context) {
+			id, _ := IDFrom(ctx)
+			started <- id
+			<-ctx.Done()
+		}),
+		WithWake(10*time.Millisecond, func(ctx context.Context) {
+			id, _ := IDFrom(ctx)
+			if _, ok := DBFrom(ctx); !ok {
+				t.Errorf("expected the database of %s in the wake context", id)
+			}
+			select {
+			case woken <- id:
+			default:
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	createTenants(t, m, "open", "closed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, release, err := m.Acquire(ctx, "open")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if id := <-started; id != "open" {
+		t.Fatalf("expected workers of open to start, got %s", id)
+	}
+	go m.Run(ctx)
+
+	select {
+	case id := <-woken:
+		if id != "closed" {
+			t.Fatalf("expected only the closed tenant to be woken, got %s", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the closed tenant to be woken")
+	}
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !slices.Equal(m.Open(), []string{"open"}) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the woken tenant to be closed again, open = %v", m.Open())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case id := <-started:
+		t.Errorf("expected no workers for the woken tenant, got %s", id)
+	default:
+	}
+}
@@ -0,0 +1,47 @@
+// Package timezone resolves the IANA time zone a client wants timestamps
+// rendered in. Timestamps are stored and sent over gRPC in UTC; only
+// textual renderings such as exports and the REST gateway use the zone.
+package timezone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	// Embeds the zone database so names resolve on hosts without one.
+	_ "time/tzdata"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the metadata key carrying the time zone of a call.
+const Header = "x-timezone"
+
+var ErrUnknownZone = errors.New("unknown time zone")
+
+// Load returns the location named by an IANA time zone name such as
+// "Europe/Berlin". An empty name means UTC. "Local" is rejected because it
+// depends on the server.
+func Load(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("%w %q", ErrUnknownZone, name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownZone, name)
+	}
+	return loc, nil
+}
+
+// FromIncoming returns the location requested in the incoming metadata of
+// ctx, or UTC if there is none.
+func FromIncoming(ctx context.Context) (*time.Location, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(Header); len(values) > 0 {
+		return Load(values[0])
+	}
+	return time.UTC, nil
+}
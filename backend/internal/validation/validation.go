@@ -0,0 +1,445 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrInvalidData = errors.New("invalid data")
+
+type Limits struct {
+	TitleMaxLength       int
+	DescriptionMaxLength int
+	FilterMaxLength      int
+	CommentMaxLength     int
+}
+
+func DefaultLimits() Limits {
+	return Limits{
+		TitleMaxLength:       200,
+		DescriptionMaxLength: 10000,
+		FilterMaxLength:      200,
+		CommentMaxLength:     5000,
+	}
+}
+
+type Violation struct {
+	Field       string
+	Description string
+}
+
+// Error carries every violation found in a request, not just the first one.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Description
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidData, strings.Join(parts, "; "))
+}
+
+func (e *Error) Unwrap() error {
+	return ErrInvalidData
+}
+
+// GRPCStatus lets status.FromError turn the error into InvalidArgument
+// with a BadRequest detail listing each failing field.
+func (e *Error) GRPCStatus() *status.Status {
+	br := &errdetails.BadRequest{}
+	for _, v := range e.Violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, e.Error())
+	if withDetails, err := st.WithDetails(br); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// Rule checks a single field value and returns a violation description,
+// or an empty string when the value is acceptable.
+type Rule func(fd protoreflect.FieldDescriptor, v protoreflect.Value) string
+
+type fieldRules struct {
+	name  protoreflect.Name
+	rules []Rule
+	// each applies the rules to every element of a repeated field.
+	each bool
+}
+
+func field(name protoreflect.Name, rules ...Rule) fieldRules {
+	return fieldRules{name: name, rules: rules}
+}
+
+// each applies rules to every element of a repeated field; violations
+// are reported as "field[i]".
+func each(name protoreflect.Name, rules ...Rule) fieldRules {
+	return fieldRules{name: name, rules: rules, each: true}
+}
+
+func Required() Rule {
+	return func(_ protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		if strings.TrimSpace(v.String()) == "" {
+			return "must not be empty"
+		}
+		return ""
+	}
+}
+
+func Trimmed() Rule {
+	return func(_ protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		s := v.String()
+		if s != strings.TrimSpace(s) {
+			return "must not have leading or trailing whitespace"
+		}
+		return ""
+	}
+}
+
+func MaxLength(n int) Rule {
+	return func(_ protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		if n > 0 && utf8.RuneCountInString(v.String()) > n {
+			return fmt.Sprintf("must be at most %d characters", n)
+		}
+		return ""
+	}
+}
+
+func PositiveID() Rule {
+	return func(_ protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		if v.Int() <= 0 {
+			return "must be greater than 0"
+		}
+		return ""
+	}
+}
+
+// DefinedEnum rejects enum numbers that are not declared in the proto,
+// including the zero UNSPECIFIED value.
+func DefinedEnum() Rule {
+	return func(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		n := v.Enum()
+		if n == 0 || fd.Enum().Values().ByNumber(n) == nil {
+			return fmt.Sprintf("must be one of the defined %s values", fd.Enum().Name())
+		}
+		return ""
+	}
+}
+
+// KnownEnum rejects enum numbers that are not declared in the proto but,
+// unlike DefinedEnum, accepts the zero value.
+func KnownEnum() Rule {
+	return func(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		if fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			return fmt.Sprintf("must be one of the defined %s values", fd.Enum().Name())
+		}
+		return ""
+	}
+}
+
+// MinItems requires a repeated field to have at least n elements.
+func MinItems(n int) Rule {
+	return func(_ protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		if v.List().Len() < n {
+			return fmt.Sprintf("must have at least %d items", n)
+		}
+		return ""
+	}
+}
+
+// MaxItems limits a repeated field to n elements.
+func MaxItems(n int) Rule {
+	return func(_ protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		if v.List().Len() > n {
+			return fmt.Sprintf("must have at most %d items", n)
+		}
+		return ""
+	}
+}
+
+// SHA256Hex accepts an empty value or a hex-encoded SHA-256 digest.
+func SHA256Hex() Rule {
+	return func(_ protoreflect.FieldDescriptor, v protoreflect.Value) string {
+		s := v.String()
+		if s == "" {
+			return ""
+		}
+		if len(s) != 64 || strings.Trim(s, "0123456789abcdefABCDEF") != "" {
+			return "must be a hex-encoded SHA-256 digest"
+		}
+		return ""
+	}
+}
+
+type Validator struct {
+	limits Limits
+	rules  map[protoreflect.FullName][]fieldRules
+}
+
+func New(limits Limits) *Validator {
+	v := &Validator{
+		limits: limits,
+		rules:  make(map[protoreflect.FullName][]fieldRules),
+	}
+
+	v.register(&todo.CreateTaskRequest{},
+		field("title", v.titleRules()...),
+		field("description", v.descriptionRules()...),
+	)
+	v.register(&todo.GetTaskRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.ListTasksRequest{},
+		field("filter", MaxLength(limits.FilterMaxLength)),
+		field("assignee_filter", KnownEnum()),
+	)
+	v.register(&todo.UpdateTaskRequest{},
+		field("id", PositiveID()),
+		field("title", v.titleRules()...),
+		field("description", v.descriptionRules()...),
+	)
+	v.register(&todo.DeleteTaskRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.ExportTasksRequest{},
+		field("format", DefinedEnum()),
+		field("filter", MaxLength(limits.FilterMaxLength)),
+	)
+	v.register(&todo.CreateWebhookRequest{},
+		field("url", Required(), Trimmed(), MaxLength(2048)),
+		field("secret", MaxLength(256)),
+	)
+	v.register(&todo.DeleteWebhookRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.ListWebhookDeliveriesRequest{},
+		field("webhook_id", PositiveID()),
+	)
+	v.register(&todo.RetryWebhookDeliveryRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.GetTaskHistoryRequest{},
+		field("task_id", PositiveID()),
+		field("page_token", MaxLength(64)),
+	)
+	v.register(&todo.ListTaskChangesRequest{},
+		field("page_token", MaxLength(64)),
+	)
+	v.register(&todo.ListTaskRevisionsRequest{},
+		field("task_id", PositiveID()),
+	)
+	v.register(&todo.RevertTaskRequest{},
+		field("task_id", PositiveID()),
+		field("revision", PositiveID()),
+	)
+	v.register(&todo.RestoreTaskRequest{},
+		field("task_id", PositiveID()),
+	)
+	v.register(&todo.AddCommentRequest{},
+		field("task_id", PositiveID()),
+		field("body", v.commentRules()...),
+	)
+	v.register(&todo.ListCommentsRequest{},
+		field("task_id", PositiveID()),
+		field("page_token", MaxLength(64)),
+	)
+	v.register(&todo.EditCommentRequest{},
+		field("id", PositiveID()),
+		field("body", v.commentRules()...),
+	)
+	v.register(&todo.DeleteCommentRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.AttachmentInfo{},
+		field("task_id", PositiveID()),
+		field("filename", Required(), Trimmed(), MaxLength(255)),
+		field("content_type", Required(), MaxLength(255)),
+		field("sha256", SHA256Hex()),
+	)
+	v.register(&todo.DownloadAttachmentRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.ListAttachmentsRequest{},
+		field("task_id", PositiveID()),
+	)
+	v.register(&todo.DeleteAttachmentRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.CreateWorkspaceRequest{},
+		field("name", Required(), Trimmed(), MaxLength(100)),
+	)
+	v.register(&todo.ListWorkspaceMembersRequest{},
+		field("workspace_id", PositiveID()),
+	)
+	v.register(&todo.SetWorkspaceMemberRequest{},
+		field("workspace_id", PositiveID()),
+		field("member", userRules()...),
+		field("role", DefinedEnum()),
+	)
+	v.register(&todo.RemoveWorkspaceMemberRequest{},
+		field("workspace_id", PositiveID()),
+		field("member", userRules()...),
+	)
+	v.register(&todo.AssignTaskRequest{},
+		field("task_id", PositiveID()),
+		field("user_ids", MinItems(1), MaxItems(100)),
+		each("user_ids", userRules()...),
+	)
+	v.register(&todo.UnassignTaskRequest{},
+		field("task_id", PositiveID()),
+		field("user_ids", MinItems(1), MaxItems(100)),
+		each("user_ids", userRules()...),
+	)
+	v.register(&todo.ShareTaskRequest{},
+		field("task_id", PositiveID()),
+		field("user", userRules()...),
+		field("role", DefinedEnum()),
+	)
+	v.register(&todo.UnshareTaskRequest{},
+		field("task_id", PositiveID()),
+		field("user", userRules()...),
+	)
+	v.register(&todo.ListTaskSharesRequest{},
+		field("task_id", PositiveID()),
+	)
+	v.register(&todo.CreateShareLinkRequest{},
+		field("task_id", PositiveID()),
+	)
+	v.register(&todo.ListShareLinksRequest{},
+		field("task_id", PositiveID()),
+	)
+	v.register(&todo.RevokeShareLinkRequest{},
+		field("id", PositiveID()),
+	)
+	v.register(&todo.GetSharedTaskRequest{},
+		field("token", Required(), MaxLength(64)),
+	)
+	v.register(&todo.ImportOptions{},
+		field("format", DefinedEnum()),
+		field("on_duplicate", KnownEnum()),
+	)
+
+	return v
+}
+
+func (v *Validator) register(msg proto.Message, fields ...fieldRules) {
+	name := msg.ProtoReflect().Descriptor().FullName()
+	v.rules[name] = append(v.rules[name], fields...)
+}
+
+func (v *Validator) titleRules() []Rule {
+	return []Rule{Required(), Trimmed(), MaxLength(v.limits.TitleMaxLength)}
+}
+
+func (v *Validator) descriptionRules() []Rule {
+	return []Rule{MaxLength(v.limits.DescriptionMaxLength)}
+}
+
+func userRules() []Rule {
+	return []Rule{Required(), Trimmed(), MaxLength(128)}
+}
+
+// commentRules limit the Markdown source of a comment; the body is stored
+// as written and never rendered by the service.
+func (v *Validator) commentRules() []Rule {
+	return []Rule{Required(), MaxLength(v.limits.CommentMaxLength)}
+}
+
+// Validate applies the rules registered for the message type and for any
+// set singular message fields, which are reported as "parent.field".
+// Optional fields that are not set are skipped; messages without rules
+// pass.
+func (v *Validator) Validate(msg proto.Message) error {
+	violations := v.validate("", msg.ProtoReflect())
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+func (v *Validator) validate(prefix string, m protoreflect.Message) []Violation {
+	fields := m.Descriptor().Fields()
+
+	var violations []Violation
+	for _, fr := range v.rules[m.Descriptor().FullName()] {
+		fd := fields.ByName(fr.name)
+		if fd == nil {
+			continue
+		}
+		if fd.HasPresence() && !m.Has(fd) {
+			continue
+		}
+		if fr.each {
+			list := m.Get(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				name := fmt.Sprintf("%s%s[%d]", prefix, fr.name, i)
+				violations = append(violations, check(name, fd, list.Get(i), fr.rules)...)
+			}
+			continue
+		}
+		violations = append(violations, check(prefix+string(fr.name), fd, m.Get(fd), fr.rules)...)
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		violations = append(violations, v.validate(prefix+string(fd.Name())+".", m.Get(fd).Message())...)
+	}
+
+	return violations
+}
+
+// Task validates task fields outside of a request message, so callers
+// that do not go through gRPC apply the same rules.
+func (v *Validator) Task(title string, description *string) error {
+	fields := (&todo.Task{}).ProtoReflect().Descriptor().Fields()
+
+	violations := check("title", fields.ByName("title"), protoreflect.ValueOfString(title), v.titleRules())
+	if description != nil {
+		violations = append(violations, check("description", fields.ByName("description"),
+			protoreflect.ValueOfString(*description), v.descriptionRules())...)
+	}
+
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+// Comment validates a comment body outside of a request message.
+func (v *Validator) Comment(body string) error {
+	fd := (&todo.Comment{}).ProtoReflect().Descriptor().Fields().ByName("body")
+	if violations := check("body", fd, protoreflect.ValueOfString(body), v.commentRules()); len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+func check(name string, fd protoreflect.FieldDescriptor, value protoreflect.Value, rules []Rule) []Violation {
+	var violations []Violation
+	for _, rule := range rules {
+		if desc := rule(fd, value); desc != "" {
+			violations = append(violations, Violation{Field: name, Description: desc})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidate(t *testing.T) {
+	v := New(Limits{TitleMaxLength: 5, DescriptionMaxLength: 5, FilterMaxLength: 5, CommentMaxLength: 5})
+	title := func(s string) *string { return &s }
+
+	tests := []struct {
+		name string
+		msg  proto.Message
+		want []string
+	}{
+		{"valid", &todo.CreateTaskRequest{Title: "Milk"}, nil},
+		{"every violation", &todo.CreateTaskRequest{Title: " ", Description: "too long"}, []string{"title", "title", "description"}},
+		{"multi-byte at the limit", &todo.CreateTaskRequest{Title: "héllo", Description: "日本語です"}, nil},
+		{"multi-byte over the limit", &todo.CreateTaskRequest{Title: "日本語のです"}, []string{"title"}},
+		{"unset optional", &todo.UpdateTaskRequest{Id: 1}, nil},
+		{"set optional", &todo.UpdateTaskRequest{Id: 1, Title: title("")}, []string{"title"}},
+		{"id", &todo.GetTaskRequest{}, []string{"id"}},
+		{"defined enum", &todo.ExportTasksRequest{Format: todo.TaskFormat_TASK_FORMAT_CSV}, nil},
+		{"defined enum unspecified", &todo.ExportTasksRequest{}, []string{"format"}},
+		{"defined enum unknown", &todo.ExportTasksRequest{Format: 99}, []string{"format"}},
+		{"known enum unspecified", &todo.ListTasksRequest{}, nil},
+		{"known enum unknown", &todo.ListTasksRequest{AssigneeFilter: 7}, []string{"assignee_filter"}},
+		{"repeated", &todo.AssignTaskRequest{TaskId: 1, UserIds: []string{"bob", " carol", ""}}, []string{"user_ids[1]", "user_ids[2]"}},
+		{"repeated empty", &todo.AssignTaskRequest{TaskId: 1}, []string{"user_ids"}},
+		{"nested", &todo.UploadAttachmentRequest{Payload: &todo.UploadAttachmentRequest_Info{Info: &todo.AttachmentInfo{
+			Filename: "a.txt", ContentType: "text/plain", Sha256: "abc",
+		}}}, []string{"info.task_id", "info.sha256"}},
+		{"nested enum", &todo.ImportTasksRequest{Payload: &todo.ImportTasksRequest_Options{Options: &todo.ImportOptions{OnDuplicate: 9}}},
+			[]string{"options.format", "options.on_duplicate"}},
+		{"unset nested", &todo.ImportTasksRequest{Payload: &todo.ImportTasksRequest_Data{Data: []byte("{}")}}, nil},
+		{"no rules", &todo.ListWebhooksRequest{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.msg)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var verr *Error
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *Error, got %v", err)
+			}
+			if !errors.Is(err, ErrInvalidData) {
+				t.Errorf("expected ErrInvalidData, got %v", err)
+			}
+			var fields []string
+			for _, viol := range verr.Violations {
+				fields = append(fields, viol.Field)
+			}
+			if !slices.Equal(fields, tt.want) {
+				t.Errorf("expected violations of %v, got %+v", tt.want, verr.Violations)
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	fd := (&todo.CreateTaskRequest{}).ProtoReflect().Descriptor().Fields().ByName("title")
+	format := (&todo.ExportTasksRequest{}).ProtoReflect().Descriptor().Fields().ByName("format")
+	str := func(s string) proto.Message { return &todo.CreateTaskRequest{Title: s} }
+
+	tests := []struct {
+		name string
+		rule Rule
+		msg  proto.Message
+		want string
+	}{
+		{"max length ascii", MaxLength(3), str("abcd"), "must be at most 3 characters"},
+		{"max length counts runes", MaxLength(3), str("äöü"), ""},
+		{"max length emoji", MaxLength(1), str("🙂🙂"), "must be at most 1 characters"},
+		{"max length unlimited", MaxLength(0), str("abcd"), ""},
+		{"defined enum zero", DefinedEnum(), &todo.ExportTasksRequest{}, "must be one of the defined TaskFormat values"},
+		{"defined enum declared", DefinedEnum(), &todo.ExportTasksRequest{Format: todo.TaskFormat_TASK_FORMAT_ICALENDAR}, ""},
+		{"known enum zero", KnownEnum(), &todo.ExportTasksRequest{}, ""},
+		{"known enum undeclared", KnownEnum(), &todo.ExportTasksRequest{Format: 42}, "must be one of the defined TaskFormat values"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fd
+			if _, ok := tt.msg.(*todo.ExportTasksRequest); ok {
+				f = format
+			}
+			if got := tt.rule(f, tt.msg.ProtoReflect().Get(f)); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestErrorGRPCStatus(t *testing.T) {
+	err := &Error{Violations: []Violation{
+		{Field: "title", Description: "must not be empty"},
+		{Field: "user_ids[1]", Description: "must not have leading or trailing whitespace"},
+	}}
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", st)
+	}
+	if st.Message() != err.Error() {
+		t.Errorf("expected message %q, got %q", err.Error(), st.Message())
+	}
+
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one detail, got %v", details)
+	}
+	br, ok := details[0].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("expected BadRequest, got %T", details[0])
+	}
+	if len(br.GetFieldViolations()) != len(err.Violations) {
+		t.Fatalf("expected %d field violations, got %v", len(err.Violations), br.GetFieldViolations())
+	}
+	for i, fv := range br.GetFieldViolations() {
+		if fv.GetField() != err.Violations[i].Field || fv.GetDescription() != err.Violations[i].Description {
+			t.Errorf("expected %+v, got %v", err.Violations[i], fv)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+	"github.com/Elmar006/todo_grpc/internal/model"
+)
+
+const (
+	defaultMaxAttempts  = 8
+	defaultBackoffBase  = 10 * time.Second
+	defaultBackoffMax   = time.Hour
+	defaultPollInterval = time.Second
+	defaultTimeout      = 10 * time.Second
+	batchSize           = 50
+	maxErrorLength      = 512
+)
+
+// DeliveryStore is the outbox the Dispatcher works through.
+type DeliveryStore interface {
+	DueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, d *model.WebhookDelivery, a model.WebhookAttempt) error
+}
+
+// Dispatcher POSTs pending deliveries from the outbox. Failed attempts
+// are retried with exponential backoff; after the last attempt the
+// delivery is marked dead and stays in the log until retried by hand.
+type Dispatcher struct {
+	store        DeliveryStore
+	client       *http.Client
+	maxAttempts  int
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+type Option func(*Dispatcher)
+
+// WithHTTPClient replaces the default client from NewHTTPClient, which
+// refuses to connect to private addresses.
+func WithHTTPClient(c *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = c
+	}
+}
+
+// WithTimeout sets the timeout of a delivery request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.client = NewHTTPClient(timeout)
+	}
+}
+
+func WithMaxAttempts(n int) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the cap the
+// doubling delay never exceeds.
+func WithBackoff(base, max time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.backoffBase = base
+		d.backoffMax = max
+	}
+}
+
+func WithPollInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.pollInterval = interval
+	}
+}
+
+func NewDispatcher(store DeliveryStore, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		store:        store,
+		client:       NewHTTPClient(defaultTimeout),
+		maxAttempts:  defaultMaxAttempts,
+		backoffBase:  defaultBackoffBase,
+		backoffMax:   defaultBackoffMax,
+		pollInterval: defaultPollInterval,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Run dispatches due deliveries until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			log.L().Errorf("webhook dispatch failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue makes one attempt for every delivery that is due and
+// returns how many were attempted.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		due, err := d.store.DueWebhookDeliveries(ctx, d.now(), batchSize)
+		if err != nil {
+			return attempted, err
+		}
+
+		for _, delivery := range due {
+			if err := ctx.Err(); err != nil {
+				return attempted, err
+			}
+			if err := d.attempt(ctx, delivery); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+
+		if len(due) < batchSize {
+			return attempted, nil
+		}
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery *model.WebhookDelivery) error {
+	start := d.now()
+	code, err := d.post(ctx, delivery, start)
+	a := model.WebhookAttempt{
+		AttemptedAt: start,
+		StatusCode:  code,
+		Duration:    d.now().Sub(start),
+	}
+
+	delivery.Attempts++
+	switch {
+	case err == nil:
+		delivery.Status = model.DeliveryDelivered
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = model.DeliveryDead
+	default:
+		delivery.NextAttemptAt = start.Add(d.backoff(delivery.Attempts))
+	}
+
+	if err != nil {
+		a.Error = truncate(err.Error(), maxErrorLength)
+		log.L().Warnf("webhook delivery %d to %s failed (attempt %d, %s): %v",
+			delivery.ID, delivery.URL, delivery.Attempts, delivery.Status, err)
+	}
+
+	return d.store.RecordWebhookAttempt(ctx, delivery, a)
+}
+
+func (d *Dispatcher) post(ctx context.Context, delivery *model.WebhookDelivery, at time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := at.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-grpc-webhooks")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the delay with each failed attempt, up to backoffMax.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.backoffBase
+	for i := 1; i < attempts && delay < d.backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, d.backoffMax)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,217 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/db"
+	"github.com/Elmar006/todo_grpc/internal/events"
+	"github.com/Elmar006/todo_grpc/internal/model"
+	"github.com/Elmar006/todo_grpc/internal/repository"
+)
+
+func newRepo(t *testing.T) *repository.RepositoryDB {
+	t.Helper()
+	pool, err := db.Open(filepath.Join(t.TempDir(), "todo.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return &repository.RepositoryDB{Pool: pool}
+}
+
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	w.WriteHeader(rc.status)
+}
+
+func TestDispatchSignedDelivery(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	rc := &receiver{status: http.StatusNoContent}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	hook := &model.Webhook{URL: srv.URL, Secret: "s3cret", Events: []string{events.TaskCreated}}
+	if err := repo.CreateWebhook(ctx, hook); err != nil {
+		t.Fatal(err)
+	}
+
+	pub := NewPublisher(repo)
+	task := &model.Model{ID: 1, Title: "Write tests"}
+	created := events.New(events.TaskCreated, task)
+	// Publishing is at-least-once; the repeated event must not be
+	// delivered twice.
+	for _, ev := range []events.Event{created, events.New(events.TaskUpdated, task), created} {
+		if err := pub.Publish(ctx, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := NewDispatcher(repo, WithHTTPClient(srv.Client())).DispatchDue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || len(rc.requests) != 1 {
+		t.Fatalf("expected one delivery for the subscribed event, got %d attempts and %d requests", n, len(rc.requests))
+	}
+
+	req, body := rc.requests[0], rc.bodies[0]
+	if req.Header.Get(HeaderEvent) != events.TaskCreated {
+		t.Errorf("unexpected event header %q", req.Header.Get(HeaderEvent))
+	}
+	if !Verify("s3cret", req.Header.Get(HeaderTimestamp), req.Header.Get(HeaderSignature), body, time.Minute) {
+		t.Error("signature does not verify")
+	}
+	var ev events.Event
+	if err := json.Unmarshal(body, &ev); err != nil || ev.Task.Title != "Write tests" {
+		t.Errorf("unexpected payload %s (%v)", body, err)
+	}
+
+	deliveries, err := repo.ListWebhookDeliveries(ctx, hook.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != model.DeliveryDelivered || len(deliveries[0].Log) != 1 {
+		t.Errorf("unexpected delivery log: %+v", deliveries)
+	}
+}
+
+func TestDispatchRetriesThenDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	rc := &receiver{status: http.StatusInternalServerError}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	hook := &model.Webhook{URL: srv.URL, Secret: "s3cret"}
+	if err := repo.CreateWebhook(ctx, hook); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewPublisher(repo).Publish(ctx, events.New(events.TaskDeleted, &model.Model{ID: 1})); err != nil {
+		t.Fatal(err)
+	}
+
+	// The outbox stores times with millisecond precision.
+	now := time.Now().Truncate(time.Millisecond)
+	d := NewDispatcher(repo, WithHTTPClient(srv.Client()), WithMaxAttempts(3), WithBackoff(time.Minute, 90*time.Second))
+	d.now = func() time.Time { return now }
+
+	wantNext := []time.Duration{time.Minute, 90 * time.Second}
+	for i, wait := range wantNext {
+		if n, err := d.DispatchDue(ctx); err != nil || n != 1 {
+			t.Fatalf("attempt %d: dispatched %d, err %v", i+1, n, err)
+		}
+		if n, _ := d.DispatchDue(ctx); n != 0 {
+			t.Fatalf("attempt %d: delivery retried before its backoff elapsed", i+1)
+		}
+
+		deliveries, err := repo.ListWebhookDeliveries(ctx, hook.ID, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := deliveries[0]
+		if got.Status != model.DeliveryPending || got.NextAttemptAt.Sub(now) != wait {
+			t.Fatalf("attempt %d: unexpected state %s, next in %s", i+1, got.Status, got.NextAttemptAt.Sub(now))
+		}
+		now = now.Add(wait)
+	}
+
+	if n, err := d.DispatchDue(ctx); err != nil || n != 1 {
+		t.Fatalf("last attempt: dispatched %d, err %v", n, err)
+	}
+	deliveries, err := repo.ListWebhookDeliveries(ctx, hook.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := deliveries[0]
+	if got.Status != model.DeliveryDead || got.Attempts != 3 || len(got.Log) != 3 || got.Log[0].StatusCode != 500 {
+		t.Fatalf("expected a dead delivery with three logged attempts, got %+v", got)
+	}
+
+	if _, err := repo.RetryWebhookDelivery(ctx, got.ID, now); err != nil {
+		t.Fatal(err)
+	}
+	rc.mu.Lock()
+	rc.status = http.StatusOK
+	rc.mu.Unlock()
+	if n, err := d.DispatchDue(ctx); err != nil || n != 1 {
+		t.Fatalf("after retry: dispatched %d, err %v", n, err)
+	}
+	if len(rc.requests) != 4 {
+		t.Errorf("expected 4 requests, got %d", len(rc.requests))
+	}
+}
+
+func TestDispatchRefusesPrivateAddresses(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	rc := &receiver{status: http.StatusOK}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	// The hook is stored directly, as if its host had resolved to a public
+	// address when it was created.
+	hook := &model.Webhook{URL: srv.URL, Secret: "s3cret"}
+	if err := repo.CreateWebhook(ctx, hook); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewPublisher(repo).Publish(ctx, events.New(events.TaskCreated, &model.Model{ID: 1})); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := NewDispatcher(repo).DispatchDue(ctx); err != nil || n != 1 {
+		t.Fatalf("dispatched %d, err %v", n, err)
+	}
+	if len(rc.requests) != 0 {
+		t.Fatalf("expected no request to a loopback address, got %d", len(rc.requests))
+	}
+	deliveries, err := repo.ListWebhookDeliveries(ctx, hook.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log := deliveries[0].Log; len(log) != 1 || !strings.Contains(log[0].Error, ErrPrivateAddress.Error()) {
+		t.Errorf("expected the attempt to fail with %q, got %+v", ErrPrivateAddress, log)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	for host, private := range map[string]bool{
+		"127.0.0.1":       true,
+		"::1":             true,
+		"10.1.2.3":        true,
+		"192.168.0.10":    true,
+		"169.254.169.254": true,
+		"fe80::1":         true,
+		"0.0.0.0":         true,
+		"::ffff:10.0.0.1": true,
+		"localhost":       true,
+		"93.184.216.34":   false,
+		"2606:4700::1111": false,
+	} {
+		err := CheckHost(context.Background(), host)
+		if got := errors.Is(err, ErrPrivateAddress); got != private {
+			t.Errorf("CheckHost(%q) = %v, want private=%t", host, err, private)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/events"
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Todo-Event"
+	HeaderEventID   = "X-Todo-Event-Id"
+	HeaderDelivery  = "X-Todo-Delivery"
+	HeaderTimestamp = "X-Todo-Timestamp"
+	HeaderSignature = "X-Todo-Signature"
+)
+
+// Sign returns the HeaderSignature value for a body sent at timestamp:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Including the timestamp lets receivers reject replayed requests.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a delivery. A
+// positive tolerance also rejects timestamps further than that from now.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if tolerance > 0 {
+		if d := time.Since(time.Unix(ts, 0)); d > tolerance || d < -tolerance {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}
+
+// EnqueueStore fans an event out to the webhooks subscribed to it.
+type EnqueueStore interface {
+	EnqueueWebhookDeliveries(ctx context.Context, eventID, eventType string, payload []byte, at time.Time) (int64, error)
+}
+
+// Publisher is an event publisher for the outbox relay that queues a
+// webhook delivery per subscription for the Dispatcher. Publishing the
+// same event twice queues it once.
+type Publisher struct {
+	store EnqueueStore
+}
+
+func NewPublisher(store EnqueueStore) *Publisher {
+	return &Publisher{store: store}
+}
+
+func (p *Publisher) Publish(ctx context.Context, ev events.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = p.store.EnqueueWebhookDeliveries(ctx, ev.ID, ev.Type, payload, time.Now())
+	return err
+}
+
+func (p *Publisher) Close() error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package webrpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type connectDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type connectError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Details []connectDetail `json:"details,omitempty"`
+}
+
+type connectEndStream struct {
+	Error    *connectError       `json:"error,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+}
+
+// connectCode returns the Connect name of a gRPC code, e.g.
+// InvalidArgument becomes "invalid_argument".
+func connectCode(c codes.Code) string {
+	var sb strings.Builder
+	for i, r := range c.String() {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func newConnectError(st *status.Status) *connectError {
+	ce := &connectError{Code: connectCode(st.Code()), Message: st.Message()}
+	for _, d := range st.Proto().GetDetails() {
+		ce.Details = append(ce.Details, connectDetail{
+			Type:  strings.TrimPrefix(d.GetTypeUrl(), "type.googleapis.com/"),
+			Value: base64.RawStdEncoding.EncodeToString(d.GetValue()),
+		})
+	}
+	return ce
+}
+
+func connectErrorJSON(st *status.Status) []byte {
+	b, err := json.Marshal(newConnectError(st))
+	if err != nil {
+		log.L().Errorf("webrpc: marshal connect error: %v", err)
+		return []byte(`{"code":"internal"}`)
+	}
+	return b
+}
+
+func connectEndStreamJSON(st *status.Status, trailer metadata.MD) []byte {
+	end := connectEndStream{}
+	if st.Code() != codes.OK {
+		end.Error = newConnectError(st)
+	}
+	if len(trailer) > 0 {
+		end.Metadata = trailer
+	}
+
+	b, err := json.Marshal(end)
+	if err != nil {
+		log.L().Errorf("webrpc: marshal connect end stream: %v", err)
+		return []byte(`{"error":{"code":"internal"}}`)
+	}
+	return b
+}
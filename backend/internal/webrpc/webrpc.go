@@ -0,0 +1,532 @@
+package webrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Elmar006/todo_grpc/internal/gateway"
+	log "github.com/Elmar006/todo_grpc/internal/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const maxMessageSize = 4 << 20
+
+const (
+	flagCompressed = 0x01
+	flagEndStream  = 0x02 // Connect end-of-stream envelope
+	flagTrailer    = 0x80 // gRPC-Web trailers frame
+)
+
+// skippedHeaders are HTTP headers that must not be forwarded as metadata.
+var skippedHeaders = map[string]bool{
+	"connection":               true,
+	"content-length":           true,
+	"content-type":             true,
+	"host":                     true,
+	"origin":                   true,
+	"referer":                  true,
+	"te":                       true,
+	"user-agent":               true,
+	"accept":                   true,
+	"accept-encoding":          true,
+	"connect-protocol-version": true,
+	"connect-timeout-ms":       true,
+	"grpc-timeout":             true,
+	"x-grpc-web":               true,
+	"x-user-agent":             true,
+}
+
+type method struct {
+	desc   protoreflect.MethodDescriptor
+	input  protoreflect.MessageType
+	output protoreflect.MessageType
+}
+
+// Handler accepts gRPC-Web and Connect requests from browsers and replays
+// them as regular gRPC calls over conn. It works from the service
+// descriptors alone, so new RPCs need no extra code here.
+type Handler struct {
+	conn    grpc.ClientConnInterface
+	methods map[string]method
+}
+
+func New(conn grpc.ClientConnInterface, services ...protoreflect.ServiceDescriptor) (*Handler, error) {
+	h := &Handler{conn: conn, methods: make(map[string]method)}
+
+	for _, sd := range services {
+		for i := 0; i < sd.Methods().Len(); i++ {
+			md := sd.Methods().Get(i)
+			in, err := protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName())
+			if err != nil {
+				return nil, err
+			}
+			out, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+			if err != nil {
+				return nil, err
+			}
+			path := fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())
+			h.methods[path] = method{desc: md, input: in, output: out}
+		}
+	}
+
+	return h, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m, ok := h.methods[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	p, err := parseProtocol(r.Header.Get("Content-Type"), m.desc)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	ctx, cancel, err := callContext(r, p)
+	if err != nil {
+		p.writeError(w, nil, nil, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+	defer cancel()
+
+	body := io.Reader(r.Body)
+	if p.text {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	requests, err := p.readRequests(body, m)
+	if err != nil {
+		p.writeError(w, nil, nil, err)
+		return
+	}
+
+	h.call(ctx, w, p, m, r.URL.Path, requests)
+}
+
+func (h *Handler) call(ctx context.Context, w http.ResponseWriter, p *protocol, m method, path string, requests []proto.Message) {
+	desc := &grpc.StreamDesc{
+		StreamName:    string(m.desc.Name()),
+		ServerStreams: m.desc.IsStreamingServer(),
+		ClientStreams: m.desc.IsStreamingClient(),
+	}
+
+	stream, err := h.conn.NewStream(ctx, desc, path)
+	if err != nil {
+		p.writeError(w, nil, nil, err)
+		return
+	}
+	for _, req := range requests {
+		// io.EOF means the server already ended the call; its status
+		// comes out of RecvMsg below.
+		if err := stream.SendMsg(req); err != nil && !errors.Is(err, io.EOF) {
+			p.writeError(w, nil, nil, err)
+			return
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		p.writeError(w, nil, nil, err)
+		return
+	}
+
+	if !desc.ServerStreams {
+		h.unary(w, p, m, stream)
+		return
+	}
+
+	var out responseWriter
+	for {
+		resp := m.output.New().Interface()
+		err := stream.RecvMsg(resp)
+		if err != nil && !errors.Is(err, io.EOF) {
+			if out == nil {
+				header, _ := stream.Header()
+				p.writeError(w, header, stream.Trailer(), err)
+			} else {
+				out.finish(stream.Trailer(), err)
+			}
+			return
+		}
+		if out == nil {
+			header, _ := stream.Header()
+			out = p.start(w, header, nil)
+		}
+		if errors.Is(err, io.EOF) {
+			out.finish(stream.Trailer(), nil)
+			return
+		}
+		if err := out.write(resp); err != nil {
+			log.L().Warnf("webrpc %s: write response: %v", path, err)
+			return
+		}
+	}
+}
+
+// unary reads the single response and then drains the stream, so the
+// trailers are known before anything is written.
+func (h *Handler) unary(w http.ResponseWriter, p *protocol, m method, stream grpc.ClientStream) {
+	resp := m.output.New().Interface()
+	err := stream.RecvMsg(resp)
+	if err == nil {
+		if extra := stream.RecvMsg(m.output.New().Interface()); !errors.Is(extra, io.EOF) {
+			err = extra
+			if err == nil {
+				err = status.Error(codes.Internal, "unexpected extra response message")
+			}
+		}
+	}
+
+	header, _ := stream.Header()
+	if err != nil {
+		p.writeError(w, header, stream.Trailer(), err)
+		return
+	}
+
+	out := p.start(w, header, stream.Trailer())
+	if err := out.write(resp); err != nil {
+		log.L().Warnf("webrpc %s: write response: %v", m.desc.FullName(), err)
+		return
+	}
+	out.finish(stream.Trailer(), nil)
+}
+
+func callContext(r *http.Request, p *protocol) (context.Context, context.CancelFunc, error) {
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		key := strings.ToLower(name)
+		if skippedHeaders[key] || strings.HasPrefix(key, "access-control-") {
+			continue
+		}
+		if strings.HasSuffix(key, "-bin") {
+			for _, v := range values {
+				if b, err := base64.StdEncoding.DecodeString(v); err == nil {
+					md.Append(key, string(b))
+				}
+			}
+			continue
+		}
+		md.Append(key, values...)
+	}
+	gateway.SetClientMetadata(md, r)
+
+	ctx := metadata.NewOutgoingContext(r.Context(), md)
+
+	timeout, err := p.timeout(r.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		return ctx, cancel, nil
+	}
+	return ctx, func() {}, nil
+}
+
+type protocol struct {
+	connect bool
+	stream  bool // enveloped messages; always true for gRPC-Web
+	text    bool // gRPC-Web base64 body
+	json    bool
+
+	contentType string
+}
+
+func parseProtocol(contentType string, md protoreflect.MethodDescriptor) (*protocol, error) {
+	ct, _, _ := strings.Cut(contentType, ";")
+	ct = strings.TrimSpace(strings.ToLower(ct))
+
+	streaming := md.IsStreamingClient() || md.IsStreamingServer()
+
+	switch ct {
+	case "application/grpc-web", "application/grpc-web+proto":
+		return &protocol{stream: true, contentType: ct}, nil
+	case "application/grpc-web+json":
+		return &protocol{stream: true, json: true, contentType: ct}, nil
+	case "application/grpc-web-text", "application/grpc-web-text+proto":
+		return &protocol{stream: true, text: true, contentType: ct}, nil
+	case "application/proto", "application/json":
+		if streaming {
+			return nil, fmt.Errorf("%s requires a streaming content type", md.Name())
+		}
+		return &protocol{connect: true, json: ct == "application/json", contentType: ct}, nil
+	case "application/connect+proto", "application/connect+json":
+		if !streaming {
+			return nil, fmt.Errorf("%s is a unary method", md.Name())
+		}
+		return &protocol{connect: true, stream: true, json: ct == "application/connect+json", contentType: ct}, nil
+	}
+	return nil, fmt.Errorf("unsupported content type %q", contentType)
+}
+
+func (p *protocol) timeout(h http.Header) (time.Duration, error) {
+	if p.connect {
+		v := h.Get("Connect-Timeout-Ms")
+		if v == "" {
+			return 0, nil
+		}
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || ms <= 0 {
+			return 0, fmt.Errorf("invalid Connect-Timeout-Ms %q", v)
+		}
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+
+	v := h.Get("Grpc-Timeout")
+	if len(v) < 2 {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v[:len(v)-1], 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", v)
+	}
+	unit := map[byte]time.Duration{
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+		'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+	}[v[len(v)-1]]
+	if unit == 0 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", v)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+func (p *protocol) unmarshal(b []byte, msg proto.Message) error {
+	if p.json {
+		return protojson.Unmarshal(b, msg)
+	}
+	return proto.Unmarshal(b, msg)
+}
+
+func (p *protocol) marshal(msg proto.Message) ([]byte, error) {
+	if p.json {
+		return protojson.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
+
+func (p *protocol) readRequests(body io.Reader, m method) ([]proto.Message, error) {
+	if !p.stream {
+		b, err := io.ReadAll(io.LimitReader(body, maxMessageSize+1))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(b) > maxMessageSize {
+			return nil, status.Error(codes.ResourceExhausted, "request message too large")
+		}
+		msg := m.input.New().Interface()
+		if err := p.unmarshal(b, msg); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return []proto.Message{msg}, nil
+	}
+
+	var messages []proto.Message
+	br := bufio.NewReader(body)
+	for {
+		flags, payload, err := readEnvelope(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if flags&flagCompressed != 0 {
+			return nil, status.Error(codes.Unimplemented, "compressed messages are not supported")
+		}
+		if flags&flagEndStream != 0 {
+			break
+		}
+		msg := m.input.New().Interface()
+		if err := p.unmarshal(payload, msg); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		messages = append(messages, msg)
+	}
+
+	if len(messages) == 0 && !m.desc.IsStreamingClient() {
+		return nil, status.Error(codes.InvalidArgument, "missing request message")
+	}
+	return messages, nil
+}
+
+func readEnvelope(r io.Reader) (byte, []byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, status.Error(codes.InvalidArgument, "truncated message envelope")
+		}
+		return 0, nil, err
+	}
+
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxMessageSize {
+		return 0, nil, status.Error(codes.ResourceExhausted, "request message too large")
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, status.Error(codes.InvalidArgument, "truncated message envelope")
+	}
+	return prefix[0], payload, nil
+}
+
+func envelope(flags byte, payload []byte) []byte {
+	b := make([]byte, 5+len(payload))
+	b[0] = flags
+	binary.BigEndian.PutUint32(b[1:5], uint32(len(payload)))
+	copy(b[5:], payload)
+	return b
+}
+
+type responseWriter interface {
+	write(msg proto.Message) error
+	finish(trailer metadata.MD, err error)
+}
+
+// start writes the response headers. Connect unary calls pass their
+// trailers here too, since they travel as "Trailer-" prefixed headers.
+func (p *protocol) start(w http.ResponseWriter, header, trailer metadata.MD) responseWriter {
+	w.Header().Set("Content-Type", p.contentType)
+	setHeaders(w.Header(), "", header)
+	setHeaders(w.Header(), "Trailer-", trailer)
+	w.WriteHeader(http.StatusOK)
+
+	if p.connect && !p.stream {
+		return &connectUnaryWriter{p: p, w: w}
+	}
+	return &envelopeWriter{p: p, w: w}
+}
+
+// writeError answers a call that failed before any message was sent.
+func (p *protocol) writeError(w http.ResponseWriter, header, trailer metadata.MD, err error) {
+	if p.connect && !p.stream {
+		st := status.Convert(err)
+		setHeaders(w.Header(), "", header)
+		setHeaders(w.Header(), "Trailer-", trailer)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(gateway.HTTPStatusFromCode(st.Code()))
+		w.Write(connectErrorJSON(st))
+		return
+	}
+	p.start(w, header, nil).finish(trailer, err)
+}
+
+type connectUnaryWriter struct {
+	p *protocol
+	w http.ResponseWriter
+}
+
+func (c *connectUnaryWriter) write(msg proto.Message) error {
+	b, err := c.p.marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = c.w.Write(b)
+	return err
+}
+
+// finish has nothing left to do: the trailers went out with the headers.
+func (c *connectUnaryWriter) finish(metadata.MD, error) {}
+
+type envelopeWriter struct {
+	p *protocol
+	w http.ResponseWriter
+}
+
+func (e *envelopeWriter) send(b []byte) error {
+	if e.p.text {
+		b = []byte(base64.StdEncoding.EncodeToString(b))
+	}
+	if _, err := e.w.Write(b); err != nil {
+		return err
+	}
+	if f, ok := e.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (e *envelopeWriter) write(msg proto.Message) error {
+	b, err := e.p.marshal(msg)
+	if err != nil {
+		return err
+	}
+	return e.send(envelope(0, b))
+}
+
+func (e *envelopeWriter) finish(trailer metadata.MD, err error) {
+	st := status.Convert(err)
+
+	if e.p.connect {
+		e.send(envelope(flagEndStream, connectEndStreamJSON(st, trailer)))
+		return
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "grpc-status: %d\r\n", st.Code())
+	if st.Message() != "" {
+		fmt.Fprintf(&sb, "grpc-message: %s\r\n", encodeGRPCMessage(st.Message()))
+	}
+	if len(st.Proto().GetDetails()) > 0 {
+		if b, err := proto.Marshal(st.Proto()); err == nil {
+			fmt.Fprintf(&sb, "grpc-status-details-bin: %s\r\n", base64.RawStdEncoding.EncodeToString(b))
+		}
+	}
+	for k, values := range trailer {
+		for _, v := range values {
+			if strings.HasSuffix(k, "-bin") {
+				v = base64.RawStdEncoding.EncodeToString([]byte(v))
+			}
+			fmt.Fprintf(&sb, "%s: %s\r\n", k, v)
+		}
+	}
+	e.send(envelope(flagTrailer, []byte(sb.String())))
+}
+
+func setHeaders(h http.Header, prefix string, md metadata.MD) {
+	for k, values := range md {
+		for _, v := range values {
+			if strings.HasSuffix(k, "-bin") {
+				v = base64.RawStdEncoding.EncodeToString([]byte(v))
+			}
+			h.Add(prefix+k, v)
+		}
+	}
+}
+
+// encodeGRPCMessage percent-encodes the status message as the gRPC
+// spec requires for the grpc-message trailer.
+func encodeGRPCMessage(msg string) string {
+	var sb strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
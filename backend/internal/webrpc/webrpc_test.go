@@ -0,0 +1,144 @@
+package webrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeServer struct {
+	todo.UnimplementedTodoServiceServer
+}
+
+func (fakeServer) GetTask(_ context.Context, req *todo.GetTaskRequest) (*todo.Task, error) {
+	if req.GetId() != 1 {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &todo.Task{Id: 1, Title: "Buy milk"}, nil
+}
+
+func newHandler(t *testing.T) *Handler {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	todo.RegisterTodoServiceServer(srv, fakeServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	h, err := New(conn, todo.File_todoService_todo_proto.Services().ByName("TodoService"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func post(h http.Handler, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/todoService.TodoService/GetTask", bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGRPCWeb(t *testing.T) {
+	h := newHandler(t)
+
+	in, _ := proto.Marshal(&todo.GetTaskRequest{Id: 1})
+	rec := post(h, "application/grpc-web+proto", envelope(0, in))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	flags, payload, err := readEnvelope(rec.Body)
+	if err != nil || flags != 0 {
+		t.Fatalf("expected message frame, got flags %x, %v", flags, err)
+	}
+	task := &todo.Task{}
+	if err := proto.Unmarshal(payload, task); err != nil || task.GetTitle() != "Buy milk" {
+		t.Fatalf("unexpected task %v, %v", task, err)
+	}
+
+	flags, payload, err = readEnvelope(rec.Body)
+	if err != nil || flags != flagTrailer || !strings.Contains(string(payload), "grpc-status: 0") {
+		t.Fatalf("expected OK trailers, got %x %q, %v", flags, payload, err)
+	}
+}
+
+func TestGRPCWebTextError(t *testing.T) {
+	h := newHandler(t)
+
+	in, _ := proto.Marshal(&todo.GetTaskRequest{Id: 2})
+	body := base64.StdEncoding.EncodeToString(envelope(0, in))
+	rec := post(h, "application/grpc-web-text", []byte(body))
+
+	raw, err := base64.StdEncoding.DecodeString(rec.Body.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags, payload, err := readEnvelope(bytes.NewReader(raw))
+	if err != nil || flags != flagTrailer {
+		t.Fatalf("expected trailers-only response, got %x, %v", flags, err)
+	}
+	if !strings.Contains(string(payload), "grpc-status: 5") {
+		t.Errorf("expected NotFound status, got %q", payload)
+	}
+}
+
+func TestConnectUnary(t *testing.T) {
+	h := newHandler(t)
+
+	rec := post(h, "application/json", []byte(`{"id":"1"}`))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"title":"Buy milk"`) {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body)
+	}
+
+	rec = post(h, "application/json", []byte(`{"id":"2"}`))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	var ce connectError
+	if err := json.Unmarshal(rec.Body.Bytes(), &ce); err != nil || ce.Code != "not_found" {
+		t.Errorf("expected not_found error, got %+v, %v", ce, err)
+	}
+}
+
+func TestConnectCode(t *testing.T) {
+	cases := map[codes.Code]string{
+		codes.Canceled:          "canceled",
+		codes.InvalidArgument:   "invalid_argument",
+		codes.ResourceExhausted: "resource_exhausted",
+		codes.Unauthenticated:   "unauthenticated",
+	}
+	for c, want := range cases {
+		if got := connectCode(c); got != want {
+			t.Errorf("connectCode(%v) = %q, want %q", c, got, want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Package client is a Go SDK for the TodoService API. It exposes tasks as
+// plain Go values, applies default deadlines, retries idempotent calls and
+// maps gRPC status codes to sentinel errors.
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type Task struct {
+	ID          int64
+	Title       string
+	Description *string
+	Completed   bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TaskUpdate lists the fields to change; nil fields are left as they are.
+type TaskUpdate struct {
+	Title       *string
+	Description *string
+	Completed   *bool
+}
+
+type Client struct {
+	conn *grpc.ClientConn
+	api  todo.TodoServiceClient
+	opts options
+}
+
+// New connects to the server at addr.
+func New(addr string, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	creds := insecure.NewCredentials()
+	if o.tlsConfig != nil {
+		creds = credentials.NewTLS(o.tlsConfig)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(o.keepalive),
+	}
+	if o.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken{token: o.token, secure: o.tlsConfig != nil}))
+	}
+	dialOpts = append(dialOpts, o.dialOpts...)
+
+	conn, err := grpc.NewClient(addr, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{conn: conn, api: todo.NewTodoServiceClient(conn), opts: o}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// CreateTask is not retried: a retry after a lost response would create
+// a duplicate task.
+func (c *Client) CreateTask(ctx context.Context, title string, description *string) (*Task, error) {
+	req := &todo.CreateTaskRequest{Title: title}
+	if description != nil {
+		req.Description = *description
+	}
+
+	var resp *todo.Task
+	err := c.call(ctx, false, func(ctx context.Context) (err error) {
+		resp, err = c.api.CreateTask(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProto(resp)
+}
+
+func (c *Client) GetTask(ctx context.Context, id int64) (*Task, error) {
+	var resp *todo.Task
+	err := c.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = c.api.GetTask(ctx, &todo.GetTaskRequest{Id: id})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProto(resp)
+}
+
+// ListTasks returns tasks whose title or description contains filter;
+// an empty filter returns every task.
+func (c *Client) ListTasks(ctx context.Context, filter string) ([]*Task, error) {
+	var resp *todo.ListTasksResponse
+	err := c.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = c.api.ListTasks(ctx, &todo.ListTasksRequest{Filter: filter})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*Task, len(resp.GetTasks()))
+	for i, t := range resp.GetTasks() {
+		if tasks[i], err = fromProto(t); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+// UpdateTask sets absolute values, so repeating it is safe and it is
+// retried like a read.
+func (c *Client) UpdateTask(ctx context.Context, id int64, update TaskUpdate) (*Task, error) {
+	req := &todo.UpdateTaskRequest{
+		Id:          id,
+		Title:       update.Title,
+		Description: update.Description,
+		Completed:   update.Completed,
+	}
+
+	var resp *todo.Task
+	err := c.call(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = c.api.UpdateTask(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProto(resp)
+}
+
+func (c *Client) CompleteTask(ctx context.Context, id int64) (*Task, error) {
+	done := true
+	return c.UpdateTask(ctx, id, TaskUpdate{Completed: &done})
+}
+
+// DeleteTask is not retried: a retry after a lost response would report
+// ErrTaskNotFound for a delete that succeeded.
+func (c *Client) DeleteTask(ctx context.Context, id int64) error {
+	return c.call(ctx, false, func(ctx context.Context) error {
+		_, err := c.api.DeleteTask(ctx, &todo.DeleteTaskRequest{Id: id})
+		return err
+	})
+}
+
+// call applies the default deadline and, for idempotent calls, retries
+// Unavailable errors with jittered exponential backoff.
+func (c *Client) call(ctx context.Context, idempotent bool, fn func(context.Context) error) error {
+	if _, ok := ctx.Deadline(); !ok && c.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.timeout)
+		defer cancel()
+	}
+
+	attempts := 1
+	if idempotent {
+		attempts = c.opts.maxAttempts
+	}
+
+	backoff := c.opts.initialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil || attempt >= attempts || status.Code(err) != codes.Unavailable {
+			break
+		}
+
+		wait := backoff/2 + rand.N(backoff/2+1)
+		select {
+		case <-ctx.Done():
+			return convertError(err)
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, c.opts.maxBackoff)
+	}
+	return convertError(err)
+}
+
+func fromProto(t *todo.Task) (*Task, error) {
+	if err := t.GetCreatedAt().CheckValid(); err != nil {
+		return nil, fmt.Errorf("created_at: %w", err)
+	}
+	if err := t.GetUpdatedAt().CheckValid(); err != nil {
+		return nil, fmt.Errorf("updated_at: %w", err)
+	}
+
+	desc := t.GetDescription()
+	return &Task{
+		ID:          t.GetId(),
+		Title:       t.GetTitle(),
+		Description: &desc,
+		Completed:   t.GetCompleted(),
+		CreatedAt:   t.GetCreatedAt().AsTime(),
+		UpdatedAt:   t.GetUpdatedAt().AsTime(),
+	}, nil
+}
+
+type bearerToken struct {
+	token  string
+	secure bool
+}
+
+func (b bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearerToken) RequireTransportSecurity() bool {
+	return b.secure
+}
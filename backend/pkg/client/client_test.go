@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeServer struct {
+	todo.UnimplementedTodoServiceServer
+	unavailable atomic.Int32
+	calls       atomic.Int32
+}
+
+func (f *fakeServer) GetTask(ctx context.Context, req *todo.GetTaskRequest) (*todo.Task, error) {
+	f.calls.Add(1)
+	if f.unavailable.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	if req.GetId() != 1 {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	title := "anonymous"
+	if auth := md.Get("authorization"); len(auth) > 0 {
+		title = auth[0]
+	}
+	at := timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	return &todo.Task{Id: 1, Title: title, CreatedAt: at, UpdatedAt: at}, nil
+}
+
+func (f *fakeServer) CreateTask(context.Context, *todo.CreateTaskRequest) (*todo.Task, error) {
+	f.calls.Add(1)
+	return nil, status.Error(codes.Unavailable, "try again")
+}
+
+func newClient(t *testing.T, srv *fakeServer, opts ...Option) *Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	todo.RegisterTodoServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts = append([]Option{
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		})),
+	}, opts...)
+
+	c, err := New("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestGetTaskRetriesUnavailable(t *testing.T) {
+	srv := &fakeServer{}
+	srv.unavailable.Store(2)
+	c := newClient(t, srv, WithToken("secret"))
+
+	task, err := c.GetTask(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if srv.calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", srv.calls.Load())
+	}
+	if task.Title != "Bearer secret" {
+		t.Errorf("expected token to be sent, got %q", task.Title)
+	}
+	if !task.CreatedAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected created_at %v", task.CreatedAt)
+	}
+}
+
+func TestGetTaskGivesUp(t *testing.T) {
+	srv := &fakeServer{}
+	srv.unavailable.Store(10)
+	c := newClient(t, srv)
+
+	_, err := c.GetTask(context.Background(), 1)
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+	if srv.calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", srv.calls.Load())
+	}
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected status to be preserved, got %v", status.Code(err))
+	}
+}
+
+func TestCreateTaskNotRetried(t *testing.T) {
+	srv := &fakeServer{}
+	c := newClient(t, srv)
+
+	if _, err := c.CreateTask(context.Background(), "title", nil); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+	if srv.calls.Load() != 1 {
+		t.Errorf("expected a single call, got %d", srv.calls.Load())
+	}
+}
+
+func TestGetTaskNotFound(t *testing.T) {
+	c := newClient(t, &fakeServer{})
+
+	if _, err := c.GetTask(context.Background(), 2); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+}
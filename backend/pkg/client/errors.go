@@ -0,0 +1,87 @@
+package client
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrTaskNotFound      = errors.New("task not found")
+	ErrInvalidArgument   = errors.New("invalid argument")
+	ErrResourceExhausted = errors.New("resource exhausted")
+	ErrUnauthenticated   = errors.New("unauthenticated")
+	ErrPermissionDenied  = errors.New("permission denied")
+	ErrUnavailable       = errors.New("service unavailable")
+	ErrDeadlineExceeded  = errors.New("deadline exceeded")
+)
+
+var sentinels = map[codes.Code]error{
+	codes.NotFound:          ErrTaskNotFound,
+	codes.InvalidArgument:   ErrInvalidArgument,
+	codes.ResourceExhausted: ErrResourceExhausted,
+	codes.Unauthenticated:   ErrUnauthenticated,
+	codes.PermissionDenied:  ErrPermissionDenied,
+	codes.Unavailable:       ErrUnavailable,
+	codes.DeadlineExceeded:  ErrDeadlineExceeded,
+}
+
+// Error wraps a gRPC status. errors.Is matches it against the sentinel
+// errors above, and status.FromError still sees the original status.
+type Error struct {
+	st       *status.Status
+	sentinel error
+}
+
+func (e *Error) Error() string {
+	return e.st.Code().String() + ": " + e.st.Message()
+}
+
+func (e *Error) Unwrap() error {
+	return e.sentinel
+}
+
+func (e *Error) GRPCStatus() *status.Status {
+	return e.st
+}
+
+// FieldViolation describes one invalid request field reported by the
+// server.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// FieldViolations lists the per-field problems of an InvalidArgument
+// error, or nil for any other error.
+func FieldViolations(err error) []FieldViolation {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+
+	var violations []FieldViolation
+	for _, d := range e.st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range br.GetFieldViolations() {
+			violations = append(violations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+		}
+	}
+	return violations
+}
+
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &Error{st: st, sentinel: sentinels[st.Code()]}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	todo "github.com/Elmar006/todo_grpc/proto/gen/todoService"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// slowExport holds an export stream open without sending anything, as a
+// quiet download or watch does.
+type slowExport struct {
+	todo.UnimplementedTodoServiceServer
+	hold time.Duration
+}
+
+func (s *slowExport) ExportTasks(_ *todo.ExportTasksRequest, stream grpc.ServerStreamingServer[todo.ExportTasksResponse]) error {
+	select {
+	case <-time.After(s.hold):
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	}
+	return stream.Send(&todo.ExportTasksResponse{Data: []byte("done")})
+}
+
+// TestKeepaliveOnLongStream holds a stream open across several client
+// pings. Pings are sent at most every 10s, so the test takes a while.
+func TestKeepaliveOnLongStream(t *testing.T) {
+	if testing.Short() {
+		t.Skip("holds a stream open for 45s")
+	}
+
+	tests := []struct {
+		name   string
+		policy *keepalive.EnforcementPolicy
+		code   codes.Code
+	}{
+		// The server's policy: pings every KEEPALIVE_MIN_TIME are fine.
+		{name: "with policy", policy: &keepalive.EnforcementPolicy{MinTime: 5 * time.Second, PermitWithoutStream: true}, code: codes.OK},
+		// grpc-go's default policy allows one ping every 5 minutes.
+		{name: "default policy", code: codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var serverOpts []grpc.ServerOption
+			if tt.policy != nil {
+				serverOpts = append(serverOpts, grpc.KeepaliveEnforcementPolicy(*tt.policy))
+			}
+			lis := bufconn.Listen(1 << 20)
+			s := grpc.NewServer(serverOpts...)
+			todo.RegisterTodoServiceServer(s, &slowExport{hold: 45 * time.Second})
+			go s.Serve(lis)
+			t.Cleanup(s.Stop)
+
+			c, err := New("passthrough:///bufnet",
+				WithKeepalive(keepalive.ClientParameters{Time: 10 * time.Second, Timeout: 5 * time.Second}),
+				WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return lis.DialContext(ctx)
+				})),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { c.Close() })
+
+			stream, err := todo.NewTodoServiceClient(c.conn).ExportTasks(context.Background(), &todo.ExportTasksRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = stream.Recv()
+			if got := status.Code(err); got != tt.code {
+				t.Errorf("expected %s, got %v", tt.code, err)
+			}
+		})
+	}
+}
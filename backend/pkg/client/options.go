@@ -0,0 +1,84 @@
+package client
+
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+type options struct {
+	timeout        time.Duration
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	token     string
+	tlsConfig *tls.Config
+	keepalive keepalive.ClientParameters
+	dialOpts  []grpc.DialOption
+}
+
+func defaultOptions() options {
+	return options{
+		timeout:        5 * time.Second,
+		maxAttempts:    4,
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     2 * time.Second,
+		// The server accepts pings every KEEPALIVE_MIN_TIME (20s by
+		// default) and disconnects clients that ping more often.
+		keepalive: keepalive.ClientParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+type Option func(*options)
+
+// WithTimeout sets the deadline applied to calls whose context has none.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithRetry configures retries of idempotent calls that fail with
+// Unavailable. maxAttempts includes the first call; 1 disables retries.
+func WithRetry(maxAttempts int, initialBackoff, maxBackoff time.Duration) Option {
+	return func(o *options) {
+		o.maxAttempts = max(maxAttempts, 1)
+		o.initialBackoff = initialBackoff
+		o.maxBackoff = maxBackoff
+	}
+}
+
+// WithToken sends the token as a bearer authorization header.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithTLS enables TLS; without it the connection is plaintext.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithKeepalive sets how often idle connections are pinged. params.Time
+// must not be shorter than the server's KEEPALIVE_MIN_TIME.
+func WithKeepalive(params keepalive.ClientParameters) Option {
+	return func(o *options) {
+		o.keepalive = params
+	}
+}
+
+// WithDialOptions passes extra options to grpc.NewClient.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOpts = append(o.dialOpts, opts...)
+	}
+}
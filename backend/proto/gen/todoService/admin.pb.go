@@ -0,0 +1,957 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: todoService/admin.proto
+
+package todoService
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetDatabaseStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDatabaseStatsRequest) Reset() {
+	*x = GetDatabaseStatsRequest{}
+	mi := &file_todoService_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDatabaseStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDatabaseStatsRequest) ProtoMessage() {}
+
+func (x *GetDatabaseStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDatabaseStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetDatabaseStatsRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type TableStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RowCount      int64                  `protobuf:"varint,2,opt,name=row_count,json=rowCount,proto3" json:"row_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableStats) Reset() {
+	*x = TableStats{}
+	mi := &file_todoService_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableStats) ProtoMessage() {}
+
+func (x *TableStats) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableStats.ProtoReflect.Descriptor instead.
+func (*TableStats) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TableStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TableStats) GetRowCount() int64 {
+	if x != nil {
+		return x.RowCount
+	}
+	return 0
+}
+
+type DatabaseStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tables        []*TableStats          `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	FileSizeBytes int64                  `protobuf:"varint,2,opt,name=file_size_bytes,json=fileSizeBytes,proto3" json:"file_size_bytes,omitempty"`
+	// 0 unless the database is in WAL mode.
+	WalSizeBytes int64 `protobuf:"varint,3,opt,name=wal_size_bytes,json=walSizeBytes,proto3" json:"wal_size_bytes,omitempty"`
+	PageSize     int64 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageCount    int64 `protobuf:"varint,5,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	// Unused pages that Vacuum would release.
+	FreelistCount int64  `protobuf:"varint,6,opt,name=freelist_count,json=freelistCount,proto3" json:"freelist_count,omitempty"`
+	JournalMode   string `protobuf:"bytes,7,opt,name=journal_mode,json=journalMode,proto3" json:"journal_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DatabaseStats) Reset() {
+	*x = DatabaseStats{}
+	mi := &file_todoService_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatabaseStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseStats) ProtoMessage() {}
+
+func (x *DatabaseStats) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseStats.ProtoReflect.Descriptor instead.
+func (*DatabaseStats) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DatabaseStats) GetTables() []*TableStats {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *DatabaseStats) GetFileSizeBytes() int64 {
+	if x != nil {
+		return x.FileSizeBytes
+	}
+	return 0
+}
+
+func (x *DatabaseStats) GetWalSizeBytes() int64 {
+	if x != nil {
+		return x.WalSizeBytes
+	}
+	return 0
+}
+
+func (x *DatabaseStats) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *DatabaseStats) GetPageCount() int64 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (x *DatabaseStats) GetFreelistCount() int64 {
+	if x != nil {
+		return x.FreelistCount
+	}
+	return 0
+}
+
+func (x *DatabaseStats) GetJournalMode() string {
+	if x != nil {
+		return x.JournalMode
+	}
+	return ""
+}
+
+type VacuumRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VacuumRequest) Reset() {
+	*x = VacuumRequest{}
+	mi := &file_todoService_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VacuumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VacuumRequest) ProtoMessage() {}
+
+func (x *VacuumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VacuumRequest.ProtoReflect.Descriptor instead.
+func (*VacuumRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{3}
+}
+
+type VacuumResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SizeBeforeBytes int64                  `protobuf:"varint,1,opt,name=size_before_bytes,json=sizeBeforeBytes,proto3" json:"size_before_bytes,omitempty"`
+	SizeAfterBytes  int64                  `protobuf:"varint,2,opt,name=size_after_bytes,json=sizeAfterBytes,proto3" json:"size_after_bytes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VacuumResponse) Reset() {
+	*x = VacuumResponse{}
+	mi := &file_todoService_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VacuumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VacuumResponse) ProtoMessage() {}
+
+func (x *VacuumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VacuumResponse.ProtoReflect.Descriptor instead.
+func (*VacuumResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VacuumResponse) GetSizeBeforeBytes() int64 {
+	if x != nil {
+		return x.SizeBeforeBytes
+	}
+	return 0
+}
+
+func (x *VacuumResponse) GetSizeAfterBytes() int64 {
+	if x != nil {
+		return x.SizeAfterBytes
+	}
+	return 0
+}
+
+type AnalyzeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRequest) Reset() {
+	*x = AnalyzeRequest{}
+	mi := &file_todoService_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRequest) ProtoMessage() {}
+
+func (x *AnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{5}
+}
+
+type AnalyzeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeResponse) Reset() {
+	*x = AnalyzeResponse{}
+	mi := &file_todoService_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeResponse) ProtoMessage() {}
+
+func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{6}
+}
+
+type CheckIntegrityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Runs PRAGMA quick_check, which skips index consistency checks.
+	Quick         bool `protobuf:"varint,1,opt,name=quick,proto3" json:"quick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckIntegrityRequest) Reset() {
+	*x = CheckIntegrityRequest{}
+	mi := &file_todoService_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckIntegrityRequest) ProtoMessage() {}
+
+func (x *CheckIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*CheckIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckIntegrityRequest) GetQuick() bool {
+	if x != nil {
+		return x.Quick
+	}
+	return false
+}
+
+type IntegrityReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Problems      []string               `protobuf:"bytes,2,rep,name=problems,proto3" json:"problems,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntegrityReport) Reset() {
+	*x = IntegrityReport{}
+	mi := &file_todoService_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntegrityReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityReport) ProtoMessage() {}
+
+func (x *IntegrityReport) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityReport.ProtoReflect.Descriptor instead.
+func (*IntegrityReport) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IntegrityReport) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *IntegrityReport) GetProblems() []string {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+type GetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelRequest) Reset() {
+	*x = GetLogLevelRequest{}
+	mi := &file_todoService_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelRequest) ProtoMessage() {}
+
+func (x *GetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{9}
+}
+
+type SetLogLevelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of panic, fatal, error, warn, info, debug or trace.
+	Level         string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_todoService_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type LogLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLevel) Reset() {
+	*x = LogLevel{}
+	mi := &file_todoService_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevel) ProtoMessage() {}
+
+func (x *LogLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevel.ProtoReflect.Descriptor instead.
+func (*LogLevel) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LogLevel) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type ListConnectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectionsRequest) Reset() {
+	*x = ListConnectionsRequest{}
+	mi := &file_todoService_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectionsRequest) ProtoMessage() {}
+
+func (x *ListConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{12}
+}
+
+type Connection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RemoteAddr    string                 `protobuf:"bytes,2,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	ConnectedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_todoService_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Connection) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Connection) GetRemoteAddr() string {
+	if x != nil {
+		return x.RemoteAddr
+	}
+	return ""
+}
+
+func (x *Connection) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+// Call is an RPC in progress; streaming calls stay listed until the
+// stream ends.
+type Call struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId  uint64                 `protobuf:"varint,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Principal     string                 `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	Streaming     bool                   `protobuf:"varint,4,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Call) Reset() {
+	*x = Call{}
+	mi := &file_todoService_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Call) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Call) ProtoMessage() {}
+
+func (x *Call) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Call.ProtoReflect.Descriptor instead.
+func (*Call) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Call) GetConnectionId() uint64 {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return 0
+}
+
+func (x *Call) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Call) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *Call) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+func (x *Call) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+type ListConnectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Connections   []*Connection          `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	Calls         []*Call                `protobuf:"bytes,2,rep,name=calls,proto3" json:"calls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectionsResponse) Reset() {
+	*x = ListConnectionsResponse{}
+	mi := &file_todoService_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectionsResponse) ProtoMessage() {}
+
+func (x *ListConnectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListConnectionsResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListConnectionsResponse) GetConnections() []*Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+func (x *ListConnectionsResponse) GetCalls() []*Call {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+var File_todoService_admin_proto protoreflect.FileDescriptor
+
+const file_todoService_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x17todoService/admin.proto\x12\vtodoService\x1a\x1fgoogle/protobuf/timestamp.proto\"\x19\n" +
+	"\x17GetDatabaseStatsRequest\"=\n" +
+	"\n" +
+	"TableStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\trow_count\x18\x02 \x01(\x03R\browCount\"\x94\x02\n" +
+	"\rDatabaseStats\x12/\n" +
+	"\x06tables\x18\x01 \x03(\v2\x17.todoService.TableStatsR\x06tables\x12&\n" +
+	"\x0ffile_size_bytes\x18\x02 \x01(\x03R\rfileSizeBytes\x12$\n" +
+	"\x0ewal_size_bytes\x18\x03 \x01(\x03R\fwalSizeBytes\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x03R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x05 \x01(\x03R\tpageCount\x12%\n" +
+	"\x0efreelist_count\x18\x06 \x01(\x03R\rfreelistCount\x12!\n" +
+	"\fjournal_mode\x18\a \x01(\tR\vjournalMode\"\x0f\n" +
+	"\rVacuumRequest\"f\n" +
+	"\x0eVacuumResponse\x12*\n" +
+	"\x11size_before_bytes\x18\x01 \x01(\x03R\x0fsizeBeforeBytes\x12(\n" +
+	"\x10size_after_bytes\x18\x02 \x01(\x03R\x0esizeAfterBytes\"\x10\n" +
+	"\x0eAnalyzeRequest\"\x11\n" +
+	"\x0fAnalyzeResponse\"-\n" +
+	"\x15CheckIntegrityRequest\x12\x14\n" +
+	"\x05quick\x18\x01 \x01(\bR\x05quick\"=\n" +
+	"\x0fIntegrityReport\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x1a\n" +
+	"\bproblems\x18\x02 \x03(\tR\bproblems\"\x14\n" +
+	"\x12GetLogLevelRequest\"*\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\" \n" +
+	"\bLogLevel\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\"\x18\n" +
+	"\x16ListConnectionsRequest\"|\n" +
+	"\n" +
+	"Connection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vremote_addr\x18\x02 \x01(\tR\n" +
+	"remoteAddr\x12=\n" +
+	"\fconnected_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\"\xba\x01\n" +
+	"\x04Call\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\x04R\fconnectionId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\tR\tprincipal\x12\x1c\n" +
+	"\tstreaming\x18\x04 \x01(\bR\tstreaming\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\"}\n" +
+	"\x17ListConnectionsResponse\x129\n" +
+	"\vconnections\x18\x01 \x03(\v2\x17.todoService.ConnectionR\vconnections\x12'\n" +
+	"\x05calls\x18\x02 \x03(\v2\x11.todoService.CallR\x05calls2\xad\x04\n" +
+	"\fAdminService\x12T\n" +
+	"\x10GetDatabaseStats\x12$.todoService.GetDatabaseStatsRequest\x1a\x1a.todoService.DatabaseStats\x12A\n" +
+	"\x06Vacuum\x12\x1a.todoService.VacuumRequest\x1a\x1b.todoService.VacuumResponse\x12D\n" +
+	"\aAnalyze\x12\x1b.todoService.AnalyzeRequest\x1a\x1c.todoService.AnalyzeResponse\x12R\n" +
+	"\x0eCheckIntegrity\x12\".todoService.CheckIntegrityRequest\x1a\x1c.todoService.IntegrityReport\x12E\n" +
+	"\vGetLogLevel\x12\x1f.todoService.GetLogLevelRequest\x1a\x15.todoService.LogLevel\x12E\n" +
+	"\vSetLogLevel\x12\x1f.todoService.SetLogLevelRequest\x1a\x15.todoService.LogLevel\x12\\\n" +
+	"\x0fListConnections\x12#.todoService.ListConnectionsRequest\x1a$.todoService.ListConnectionsResponseBIZGgithub.com/Elmar006/todo_grpc/backend/proto/gen/todoService;todoServiceb\x06proto3"
+
+var (
+	file_todoService_admin_proto_rawDescOnce sync.Once
+	file_todoService_admin_proto_rawDescData []byte
+)
+
+func file_todoService_admin_proto_rawDescGZIP() []byte {
+	file_todoService_admin_proto_rawDescOnce.Do(func() {
+		file_todoService_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todoService_admin_proto_rawDesc), len(file_todoService_admin_proto_rawDesc)))
+	})
+	return file_todoService_admin_proto_rawDescData
+}
+
+var file_todoService_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_todoService_admin_proto_goTypes = []any{
+	(*GetDatabaseStatsRequest)(nil), // 0: todoService.GetDatabaseStatsRequest
+	(*TableStats)(nil),              // 1: todoService.TableStats
+	(*DatabaseStats)(nil),           // 2: todoService.DatabaseStats
+	(*VacuumRequest)(nil),           // 3: todoService.VacuumRequest
+	(*VacuumResponse)(nil),          // 4: todoService.VacuumResponse
+	(*AnalyzeRequest)(nil),          // 5: todoService.AnalyzeRequest
+	(*AnalyzeResponse)(nil),         // 6: todoService.AnalyzeResponse
+	(*CheckIntegrityRequest)(nil),   // 7: todoService.CheckIntegrityRequest
+	(*IntegrityReport)(nil),         // 8: todoService.IntegrityReport
+	(*GetLogLevelRequest)(nil),      // 9: todoService.GetLogLevelRequest
+	(*SetLogLevelRequest)(nil),      // 10: todoService.SetLogLevelRequest
+	(*LogLevel)(nil),                // 11: todoService.LogLevel
+	(*ListConnectionsRequest)(nil),  // 12: todoService.ListConnectionsRequest
+	(*Connection)(nil),              // 13: todoService.Connection
+	(*Call)(nil),                    // 14: todoService.Call
+	(*ListConnectionsResponse)(nil), // 15: todoService.ListConnectionsResponse
+	(*timestamppb.Timestamp)(nil),   // 16: google.protobuf.Timestamp
+}
+var file_todoService_admin_proto_depIdxs = []int32{
+	1,  // 0: todoService.DatabaseStats.tables:type_name -> todoService.TableStats
+	16, // 1: todoService.Connection.connected_at:type_name -> google.protobuf.Timestamp
+	16, // 2: todoService.Call.started_at:type_name -> google.protobuf.Timestamp
+	13, // 3: todoService.ListConnectionsResponse.connections:type_name -> todoService.Connection
+	14, // 4: todoService.ListConnectionsResponse.calls:type_name -> todoService.Call
+	0,  // 5: todoService.AdminService.GetDatabaseStats:input_type -> todoService.GetDatabaseStatsRequest
+	3,  // 6: todoService.AdminService.Vacuum:input_type -> todoService.VacuumRequest
+	5,  // 7: todoService.AdminService.Analyze:input_type -> todoService.AnalyzeRequest
+	7,  // 8: todoService.AdminService.CheckIntegrity:input_type -> todoService.CheckIntegrityRequest
+	9,  // 9: todoService.AdminService.GetLogLevel:input_type -> todoService.GetLogLevelRequest
+	10, // 10: todoService.AdminService.SetLogLevel:input_type -> todoService.SetLogLevelRequest
+	12, // 11: todoService.AdminService.ListConnections:input_type -> todoService.ListConnectionsRequest
+	2,  // 12: todoService.AdminService.GetDatabaseStats:output_type -> todoService.DatabaseStats
+	4,  // 13: todoService.AdminService.Vacuum:output_type -> todoService.VacuumResponse
+	6,  // 14: todoService.AdminService.Analyze:output_type -> todoService.AnalyzeResponse
+	8,  // 15: todoService.AdminService.CheckIntegrity:output_type -> todoService.IntegrityReport
+	11, // 16: todoService.AdminService.GetLogLevel:output_type -> todoService.LogLevel
+	11, // 17: todoService.AdminService.SetLogLevel:output_type -> todoService.LogLevel
+	15, // 18: todoService.AdminService.ListConnections:output_type -> todoService.ListConnectionsResponse
+	12, // [12:19] is the sub-list for method output_type
+	5,  // [5:12] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_todoService_admin_proto_init() }
+func file_todoService_admin_proto_init() {
+	if File_todoService_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todoService_admin_proto_rawDesc), len(file_todoService_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_todoService_admin_proto_goTypes,
+		DependencyIndexes: file_todoService_admin_proto_depIdxs,
+		MessageInfos:      file_todoService_admin_proto_msgTypes,
+	}.Build()
+	File_todoService_admin_proto = out.File
+	file_todoService_admin_proto_goTypes = nil
+	file_todoService_admin_proto_depIdxs = nil
+}
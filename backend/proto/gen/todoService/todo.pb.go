@@ -9,6 +9,7 @@ package todoService
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,14 +22,279 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AssigneeFilter int32
+
+const (
+	AssigneeFilter_ASSIGNEE_FILTER_UNSPECIFIED    AssigneeFilter = 0
+	AssigneeFilter_ASSIGNEE_FILTER_ASSIGNED_TO_ME AssigneeFilter = 1
+	AssigneeFilter_ASSIGNEE_FILTER_UNASSIGNED     AssigneeFilter = 2
+)
+
+// Enum value maps for AssigneeFilter.
+var (
+	AssigneeFilter_name = map[int32]string{
+		0: "ASSIGNEE_FILTER_UNSPECIFIED",
+		1: "ASSIGNEE_FILTER_ASSIGNED_TO_ME",
+		2: "ASSIGNEE_FILTER_UNASSIGNED",
+	}
+	AssigneeFilter_value = map[string]int32{
+		"ASSIGNEE_FILTER_UNSPECIFIED":    0,
+		"ASSIGNEE_FILTER_ASSIGNED_TO_ME": 1,
+		"ASSIGNEE_FILTER_UNASSIGNED":     2,
+	}
+)
+
+func (x AssigneeFilter) Enum() *AssigneeFilter {
+	p := new(AssigneeFilter)
+	*p = x
+	return p
+}
+
+func (x AssigneeFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssigneeFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_todoService_todo_proto_enumTypes[0].Descriptor()
+}
+
+func (AssigneeFilter) Type() protoreflect.EnumType {
+	return &file_todoService_todo_proto_enumTypes[0]
+}
+
+func (x AssigneeFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssigneeFilter.Descriptor instead.
+func (AssigneeFilter) EnumDescriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{0}
+}
+
+type TaskFormat int32
+
+const (
+	TaskFormat_TASK_FORMAT_UNSPECIFIED TaskFormat = 0
+	TaskFormat_TASK_FORMAT_JSONL       TaskFormat = 1
+	TaskFormat_TASK_FORMAT_CSV         TaskFormat = 2
+	TaskFormat_TASK_FORMAT_TODO_TXT    TaskFormat = 3
+	TaskFormat_TASK_FORMAT_ICALENDAR   TaskFormat = 4
+)
+
+// Enum value maps for TaskFormat.
+var (
+	TaskFormat_name = map[int32]string{
+		0: "TASK_FORMAT_UNSPECIFIED",
+		1: "TASK_FORMAT_JSONL",
+		2: "TASK_FORMAT_CSV",
+		3: "TASK_FORMAT_TODO_TXT",
+		4: "TASK_FORMAT_ICALENDAR",
+	}
+	TaskFormat_value = map[string]int32{
+		"TASK_FORMAT_UNSPECIFIED": 0,
+		"TASK_FORMAT_JSONL":       1,
+		"TASK_FORMAT_CSV":         2,
+		"TASK_FORMAT_TODO_TXT":    3,
+		"TASK_FORMAT_ICALENDAR":   4,
+	}
+)
+
+func (x TaskFormat) Enum() *TaskFormat {
+	p := new(TaskFormat)
+	*p = x
+	return p
+}
+
+func (x TaskFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_todoService_todo_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskFormat) Type() protoreflect.EnumType {
+	return &file_todoService_todo_proto_enumTypes[1]
+}
+
+func (x TaskFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskFormat.Descriptor instead.
+func (TaskFormat) EnumDescriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{1}
+}
+
+// DuplicatePolicy decides what happens to an imported task whose title
+// matches an existing task or an earlier row. Unspecified means SKIP.
+type DuplicatePolicy int32
+
+const (
+	DuplicatePolicy_DUPLICATE_POLICY_UNSPECIFIED DuplicatePolicy = 0
+	DuplicatePolicy_DUPLICATE_POLICY_ALLOW       DuplicatePolicy = 1
+	DuplicatePolicy_DUPLICATE_POLICY_SKIP        DuplicatePolicy = 2
+	DuplicatePolicy_DUPLICATE_POLICY_REJECT      DuplicatePolicy = 3
+)
+
+// Enum value maps for DuplicatePolicy.
+var (
+	DuplicatePolicy_name = map[int32]string{
+		0: "DUPLICATE_POLICY_UNSPECIFIED",
+		1: "DUPLICATE_POLICY_ALLOW",
+		2: "DUPLICATE_POLICY_SKIP",
+		3: "DUPLICATE_POLICY_REJECT",
+	}
+	DuplicatePolicy_value = map[string]int32{
+		"DUPLICATE_POLICY_UNSPECIFIED": 0,
+		"DUPLICATE_POLICY_ALLOW":       1,
+		"DUPLICATE_POLICY_SKIP":        2,
+		"DUPLICATE_POLICY_REJECT":      3,
+	}
+)
+
+func (x DuplicatePolicy) Enum() *DuplicatePolicy {
+	p := new(DuplicatePolicy)
+	*p = x
+	return p
+}
+
+func (x DuplicatePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DuplicatePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_todoService_todo_proto_enumTypes[2].Descriptor()
+}
+
+func (DuplicatePolicy) Type() protoreflect.EnumType {
+	return &file_todoService_todo_proto_enumTypes[2]
+}
+
+func (x DuplicatePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DuplicatePolicy.Descriptor instead.
+func (DuplicatePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{2}
+}
+
+type WorkspaceRole int32
+
+const (
+	WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED WorkspaceRole = 0
+	WorkspaceRole_WORKSPACE_ROLE_OWNER       WorkspaceRole = 1
+	WorkspaceRole_WORKSPACE_ROLE_ADMIN       WorkspaceRole = 2
+	WorkspaceRole_WORKSPACE_ROLE_EDITOR      WorkspaceRole = 3
+	WorkspaceRole_WORKSPACE_ROLE_VIEWER      WorkspaceRole = 4
+)
+
+// Enum value maps for WorkspaceRole.
+var (
+	WorkspaceRole_name = map[int32]string{
+		0: "WORKSPACE_ROLE_UNSPECIFIED",
+		1: "WORKSPACE_ROLE_OWNER",
+		2: "WORKSPACE_ROLE_ADMIN",
+		3: "WORKSPACE_ROLE_EDITOR",
+		4: "WORKSPACE_ROLE_VIEWER",
+	}
+	WorkspaceRole_value = map[string]int32{
+		"WORKSPACE_ROLE_UNSPECIFIED": 0,
+		"WORKSPACE_ROLE_OWNER":       1,
+		"WORKSPACE_ROLE_ADMIN":       2,
+		"WORKSPACE_ROLE_EDITOR":      3,
+		"WORKSPACE_ROLE_VIEWER":      4,
+	}
+)
+
+func (x WorkspaceRole) Enum() *WorkspaceRole {
+	p := new(WorkspaceRole)
+	*p = x
+	return p
+}
+
+func (x WorkspaceRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_todoService_todo_proto_enumTypes[3].Descriptor()
+}
+
+func (WorkspaceRole) Type() protoreflect.EnumType {
+	return &file_todoService_todo_proto_enumTypes[3]
+}
+
+func (x WorkspaceRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceRole.Descriptor instead.
+func (WorkspaceRole) EnumDescriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{3}
+}
+
+type ShareRole int32
+
+const (
+	ShareRole_SHARE_ROLE_UNSPECIFIED ShareRole = 0
+	ShareRole_SHARE_ROLE_VIEWER      ShareRole = 1
+	ShareRole_SHARE_ROLE_EDITOR      ShareRole = 2
+)
+
+// Enum value maps for ShareRole.
+var (
+	ShareRole_name = map[int32]string{
+		0: "SHARE_ROLE_UNSPECIFIED",
+		1: "SHARE_ROLE_VIEWER",
+		2: "SHARE_ROLE_EDITOR",
+	}
+	ShareRole_value = map[string]int32{
+		"SHARE_ROLE_UNSPECIFIED": 0,
+		"SHARE_ROLE_VIEWER":      1,
+		"SHARE_ROLE_EDITOR":      2,
+	}
+)
+
+func (x ShareRole) Enum() *ShareRole {
+	p := new(ShareRole)
+	*p = x
+	return p
+}
+
+func (x ShareRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShareRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_todoService_todo_proto_enumTypes[4].Descriptor()
+}
+
+func (ShareRole) Type() protoreflect.EnumType {
+	return &file_todoService_todo_proto_enumTypes[4]
+}
+
+func (x ShareRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShareRole.Descriptor instead.
+func (ShareRole) EnumDescriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{4}
+}
+
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Completed     bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdateAt      string                 `protobuf:"bytes,6,opt,name=update_at,json=updateAt,proto3" json:"update_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Completed   bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// 0 for personal tasks.
+	WorkspaceId   int64    `protobuf:"varint,7,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	AssigneeIds   []string `protobuf:"bytes,8,rep,name=assignee_ids,json=assigneeIds,proto3" json:"assignee_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,24 +357,40 @@ func (x *Task) GetCompleted() bool {
 	return false
 }
 
-func (x *Task) GetCreatedAt() string {
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *Task) GetUpdateAt() string {
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdateAt
+		return x.UpdatedAt
 	}
-	return ""
+	return nil
+}
+
+func (x *Task) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *Task) GetAssigneeIds() []string {
+	if x != nil {
+		return x.AssigneeIds
+	}
+	return nil
 }
 
 type CreateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Creates the task in a workspace the caller belongs to.
+	WorkspaceId   int64 `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -157,6 +439,13 @@ func (x *CreateTaskRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreateTaskRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
 type GetTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -202,8 +491,11 @@ func (x *GetTaskRequest) GetId() int64 {
 }
 
 type ListTasksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filter        string                 `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Filter         string                 `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	AssigneeFilter AssigneeFilter         `protobuf:"varint,2,opt,name=assignee_filter,json=assigneeFilter,proto3,enum=todoService.AssigneeFilter" json:"assignee_filter,omitempty"`
+	// Restricts the list to a workspace the caller belongs to.
+	WorkspaceId   int64 `protobuf:"varint,3,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -245,6 +537,20 @@ func (x *ListTasksRequest) GetFilter() string {
 	return ""
 }
 
+func (x *ListTasksRequest) GetAssigneeFilter() AssigneeFilter {
+	if x != nil {
+		return x.AssigneeFilter
+	}
+	return AssigneeFilter_ASSIGNEE_FILTER_UNSPECIFIED
+}
+
+func (x *ListTasksRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
 type ListTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
@@ -437,110 +743,4710 @@ func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
 	return file_todoService_todo_proto_rawDescGZIP(), []int{7}
 }
 
-var File_todoService_todo_proto protoreflect.FileDescriptor
+type ExportTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        TaskFormat             `protobuf:"varint,1,opt,name=format,proto3,enum=todoService.TaskFormat" json:"format,omitempty"`
+	Filter        string                 `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_todoService_todo_proto_rawDesc = "" +
-	"\n" +
-	"\x16todoService/todo.proto\x12\vtodoService\"\xa8\x01\n" +
-	"\x04Task\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
-	"\tcompleted\x18\x04 \x01(\bR\tcompleted\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1b\n" +
-	"\tupdate_at\x18\x06 \x01(\tR\bupdateAt\"K\n" +
-	"\x11CreateTaskRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\" \n" +
-	"\x0eGetTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"*\n" +
-	"\x10ListTasksRequest\x12\x16\n" +
-	"\x06filter\x18\x01 \x01(\tR\x06filter\"<\n" +
-	"\x11ListTasksResponse\x12'\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x11.todoService.TaskR\x05tasks\"\xb0\x01\n" +
-	"\x11UpdateTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
-	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12!\n" +
-	"\tcompleted\x18\x04 \x01(\bH\x02R\tcompleted\x88\x01\x01B\b\n" +
-	"\x06_titleB\x0e\n" +
-	"\f_descriptionB\f\n" +
-	"\n" +
-	"_completed\"#\n" +
-	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x14\n" +
-	"\x12DeleteTaskResponse2\xe5\x02\n" +
-	"\vTodoService\x12?\n" +
-	"\n" +
-	"CreateTask\x12\x1e.todoService.CreateTaskRequest\x1a\x11.todoService.Task\x129\n" +
-	"\aGetTask\x12\x1b.todoService.GetTaskRequest\x1a\x11.todoService.Task\x12J\n" +
-	"\tListTasks\x12\x1d.todoService.ListTasksRequest\x1a\x1e.todoService.ListTasksResponse\x12?\n" +
-	"\n" +
-	"UpdateTask\x12\x1e.todoService.UpdateTaskRequest\x1a\x11.todoService.Task\x12M\n" +
-	"\n" +
-	"DeleteTask\x12\x1e.todoService.DeleteTaskRequest\x1a\x1f.todoService.DeleteTaskResponseBIZGgithub.com/Elmar006/todo_grpc/backend/proto/gen/todoService;todoServiceb\x06proto3"
+func (x *ExportTasksRequest) Reset() {
+	*x = ExportTasksRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_todoService_todo_proto_rawDescOnce sync.Once
-	file_todoService_todo_proto_rawDescData []byte
-)
+func (x *ExportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_todoService_todo_proto_rawDescGZIP() []byte {
-	file_todoService_todo_proto_rawDescOnce.Do(func() {
-		file_todoService_todo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todoService_todo_proto_rawDesc), len(file_todoService_todo_proto_rawDesc)))
-	})
-	return file_todoService_todo_proto_rawDescData
+func (*ExportTasksRequest) ProtoMessage() {}
+
+func (x *ExportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_todoService_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_todoService_todo_proto_goTypes = []any{
-	(*Task)(nil),               // 0: todoService.Task
-	(*CreateTaskRequest)(nil),  // 1: todoService.CreateTaskRequest
-	(*GetTaskRequest)(nil),     // 2: todoService.GetTaskRequest
-	(*ListTasksRequest)(nil),   // 3: todoService.ListTasksRequest
-	(*ListTasksResponse)(nil),  // 4: todoService.ListTasksResponse
-	(*UpdateTaskRequest)(nil),  // 5: todoService.UpdateTaskRequest
-	(*DeleteTaskRequest)(nil),  // 6: todoService.DeleteTaskRequest
-	(*DeleteTaskResponse)(nil), // 7: todoService.DeleteTaskResponse
+// Deprecated: Use ExportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ExportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{8}
 }
-var file_todoService_todo_proto_depIdxs = []int32{
-	0, // 0: todoService.ListTasksResponse.tasks:type_name -> todoService.Task
-	1, // 1: todoService.TodoService.CreateTask:input_type -> todoService.CreateTaskRequest
-	2, // 2: todoService.TodoService.GetTask:input_type -> todoService.GetTaskRequest
-	3, // 3: todoService.TodoService.ListTasks:input_type -> todoService.ListTasksRequest
-	5, // 4: todoService.TodoService.UpdateTask:input_type -> todoService.UpdateTaskRequest
-	6, // 5: todoService.TodoService.DeleteTask:input_type -> todoService.DeleteTaskRequest
-	0, // 6: todoService.TodoService.CreateTask:output_type -> todoService.Task
-	0, // 7: todoService.TodoService.GetTask:output_type -> todoService.Task
-	4, // 8: todoService.TodoService.ListTasks:output_type -> todoService.ListTasksResponse
-	0, // 9: todoService.TodoService.UpdateTask:output_type -> todoService.Task
-	7, // 10: todoService.TodoService.DeleteTask:output_type -> todoService.DeleteTaskResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+func (x *ExportTasksRequest) GetFormat() TaskFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TaskFormat_TASK_FORMAT_UNSPECIFIED
 }
 
-func init() { file_todoService_todo_proto_init() }
-func file_todoService_todo_proto_init() {
-	if File_todoService_todo_proto != nil {
-		return
+func (x *ExportTasksRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type ExportTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTasksResponse) Reset() {
+	*x = ExportTasksResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTasksResponse) ProtoMessage() {}
+
+func (x *ExportTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTasksResponse.ProtoReflect.Descriptor instead.
+func (*ExportTasksResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportTasksResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportOptions struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Format TaskFormat             `protobuf:"varint,1,opt,name=format,proto3,enum=todoService.TaskFormat" json:"format,omitempty"`
+	DryRun bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Maps source fields (JSON keys or CSV header names) to task fields:
+	// title, description, completed.
+	FieldMapping  map[string]string `protobuf:"bytes,3,rep,name=field_mapping,json=fieldMapping,proto3" json:"field_mapping,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OnDuplicate   DuplicatePolicy   `protobuf:"varint,4,opt,name=on_duplicate,json=onDuplicate,proto3,enum=todoService.DuplicatePolicy" json:"on_duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_todoService_todo_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ImportOptions) GetFormat() TaskFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TaskFormat_TASK_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetFieldMapping() map[string]string {
+	if x != nil {
+		return x.FieldMapping
+	}
+	return nil
+}
+
+func (x *ImportOptions) GetOnDuplicate() DuplicatePolicy {
+	if x != nil {
+		return x.OnDuplicate
+	}
+	return DuplicatePolicy_DUPLICATE_POLICY_UNSPECIFIED
+}
+
+// The first message must carry options; the following ones carry the
+// file contents in order.
+type ImportTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportTasksRequest_Options
+	//	*ImportTasksRequest_Data
+	Payload       isImportTasksRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTasksRequest) Reset() {
+	*x = ImportTasksRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksRequest) ProtoMessage() {}
+
+func (x *ImportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ImportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ImportTasksRequest) GetPayload() isImportTasksRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportTasksRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportTasksRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportTasksRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportTasksRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isImportTasksRequest_Payload interface {
+	isImportTasksRequest_Payload()
+}
+
+type ImportTasksRequest_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportTasksRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*ImportTasksRequest_Options) isImportTasksRequest_Payload() {}
+
+func (*ImportTasksRequest_Data) isImportTasksRequest_Payload() {}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int64                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_todoService_todo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ImportRowError) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Imported      int64                  `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	Skipped       int64                  `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	DryRun        bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTasksResponse) Reset() {
+	*x = ImportTasksResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksResponse) ProtoMessage() {}
+
+func (x *ImportTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksResponse.ProtoReflect.Descriptor instead.
+func (*ImportTasksResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImportTasksResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetImported() int64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportTasksResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportTasksResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type GetCalendarFeedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replaces the secret feed URL; the previous one stops working.
+	Rotate        bool `protobuf:"varint,1,opt,name=rotate,proto3" json:"rotate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCalendarFeedRequest) Reset() {
+	*x = GetCalendarFeedRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarFeedRequest) ProtoMessage() {}
+
+func (x *GetCalendarFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetCalendarFeedRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetCalendarFeedRequest) GetRotate() bool {
+	if x != nil {
+		return x.Rotate
+	}
+	return false
+}
+
+// CalendarFeed is a read-only iCalendar feed of the caller's tasks,
+// served by the HTTP gateway at path. Anyone who knows the path can
+// read the feed.
+type CalendarFeed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarFeed) Reset() {
+	*x = CalendarFeed{}
+	mi := &file_todoService_todo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarFeed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarFeed) ProtoMessage() {}
+
+func (x *CalendarFeed) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarFeed.ProtoReflect.Descriptor instead.
+func (*CalendarFeed) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CalendarFeed) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// Webhook receives a signed JSON POST for each matching event:
+// task.created, task.updated, task.completed, task.deleted,
+// comment.added, comment.edited or comment.deleted.
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Empty means every event.
+	Events []string `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	// Returned only by CreateWebhook.
+	Secret        string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_todoService_todo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Webhook) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateWebhookRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Url    string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Events []string               `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	// Generated when empty.
+	Secret        string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{18}
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteWebhookRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{21}
+}
+
+type WebhookAttempt struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AttemptedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=attempted_at,json=attemptedAt,proto3" json:"attempted_at,omitempty"`
+	// Zero when no response was received.
+	StatusCode    int32  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookAttempt) Reset() {
+	*x = WebhookAttempt{}
+	mi := &file_todoService_todo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookAttempt) ProtoMessage() {}
+
+func (x *WebhookAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookAttempt.ProtoReflect.Descriptor instead.
+func (*WebhookAttempt) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WebhookAttempt) GetAttemptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedAt
+	}
+	return nil
+}
+
+func (x *WebhookAttempt) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookAttempt) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type WebhookDelivery struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventId   string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// pending, delivered or dead.
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts      int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Log           []*WebhookAttempt      `protobuf:"bytes,9,rep,name=log,proto3" json:"log,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_todoService_todo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLog() []*WebhookAttempt {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Defaults to 50.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() int64 {
+	if x != nil {
+		return x.WebhookId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+// Puts a delivery, typically a dead one, back into the queue.
+type RetryWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryWebhookDeliveryRequest) Reset() {
+	*x = RetryWebhookDeliveryRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *RetryWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*RetryWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RetryWebhookDeliveryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// FieldChange holds the old and new value of a task field. old_value is
+// unset for created tasks and new_value for deleted ones.
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      *string                `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3,oneof" json:"old_value,omitempty"`
+	NewValue      *string                `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3,oneof" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_todoService_todo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() string {
+	if x != nil && x.OldValue != nil {
+		return *x.OldValue
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNewValue() string {
+	if x != nil && x.NewValue != nil {
+		return *x.NewValue
+	}
+	return ""
+}
+
+type TaskChange struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// created, updated or deleted.
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskChange) Reset() {
+	*x = TaskChange{}
+	mi := &file_todoService_todo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskChange) ProtoMessage() {}
+
+func (x *TaskChange) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskChange.ProtoReflect.Descriptor instead.
+func (*TaskChange) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TaskChange) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskChange) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TaskChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TaskChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *TaskChange) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetTaskHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Defaults to 50, at most 500.
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryRequest) Reset() {
+	*x = GetTaskHistoryRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryRequest) ProtoMessage() {}
+
+func (x *GetTaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetTaskHistoryRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetTaskHistoryResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Changes []*TaskChange          `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryResponse) Reset() {
+	*x = GetTaskHistoryResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTaskHistoryResponse) GetChanges() []*TaskChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Lists changes to all tasks made in [start_time, end_time).
+type ListTaskChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskChangesRequest) Reset() {
+	*x = ListTaskChangesRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskChangesRequest) ProtoMessage() {}
+
+func (x *ListTaskChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskChangesRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTaskChangesRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListTaskChangesRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListTaskChangesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTaskChangesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTaskChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*TaskChange          `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskChangesResponse) Reset() {
+	*x = ListTaskChangesResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskChangesResponse) ProtoMessage() {}
+
+func (x *ListTaskChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskChangesResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListTaskChangesResponse) GetChanges() []*TaskChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListTaskChangesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// TaskRevision is a snapshot of a task taken after a change.
+type TaskRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	RevisedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=revised_at,json=revisedAt,proto3" json:"revised_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskRevision) Reset() {
+	*x = TaskRevision{}
+	mi := &file_todoService_todo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRevision) ProtoMessage() {}
+
+func (x *TaskRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRevision.ProtoReflect.Descriptor instead.
+func (*TaskRevision) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TaskRevision) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *TaskRevision) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskRevision) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TaskRevision) GetRevisedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevisedAt
+	}
+	return nil
+}
+
+type ListTaskRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskRevisionsRequest) Reset() {
+	*x = ListTaskRevisionsRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskRevisionsRequest) ProtoMessage() {}
+
+func (x *ListTaskRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListTaskRevisionsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListTaskRevisionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Revisions     []*TaskRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskRevisionsResponse) Reset() {
+	*x = ListTaskRevisionsResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskRevisionsResponse) ProtoMessage() {}
+
+func (x *ListTaskRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListTaskRevisionsResponse) GetRevisions() []*TaskRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// Sets the title, description and completion of a task back to a revision.
+// A deleted task is recreated. The revert is saved as a new revision.
+type RevertTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Revision      int64                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertTaskRequest) Reset() {
+	*x = RevertTaskRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertTaskRequest) ProtoMessage() {}
+
+func (x *RevertTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertTaskRequest.ProtoReflect.Descriptor instead.
+func (*RevertTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RevertTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RevertTaskRequest) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// Recreates a deleted task from its last revision.
+type RestoreTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RestoreTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+// Comment is a note on a task. The body is Markdown source.
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_todoService_todo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Comment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AddCommentRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type ListCommentsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Defaults to 50, at most 500.
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListCommentsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Comments []*Comment `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Only the author may edit or delete a comment.
+type EditCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditCommentRequest) Reset() {
+	*x = EditCommentRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditCommentRequest) ProtoMessage() {}
+
+func (x *EditCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditCommentRequest.ProtoReflect.Descriptor instead.
+func (*EditCommentRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *EditCommentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EditCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DeleteCommentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentResponse) Reset() {
+	*x = DeleteCommentResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentResponse) ProtoMessage() {}
+
+func (x *DeleteCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{44}
+}
+
+type Attachment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId      int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Filename    string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// Hex-encoded SHA-256 of the contents.
+	Sha256        string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Uploader      string                 `protobuf:"bytes,7,opt,name=uploader,proto3" json:"uploader,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_todoService_todo_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Attachment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Attachment) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetUploader() string {
+	if x != nil {
+		return x.Uploader
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// AttachmentInfo describes an upload. size and sha256 are optional; when
+// set, the upload fails unless the received contents match them.
+type AttachmentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentInfo) Reset() {
+	*x = AttachmentInfo{}
+	mi := &file_todoService_todo_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentInfo) ProtoMessage() {}
+
+func (x *AttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentInfo.ProtoReflect.Descriptor instead.
+func (*AttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AttachmentInfo) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+// The first message carries info, the following ones the contents.
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadAttachmentRequest_Info
+	//	*UploadAttachmentRequest_Data
+	Payload       isUploadAttachmentRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UploadAttachmentRequest) GetPayload() isUploadAttachmentRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetInfo() *AttachmentInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Payload interface {
+	isUploadAttachmentRequest_Payload()
+}
+
+type UploadAttachmentRequest_Info struct {
+	Info *AttachmentInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Info) isUploadAttachmentRequest_Payload() {}
+
+func (*UploadAttachmentRequest_Data) isUploadAttachmentRequest_Payload() {}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DownloadAttachmentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// The first message carries the attachment, the following ones the
+// contents. The stream fails with DATA_LOSS if the stored contents do not
+// match the checksum.
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Data
+	Payload       isDownloadAttachmentResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DownloadAttachmentResponse) GetPayload() isDownloadAttachmentResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Payload interface {
+	isDownloadAttachmentResponse_Payload()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Payload() {}
+
+func (*DownloadAttachmentResponse_Data) isDownloadAttachmentResponse_Payload() {}
+
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListAttachmentsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteAttachmentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentResponse) Reset() {
+	*x = DeleteAttachmentResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentResponse) ProtoMessage() {}
+
+func (x *DeleteAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{53}
+}
+
+type Workspace struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedBy string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The caller's role in the workspace.
+	Role          WorkspaceRole `protobuf:"varint,5,opt,name=role,proto3,enum=todoService.WorkspaceRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_todoService_todo_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Workspace) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Workspace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workspace) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Workspace) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Workspace) GetRole() WorkspaceRole {
+	if x != nil {
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+}
+
+type CreateWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CreateWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListWorkspacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesRequest) Reset() {
+	*x = ListWorkspacesRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesRequest) ProtoMessage() {}
+
+func (x *ListWorkspacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{56}
+}
+
+type ListWorkspacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspaces    []*Workspace           `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListWorkspacesResponse) GetWorkspaces() []*Workspace {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+type WorkspaceMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Member        string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=todoService.WorkspaceRole" json:"role,omitempty"`
+	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceMember) Reset() {
+	*x = WorkspaceMember{}
+	mi := &file_todoService_todo_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceMember) ProtoMessage() {}
+
+func (x *WorkspaceMember) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceMember.ProtoReflect.Descriptor instead.
+func (*WorkspaceMember) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *WorkspaceMember) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *WorkspaceMember) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *WorkspaceMember) GetRole() WorkspaceRole {
+	if x != nil {
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+}
+
+func (x *WorkspaceMember) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+type ListWorkspaceMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceMembersRequest) Reset() {
+	*x = ListWorkspaceMembersRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceMembersRequest) ProtoMessage() {}
+
+func (x *ListWorkspaceMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListWorkspaceMembersRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+type ListWorkspaceMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*WorkspaceMember     `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspaceMembersResponse) Reset() {
+	*x = ListWorkspaceMembersResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspaceMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspaceMembersResponse) ProtoMessage() {}
+
+func (x *ListWorkspaceMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspaceMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspaceMembersResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListWorkspaceMembersResponse) GetMembers() []*WorkspaceMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Adds a member or changes their role. Owners and admins manage members;
+// only owners grant or revoke the owner role.
+type SetWorkspaceMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Member        string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	Role          WorkspaceRole          `protobuf:"varint,3,opt,name=role,proto3,enum=todoService.WorkspaceRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWorkspaceMemberRequest) Reset() {
+	*x = SetWorkspaceMemberRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWorkspaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWorkspaceMemberRequest) ProtoMessage() {}
+
+func (x *SetWorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*SetWorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SetWorkspaceMemberRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *SetWorkspaceMemberRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *SetWorkspaceMemberRequest) GetRole() WorkspaceRole {
+	if x != nil {
+		return x.Role
+	}
+	return WorkspaceRole_WORKSPACE_ROLE_UNSPECIFIED
+}
+
+// Members may always remove themselves. A workspace keeps at least one
+// owner.
+type RemoveWorkspaceMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   int64                  `protobuf:"varint,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Member        string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWorkspaceMemberRequest) Reset() {
+	*x = RemoveWorkspaceMemberRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWorkspaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWorkspaceMemberRequest) ProtoMessage() {}
+
+func (x *RemoveWorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *RemoveWorkspaceMemberRequest) GetWorkspaceId() int64 {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return 0
+}
+
+func (x *RemoveWorkspaceMemberRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+type RemoveWorkspaceMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWorkspaceMemberResponse) Reset() {
+	*x = RemoveWorkspaceMemberResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWorkspaceMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWorkspaceMemberResponse) ProtoMessage() {}
+
+func (x *RemoveWorkspaceMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWorkspaceMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveWorkspaceMemberResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{63}
+}
+
+// Assignees of a workspace task must be members of the workspace; a
+// personal task can only be assigned to its owner.
+type AssignTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTaskRequest) Reset() {
+	*x = AssignTaskRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskRequest) ProtoMessage() {}
+
+func (x *AssignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskRequest.ProtoReflect.Descriptor instead.
+func (*AssignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AssignTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AssignTaskRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type UnassignTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignTaskRequest) Reset() {
+	*x = UnassignTaskRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignTaskRequest) ProtoMessage() {}
+
+func (x *UnassignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignTaskRequest.ProtoReflect.Descriptor instead.
+func (*UnassignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UnassignTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *UnassignTaskRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// TaskShare grants a user a role on a single task.
+type TaskShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Role          ShareRole              `protobuf:"varint,3,opt,name=role,proto3,enum=todoService.ShareRole" json:"role,omitempty"`
+	GrantedBy     string                 `protobuf:"bytes,4,opt,name=granted_by,json=grantedBy,proto3" json:"granted_by,omitempty"`
+	GrantedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=granted_at,json=grantedAt,proto3" json:"granted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskShare) Reset() {
+	*x = TaskShare{}
+	mi := &file_todoService_todo_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskShare) ProtoMessage() {}
+
+func (x *TaskShare) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskShare.ProtoReflect.Descriptor instead.
+func (*TaskShare) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *TaskShare) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskShare) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *TaskShare) GetRole() ShareRole {
+	if x != nil {
+		return x.Role
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+func (x *TaskShare) GetGrantedBy() string {
+	if x != nil {
+		return x.GrantedBy
+	}
+	return ""
+}
+
+func (x *TaskShare) GetGrantedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GrantedAt
+	}
+	return nil
+}
+
+// Replaces an earlier share with the same user.
+type ShareTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Role          ShareRole              `protobuf:"varint,3,opt,name=role,proto3,enum=todoService.ShareRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareTaskRequest) Reset() {
+	*x = ShareTaskRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareTaskRequest) ProtoMessage() {}
+
+func (x *ShareTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareTaskRequest.ProtoReflect.Descriptor instead.
+func (*ShareTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ShareTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ShareTaskRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ShareTaskRequest) GetRole() ShareRole {
+	if x != nil {
+		return x.Role
+	}
+	return ShareRole_SHARE_ROLE_UNSPECIFIED
+}
+
+type UnshareTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	User          string                 `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTaskRequest) Reset() {
+	*x = UnshareTaskRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTaskRequest) ProtoMessage() {}
+
+func (x *UnshareTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTaskRequest.ProtoReflect.Descriptor instead.
+func (*UnshareTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *UnshareTaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *UnshareTaskRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type UnshareTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareTaskResponse) Reset() {
+	*x = UnshareTaskResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareTaskResponse) ProtoMessage() {}
+
+func (x *UnshareTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareTaskResponse.ProtoReflect.Descriptor instead.
+func (*UnshareTaskResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{69}
+}
+
+type ListTaskSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskSharesRequest) Reset() {
+	*x = ListTaskSharesRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskSharesRequest) ProtoMessage() {}
+
+func (x *ListTaskSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskSharesRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListTaskSharesRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListTaskSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*TaskShare           `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskSharesResponse) Reset() {
+	*x = ListTaskSharesResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskSharesResponse) ProtoMessage() {}
+
+func (x *ListTaskSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskSharesResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListTaskSharesResponse) GetShares() []*TaskShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+// ShareLink grants read-only access to one task to anyone holding the
+// token until it expires or is revoked.
+type ShareLink struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Only set in the CreateShareLink response.
+	Token     string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	CreatedBy string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Unset unless the link was revoked.
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_todoService_todo_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ShareLink) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShareLink) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ShareLink) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ShareLink) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ShareLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type CreateShareLinkRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Defaults to 7 days, at most 90 days.
+	TtlSeconds    int64 `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CreateShareLinkRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type ListShareLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksRequest) ProtoMessage() {}
+
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListShareLinksRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListShareLinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first, including expired and revoked links.
+	Links         []*ShareLink `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_todoService_todo_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListShareLinksResponse) GetLinks() []*ShareLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type RevokeShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkRequest) ProtoMessage() {}
+
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RevokeShareLinkRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetSharedTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedTaskRequest) Reset() {
+	*x = GetSharedTaskRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedTaskRequest) ProtoMessage() {}
+
+func (x *GetSharedTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedTaskRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetSharedTaskRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type BackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupRequest) Reset() {
+	*x = BackupRequest{}
+	mi := &file_todoService_todo_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRequest) ProtoMessage() {}
+
+func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupRequest.ProtoReflect.Descriptor instead.
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{78}
+}
+
+// BackupSnapshot is a consistent copy of the database written on the
+// server. A file <name>.sha256 next to it holds the checksum.
+type BackupSnapshot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupSnapshot) Reset() {
+	*x = BackupSnapshot{}
+	mi := &file_todoService_todo_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupSnapshot) ProtoMessage() {}
+
+func (x *BackupSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_todoService_todo_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupSnapshot.ProtoReflect.Descriptor instead.
+func (*BackupSnapshot) Descriptor() ([]byte, []int) {
+	return file_todoService_todo_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *BackupSnapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BackupSnapshot) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *BackupSnapshot) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *BackupSnapshot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_todoService_todo_proto protoreflect.FileDescriptor
+
+const file_todoService_todo_proto_rawDesc = "" +
+	"\n" +
+	"\x16todoService/todo.proto\x12\vtodoService\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x02\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tcompleted\x18\x04 \x01(\bR\tcompleted\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fworkspace_id\x18\a \x01(\x03R\vworkspaceId\x12!\n" +
+	"\fassignee_ids\x18\b \x03(\tR\vassigneeIds\"n\n" +
+	"\x11CreateTaskRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12!\n" +
+	"\fworkspace_id\x18\x03 \x01(\x03R\vworkspaceId\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x93\x01\n" +
+	"\x10ListTasksRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\x12D\n" +
+	"\x0fassignee_filter\x18\x02 \x01(\x0e2\x1b.todoService.AssigneeFilterR\x0eassigneeFilter\x12!\n" +
+	"\fworkspace_id\x18\x03 \x01(\x03R\vworkspaceId\"<\n" +
+	"\x11ListTasksResponse\x12'\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x11.todoService.TaskR\x05tasks\"\xb0\x01\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12!\n" +
+	"\tcompleted\x18\x04 \x01(\bH\x02R\tcompleted\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_completed\"#\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x14\n" +
+	"\x12DeleteTaskResponse\"]\n" +
+	"\x12ExportTasksRequest\x12/\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x17.todoService.TaskFormatR\x06format\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\")\n" +
+	"\x13ExportTasksResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xae\x02\n" +
+	"\rImportOptions\x12/\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x17.todoService.TaskFormatR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12Q\n" +
+	"\rfield_mapping\x18\x03 \x03(\v2,.todoService.ImportOptions.FieldMappingEntryR\ffieldMapping\x12?\n" +
+	"\fon_duplicate\x18\x04 \x01(\x0e2\x1c.todoService.DuplicatePolicyR\vonDuplicate\x1a?\n" +
+	"\x11FieldMappingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"m\n" +
+	"\x12ImportTasksRequest\x126\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1a.todoService.ImportOptionsH\x00R\aoptions\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\apayload\"<\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xaf\x01\n" +
+	"\x13ImportTasksResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x03R\bimported\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x03R\askipped\x123\n" +
+	"\x06errors\x18\x04 \x03(\v2\x1b.todoService.ImportRowErrorR\x06errors\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"0\n" +
+	"\x16GetCalendarFeedRequest\x12\x16\n" +
+	"\x06rotate\x18\x01 \x01(\bR\x06rotate\"\"\n" +
+	"\fCalendarFeed\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"\x96\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x03 \x03(\tR\x06events\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"X\n" +
+	"\x14CreateWebhookRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06events\x18\x02 \x03(\tR\x06events\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\"\x15\n" +
+	"\x13ListWebhooksRequest\"H\n" +
+	"\x14ListWebhooksResponse\x120\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x14.todoService.WebhookR\bwebhooks\"&\n" +
+	"\x14DeleteWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x17\n" +
+	"\x15DeleteWebhookResponse\"\xa7\x01\n" +
+	"\x0eWebhookAttempt\x12=\n" +
+	"\fattempted_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vattemptedAt\x12\x1f\n" +
+	"\vstatus_code\x18\x02 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\"\xdc\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x03R\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12B\n" +
+	"\x0fnext_attempt_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12-\n" +
+	"\x03log\x18\t \x03(\v2\x1b.todoService.WebhookAttemptR\x03log\"S\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"]\n" +
+	"\x1dListWebhookDeliveriesResponse\x12<\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1c.todoService.WebhookDeliveryR\n" +
+	"deliveries\"-\n" +
+	"\x1bRetryWebhookDeliveryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x83\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
+	"\told_value\x18\x02 \x01(\tH\x00R\boldValue\x88\x01\x01\x12 \n" +
+	"\tnew_value\x18\x03 \x01(\tH\x01R\bnewValue\x88\x01\x01B\f\n" +
+	"\n" +
+	"_old_valueB\f\n" +
+	"\n" +
+	"_new_value\"\xd2\x01\n" +
+	"\n" +
+	"TaskChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x129\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x122\n" +
+	"\achanges\x18\x06 \x03(\v2\x18.todoService.FieldChangeR\achanges\"l\n" +
+	"\x15GetTaskHistoryRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"s\n" +
+	"\x16GetTaskHistoryResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.todoService.TaskChangeR\achanges\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc6\x01\n" +
+	"\x16ListTaskChangesRequest\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"t\n" +
+	"\x17ListTaskChangesResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.todoService.TaskChangeR\achanges\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa2\x01\n" +
+	"\fTaskRevision\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x12%\n" +
+	"\x04task\x18\x02 \x01(\v2\x11.todoService.TaskR\x04task\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x129\n" +
+	"\n" +
+	"revised_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\trevisedAt\"3\n" +
+	"\x18ListTaskRevisionsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"T\n" +
+	"\x19ListTaskRevisionsResponse\x127\n" +
+	"\trevisions\x18\x01 \x03(\v2\x19.todoService.TaskRevisionR\trevisions\"H\n" +
+	"\x11RevertTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision\"-\n" +
+	"\x12RestoreTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"\xd4\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"@\n" +
+	"\x11AddCommentRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\"j\n" +
+	"\x13ListCommentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"p\n" +
+	"\x14ListCommentsResponse\x120\n" +
+	"\bcomments\x18\x01 \x03(\v2\x14.todoService.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"8\n" +
+	"\x12EditCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\"&\n" +
+	"\x14DeleteCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x17\n" +
+	"\x15DeleteCommentResponse\"\xf7\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12\x1a\n" +
+	"\buploader\x18\a \x01(\tR\buploader\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x94\x01\n" +
+	"\x0eAttachmentInfo\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\"m\n" +
+	"\x17UploadAttachmentRequest\x121\n" +
+	"\x04info\x18\x01 \x01(\v2\x1b.todoService.AttachmentInfoH\x00R\x04info\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\apayload\"+\n" +
+	"\x19DownloadAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"x\n" +
+	"\x1aDownloadAttachmentResponse\x129\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x17.todoService.AttachmentH\x00R\n" +
+	"attachment\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\apayload\"1\n" +
+	"\x16ListAttachmentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"T\n" +
+	"\x17ListAttachmentsResponse\x129\n" +
+	"\vattachments\x18\x01 \x03(\v2\x17.todoService.AttachmentR\vattachments\")\n" +
+	"\x17DeleteAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1a\n" +
+	"\x18DeleteAttachmentResponse\"\xb9\x01\n" +
+	"\tWorkspace\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12.\n" +
+	"\x04role\x18\x05 \x01(\x0e2\x1a.todoService.WorkspaceRoleR\x04role\",\n" +
+	"\x16CreateWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x17\n" +
+	"\x15ListWorkspacesRequest\"P\n" +
+	"\x16ListWorkspacesResponse\x126\n" +
+	"\n" +
+	"workspaces\x18\x01 \x03(\v2\x16.todoService.WorkspaceR\n" +
+	"workspaces\"\xb3\x01\n" +
+	"\x0fWorkspaceMember\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\x12.\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1a.todoService.WorkspaceRoleR\x04role\x125\n" +
+	"\badded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\"@\n" +
+	"\x1bListWorkspaceMembersRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\"V\n" +
+	"\x1cListWorkspaceMembersResponse\x126\n" +
+	"\amembers\x18\x01 \x03(\v2\x1c.todoService.WorkspaceMemberR\amembers\"\x86\x01\n" +
+	"\x19SetWorkspaceMemberRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\x12.\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1a.todoService.WorkspaceRoleR\x04role\"Y\n" +
+	"\x1cRemoveWorkspaceMemberRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\x03R\vworkspaceId\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\"\x1f\n" +
+	"\x1dRemoveWorkspaceMemberResponse\"G\n" +
+	"\x11AssignTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"I\n" +
+	"\x13UnassignTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"\xbe\x01\n" +
+	"\tTaskShare\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12*\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x16.todoService.ShareRoleR\x04role\x12\x1d\n" +
+	"\n" +
+	"granted_by\x18\x04 \x01(\tR\tgrantedBy\x129\n" +
+	"\n" +
+	"granted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tgrantedAt\"k\n" +
+	"\x10ShareTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12*\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x16.todoService.ShareRoleR\x04role\"A\n" +
+	"\x12UnshareTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\"\x15\n" +
+	"\x13UnshareTaskResponse\"0\n" +
+	"\x15ListTaskSharesRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"H\n" +
+	"\x16ListTaskSharesResponse\x12.\n" +
+	"\x06shares\x18\x01 \x03(\v2\x16.todoService.TaskShareR\x06shares\"\x9a\x02\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"R\n" +
+	"\x16CreateShareLinkRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1f\n" +
+	"\vttl_seconds\x18\x02 \x01(\x03R\n" +
+	"ttlSeconds\"0\n" +
+	"\x15ListShareLinksRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"F\n" +
+	"\x16ListShareLinksResponse\x12,\n" +
+	"\x05links\x18\x01 \x03(\v2\x16.todoService.ShareLinkR\x05links\"(\n" +
+	"\x16RevokeShareLinkRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\",\n" +
+	"\x14GetSharedTaskRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x0f\n" +
+	"\rBackupRequest\"\x96\x01\n" +
+	"\x0eBackupSnapshot\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x02 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*u\n" +
+	"\x0eAssigneeFilter\x12\x1f\n" +
+	"\x1bASSIGNEE_FILTER_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eASSIGNEE_FILTER_ASSIGNED_TO_ME\x10\x01\x12\x1e\n" +
+	"\x1aASSIGNEE_FILTER_UNASSIGNED\x10\x02*\x8a\x01\n" +
+	"\n" +
+	"TaskFormat\x12\x1b\n" +
+	"\x17TASK_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TASK_FORMAT_JSONL\x10\x01\x12\x13\n" +
+	"\x0fTASK_FORMAT_CSV\x10\x02\x12\x18\n" +
+	"\x14TASK_FORMAT_TODO_TXT\x10\x03\x12\x19\n" +
+	"\x15TASK_FORMAT_ICALENDAR\x10\x04*\x87\x01\n" +
+	"\x0fDuplicatePolicy\x12 \n" +
+	"\x1cDUPLICATE_POLICY_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16DUPLICATE_POLICY_ALLOW\x10\x01\x12\x19\n" +
+	"\x15DUPLICATE_POLICY_SKIP\x10\x02\x12\x1b\n" +
+	"\x17DUPLICATE_POLICY_REJECT\x10\x03*\x99\x01\n" +
+	"\rWorkspaceRole\x12\x1e\n" +
+	"\x1aWORKSPACE_ROLE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14WORKSPACE_ROLE_OWNER\x10\x01\x12\x18\n" +
+	"\x14WORKSPACE_ROLE_ADMIN\x10\x02\x12\x19\n" +
+	"\x15WORKSPACE_ROLE_EDITOR\x10\x03\x12\x19\n" +
+	"\x15WORKSPACE_ROLE_VIEWER\x10\x04*U\n" +
+	"\tShareRole\x12\x1a\n" +
+	"\x16SHARE_ROLE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11SHARE_ROLE_VIEWER\x10\x01\x12\x15\n" +
+	"\x11SHARE_ROLE_EDITOR\x10\x022\xdc\x1a\n" +
+	"\vTodoService\x12?\n" +
+	"\n" +
+	"CreateTask\x12\x1e.todoService.CreateTaskRequest\x1a\x11.todoService.Task\x129\n" +
+	"\aGetTask\x12\x1b.todoService.GetTaskRequest\x1a\x11.todoService.Task\x12J\n" +
+	"\tListTasks\x12\x1d.todoService.ListTasksRequest\x1a\x1e.todoService.ListTasksResponse\x12?\n" +
+	"\n" +
+	"UpdateTask\x12\x1e.todoService.UpdateTaskRequest\x1a\x11.todoService.Task\x12M\n" +
+	"\n" +
+	"DeleteTask\x12\x1e.todoService.DeleteTaskRequest\x1a\x1f.todoService.DeleteTaskResponse\x12R\n" +
+	"\vExportTasks\x12\x1f.todoService.ExportTasksRequest\x1a .todoService.ExportTasksResponse0\x01\x12R\n" +
+	"\vImportTasks\x12\x1f.todoService.ImportTasksRequest\x1a .todoService.ImportTasksResponse(\x01\x12Q\n" +
+	"\x0fGetCalendarFeed\x12#.todoService.GetCalendarFeedRequest\x1a\x19.todoService.CalendarFeed\x12H\n" +
+	"\rCreateWebhook\x12!.todoService.CreateWebhookRequest\x1a\x14.todoService.Webhook\x12S\n" +
+	"\fListWebhooks\x12 .todoService.ListWebhooksRequest\x1a!.todoService.ListWebhooksResponse\x12V\n" +
+	"\rDeleteWebhook\x12!.todoService.DeleteWebhookRequest\x1a\".todoService.DeleteWebhookResponse\x12n\n" +
+	"\x15ListWebhookDeliveries\x12).todoService.ListWebhookDeliveriesRequest\x1a*.todoService.ListWebhookDeliveriesResponse\x12^\n" +
+	"\x14RetryWebhookDelivery\x12(.todoService.RetryWebhookDeliveryRequest\x1a\x1c.todoService.WebhookDelivery\x12Y\n" +
+	"\x0eGetTaskHistory\x12\".todoService.GetTaskHistoryRequest\x1a#.todoService.GetTaskHistoryResponse\x12\\\n" +
+	"\x0fListTaskChanges\x12#.todoService.ListTaskChangesRequest\x1a$.todoService.ListTaskChangesResponse\x12b\n" +
+	"\x11ListTaskRevisions\x12%.todoService.ListTaskRevisionsRequest\x1a&.todoService.ListTaskRevisionsResponse\x12?\n" +
+	"\n" +
+	"RevertTask\x12\x1e.todoService.RevertTaskRequest\x1a\x11.todoService.Task\x12A\n" +
+	"\vRestoreTask\x12\x1f.todoService.RestoreTaskRequest\x1a\x11.todoService.Task\x12B\n" +
+	"\n" +
+	"AddComment\x12\x1e.todoService.AddCommentRequest\x1a\x14.todoService.Comment\x12S\n" +
+	"\fListComments\x12 .todoService.ListCommentsRequest\x1a!.todoService.ListCommentsResponse\x12D\n" +
+	"\vEditComment\x12\x1f.todoService.EditCommentRequest\x1a\x14.todoService.Comment\x12V\n" +
+	"\rDeleteComment\x12!.todoService.DeleteCommentRequest\x1a\".todoService.DeleteCommentResponse\x12S\n" +
+	"\x10UploadAttachment\x12$.todoService.UploadAttachmentRequest\x1a\x17.todoService.Attachment(\x01\x12g\n" +
+	"\x12DownloadAttachment\x12&.todoService.DownloadAttachmentRequest\x1a'.todoService.DownloadAttachmentResponse0\x01\x12\\\n" +
+	"\x0fListAttachments\x12#.todoService.ListAttachmentsRequest\x1a$.todoService.ListAttachmentsResponse\x12_\n" +
+	"\x10DeleteAttachment\x12$.todoService.DeleteAttachmentRequest\x1a%.todoService.DeleteAttachmentResponse\x12N\n" +
+	"\x0fCreateWorkspace\x12#.todoService.CreateWorkspaceRequest\x1a\x16.todoService.Workspace\x12Y\n" +
+	"\x0eListWorkspaces\x12\".todoService.ListWorkspacesRequest\x1a#.todoService.ListWorkspacesResponse\x12k\n" +
+	"\x14ListWorkspaceMembers\x12(.todoService.ListWorkspaceMembersRequest\x1a).todoService.ListWorkspaceMembersResponse\x12Z\n" +
+	"\x12SetWorkspaceMember\x12&.todoService.SetWorkspaceMemberRequest\x1a\x1c.todoService.WorkspaceMember\x12n\n" +
+	"\x15RemoveWorkspaceMember\x12).todoService.RemoveWorkspaceMemberRequest\x1a*.todoService.RemoveWorkspaceMemberResponse\x12?\n" +
+	"\n" +
+	"AssignTask\x12\x1e.todoService.AssignTaskRequest\x1a\x11.todoService.Task\x12C\n" +
+	"\fUnassignTask\x12 .todoService.UnassignTaskRequest\x1a\x11.todoService.Task\x12B\n" +
+	"\tShareTask\x12\x1d.todoService.ShareTaskRequest\x1a\x16.todoService.TaskShare\x12P\n" +
+	"\vUnshareTask\x12\x1f.todoService.UnshareTaskRequest\x1a .todoService.UnshareTaskResponse\x12Y\n" +
+	"\x0eListTaskShares\x12\".todoService.ListTaskSharesRequest\x1a#.todoService.ListTaskSharesResponse\x12N\n" +
+	"\x0fCreateShareLink\x12#.todoService.CreateShareLinkRequest\x1a\x16.todoService.ShareLink\x12Y\n" +
+	"\x0eListShareLinks\x12\".todoService.ListShareLinksRequest\x1a#.todoService.ListShareLinksResponse\x12N\n" +
+	"\x0fRevokeShareLink\x12#.todoService.RevokeShareLinkRequest\x1a\x16.todoService.ShareLink\x12E\n" +
+	"\rGetSharedTask\x12!.todoService.GetSharedTaskRequest\x1a\x11.todoService.Task\x12A\n" +
+	"\x06Backup\x12\x1a.todoService.BackupRequest\x1a\x1b.todoService.BackupSnapshotBIZGgithub.com/Elmar006/todo_grpc/backend/proto/gen/todoService;todoServiceb\x06proto3"
+
+var (
+	file_todoService_todo_proto_rawDescOnce sync.Once
+	file_todoService_todo_proto_rawDescData []byte
+)
+
+func file_todoService_todo_proto_rawDescGZIP() []byte {
+	file_todoService_todo_proto_rawDescOnce.Do(func() {
+		file_todoService_todo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_todoService_todo_proto_rawDesc), len(file_todoService_todo_proto_rawDesc)))
+	})
+	return file_todoService_todo_proto_rawDescData
+}
+
+var file_todoService_todo_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_todoService_todo_proto_msgTypes = make([]protoimpl.MessageInfo, 81)
+var file_todoService_todo_proto_goTypes = []any{
+	(AssigneeFilter)(0),                   // 0: todoService.AssigneeFilter
+	(TaskFormat)(0),                       // 1: todoService.TaskFormat
+	(DuplicatePolicy)(0),                  // 2: todoService.DuplicatePolicy
+	(WorkspaceRole)(0),                    // 3: todoService.WorkspaceRole
+	(ShareRole)(0),                        // 4: todoService.ShareRole
+	(*Task)(nil),                          // 5: todoService.Task
+	(*CreateTaskRequest)(nil),             // 6: todoService.CreateTaskRequest
+	(*GetTaskRequest)(nil),                // 7: todoService.GetTaskRequest
+	(*ListTasksRequest)(nil),              // 8: todoService.ListTasksRequest
+	(*ListTasksResponse)(nil),             // 9: todoService.ListTasksResponse
+	(*UpdateTaskRequest)(nil),             // 10: todoService.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),             // 11: todoService.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),            // 12: todoService.DeleteTaskResponse
+	(*ExportTasksRequest)(nil),            // 13: todoService.ExportTasksRequest
+	(*ExportTasksResponse)(nil),           // 14: todoService.ExportTasksResponse
+	(*ImportOptions)(nil),                 // 15: todoService.ImportOptions
+	(*ImportTasksRequest)(nil),            // 16: todoService.ImportTasksRequest
+	(*ImportRowError)(nil),                // 17: todoService.ImportRowError
+	(*ImportTasksResponse)(nil),           // 18: todoService.ImportTasksResponse
+	(*GetCalendarFeedRequest)(nil),        // 19: todoService.GetCalendarFeedRequest
+	(*CalendarFeed)(nil),                  // 20: todoService.CalendarFeed
+	(*Webhook)(nil),                       // 21: todoService.Webhook
+	(*CreateWebhookRequest)(nil),          // 22: todoService.CreateWebhookRequest
+	(*ListWebhooksRequest)(nil),           // 23: todoService.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),          // 24: todoService.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),          // 25: todoService.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),         // 26: todoService.DeleteWebhookResponse
+	(*WebhookAttempt)(nil),                // 27: todoService.WebhookAttempt
+	(*WebhookDelivery)(nil),               // 28: todoService.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),  // 29: todoService.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 30: todoService.ListWebhookDeliveriesResponse
+	(*RetryWebhookDeliveryRequest)(nil),   // 31: todoService.RetryWebhookDeliveryRequest
+	(*FieldChange)(nil),                   // 32: todoService.FieldChange
+	(*TaskChange)(nil),                    // 33: todoService.TaskChange
+	(*GetTaskHistoryRequest)(nil),         // 34: todoService.GetTaskHistoryRequest
+	(*GetTaskHistoryResponse)(nil),        // 35: todoService.GetTaskHistoryResponse
+	(*ListTaskChangesRequest)(nil),        // 36: todoService.ListTaskChangesRequest
+	(*ListTaskChangesResponse)(nil),       // 37: todoService.ListTaskChangesResponse
+	(*TaskRevision)(nil),                  // 38: todoService.TaskRevision
+	(*ListTaskRevisionsRequest)(nil),      // 39: todoService.ListTaskRevisionsRequest
+	(*ListTaskRevisionsResponse)(nil),     // 40: todoService.ListTaskRevisionsResponse
+	(*RevertTaskRequest)(nil),             // 41: todoService.RevertTaskRequest
+	(*RestoreTaskRequest)(nil),            // 42: todoService.RestoreTaskRequest
+	(*Comment)(nil),                       // 43: todoService.Comment
+	(*AddCommentRequest)(nil),             // 44: todoService.AddCommentRequest
+	(*ListCommentsRequest)(nil),           // 45: todoService.ListCommentsRequest
+	(*ListCommentsResponse)(nil),          // 46: todoService.ListCommentsResponse
+	(*EditCommentRequest)(nil),            // 47: todoService.EditCommentRequest
+	(*DeleteCommentRequest)(nil),          // 48: todoService.DeleteCommentRequest
+	(*DeleteCommentResponse)(nil),         // 49: todoService.DeleteCommentResponse
+	(*Attachment)(nil),                    // 50: todoService.Attachment
+	(*AttachmentInfo)(nil),                // 51: todoService.AttachmentInfo
+	(*UploadAttachmentRequest)(nil),       // 52: todoService.UploadAttachmentRequest
+	(*DownloadAttachmentRequest)(nil),     // 53: todoService.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),    // 54: todoService.DownloadAttachmentResponse
+	(*ListAttachmentsRequest)(nil),        // 55: todoService.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),       // 56: todoService.ListAttachmentsResponse
+	(*DeleteAttachmentRequest)(nil),       // 57: todoService.DeleteAttachmentRequest
+	(*DeleteAttachmentResponse)(nil),      // 58: todoService.DeleteAttachmentResponse
+	(*Workspace)(nil),                     // 59: todoService.Workspace
+	(*CreateWorkspaceRequest)(nil),        // 60: todoService.CreateWorkspaceRequest
+	(*ListWorkspacesRequest)(nil),         // 61: todoService.ListWorkspacesRequest
+	(*ListWorkspacesResponse)(nil),        // 62: todoService.ListWorkspacesResponse
+	(*WorkspaceMember)(nil),               // 63: todoService.WorkspaceMember
+	(*ListWorkspaceMembersRequest)(nil),   // 64: todoService.ListWorkspaceMembersRequest
+	(*ListWorkspaceMembersResponse)(nil),  // 65: todoService.ListWorkspaceMembersResponse
+	(*SetWorkspaceMemberRequest)(nil),     // 66: todoService.SetWorkspaceMemberRequest
+	(*RemoveWorkspaceMemberRequest)(nil),  // 67: todoService.RemoveWorkspaceMemberRequest
+	(*RemoveWorkspaceMemberResponse)(nil), // 68: todoService.RemoveWorkspaceMemberResponse
+	(*AssignTaskRequest)(nil),             // 69: todoService.AssignTaskRequest
+	(*UnassignTaskRequest)(nil),           // 70: todoService.UnassignTaskRequest
+	(*TaskShare)(nil),                     // 71: todoService.TaskShare
+	(*ShareTaskRequest)(nil),              // 72: todoService.ShareTaskRequest
+	(*UnshareTaskRequest)(nil),            // 73: todoService.UnshareTaskRequest
+	(*UnshareTaskResponse)(nil),           // 74: todoService.UnshareTaskResponse
+	(*ListTaskSharesRequest)(nil),         // 75: todoService.ListTaskSharesRequest
+	(*ListTaskSharesResponse)(nil),        // 76: todoService.ListTaskSharesResponse
+	(*ShareLink)(nil),                     // 77: todoService.ShareLink
+	(*CreateShareLinkRequest)(nil),        // 78: todoService.CreateShareLinkRequest
+	(*ListShareLinksRequest)(nil),         // 79: todoService.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),        // 80: todoService.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),        // 81: todoService.RevokeShareLinkRequest
+	(*GetSharedTaskRequest)(nil),          // 82: todoService.GetSharedTaskRequest
+	(*BackupRequest)(nil),                 // 83: todoService.BackupRequest
+	(*BackupSnapshot)(nil),                // 84: todoService.BackupSnapshot
+	nil,                                   // 85: todoService.ImportOptions.FieldMappingEntry
+	(*timestamppb.Timestamp)(nil),         // 86: google.protobuf.Timestamp
+}
+var file_todoService_todo_proto_depIdxs = []int32{
+	86, // 0: todoService.Task.created_at:type_name -> google.protobuf.Timestamp
+	86, // 1: todoService.Task.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: todoService.ListTasksRequest.assignee_filter:type_name -> todoService.AssigneeFilter
+	5,  // 3: todoService.ListTasksResponse.tasks:type_name -> todoService.Task
+	1,  // 4: todoService.ExportTasksRequest.format:type_name -> todoService.TaskFormat
+	1,  // 5: todoService.ImportOptions.format:type_name -> todoService.TaskFormat
+	85, // 6: todoService.ImportOptions.field_mapping:type_name -> todoService.ImportOptions.FieldMappingEntry
+	2,  // 7: todoService.ImportOptions.on_duplicate:type_name -> todoService.DuplicatePolicy
+	15, // 8: todoService.ImportTasksRequest.options:type_name -> todoService.ImportOptions
+	17, // 9: todoService.ImportTasksResponse.errors:type_name -> todoService.ImportRowError
+	86, // 10: todoService.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	21, // 11: todoService.ListWebhooksResponse.webhooks:type_name -> todoService.Webhook
+	86, // 12: todoService.WebhookAttempt.attempted_at:type_name -> google.protobuf.Timestamp
+	86, // 13: todoService.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	86, // 14: todoService.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	27, // 15: todoService.WebhookDelivery.log:type_name -> todoService.WebhookAttempt
+	28, // 16: todoService.ListWebhookDeliveriesResponse.deliveries:type_name -> todoService.WebhookDelivery
+	86, // 17: todoService.TaskChange.changed_at:type_name -> google.protobuf.Timestamp
+	32, // 18: todoService.TaskChange.changes:type_name -> todoService.FieldChange
+	33, // 19: todoService.GetTaskHistoryResponse.changes:type_name -> todoService.TaskChange
+	86, // 20: todoService.ListTaskChangesRequest.start_time:type_name -> google.protobuf.Timestamp
+	86, // 21: todoService.ListTaskChangesRequest.end_time:type_name -> google.protobuf.Timestamp
+	33, // 22: todoService.ListTaskChangesResponse.changes:type_name -> todoService.TaskChange
+	5,  // 23: todoService.TaskRevision.task:type_name -> todoService.Task
+	86, // 24: todoService.TaskRevision.revised_at:type_name -> google.protobuf.Timestamp
+	38, // 25: todoService.ListTaskRevisionsResponse.revisions:type_name -> todoService.TaskRevision
+	86, // 26: todoService.Comment.created_at:type_name -> google.protobuf.Timestamp
+	86, // 27: todoService.Comment.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 28: todoService.ListCommentsResponse.comments:type_name -> todoService.Comment
+	86, // 29: todoService.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	51, // 30: todoService.UploadAttachmentRequest.info:type_name -> todoService.AttachmentInfo
+	50, // 31: todoService.DownloadAttachmentResponse.attachment:type_name -> todoService.Attachment
+	50, // 32: todoService.ListAttachmentsResponse.attachments:type_name -> todoService.Attachment
+	86, // 33: todoService.Workspace.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 34: todoService.Workspace.role:type_name -> todoService.WorkspaceRole
+	59, // 35: todoService.ListWorkspacesResponse.workspaces:type_name -> todoService.Workspace
+	3,  // 36: todoService.WorkspaceMember.role:type_name -> todoService.WorkspaceRole
+	86, // 37: todoService.WorkspaceMember.added_at:type_name -> google.protobuf.Timestamp
+	63, // 38: todoService.ListWorkspaceMembersResponse.members:type_name -> todoService.WorkspaceMember
+	3,  // 39: todoService.SetWorkspaceMemberRequest.role:type_name -> todoService.WorkspaceRole
+	4,  // 40: todoService.TaskShare.role:type_name -> todoService.ShareRole
+	86, // 41: todoService.TaskShare.granted_at:type_name -> google.protobuf.Timestamp
+	4,  // 42: todoService.ShareTaskRequest.role:type_name -> todoService.ShareRole
+	71, // 43: todoService.ListTaskSharesResponse.shares:type_name -> todoService.TaskShare
+	86, // 44: todoService.ShareLink.created_at:type_name -> google.protobuf.Timestamp
+	86, // 45: todoService.ShareLink.expires_at:type_name -> google.protobuf.Timestamp
+	86, // 46: todoService.ShareLink.revoked_at:type_name -> google.protobuf.Timestamp
+	77, // 47: todoService.ListShareLinksResponse.links:type_name -> todoService.ShareLink
+	86, // 48: todoService.BackupSnapshot.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 49: todoService.TodoService.CreateTask:input_type -> todoService.CreateTaskRequest
+	7,  // 50: todoService.TodoService.GetTask:input_type -> todoService.GetTaskRequest
+	8,  // 51: todoService.TodoService.ListTasks:input_type -> todoService.ListTasksRequest
+	10, // 52: todoService.TodoService.UpdateTask:input_type -> todoService.UpdateTaskRequest
+	11, // 53: todoService.TodoService.DeleteTask:input_type -> todoService.DeleteTaskRequest
+	13, // 54: todoService.TodoService.ExportTasks:input_type -> todoService.ExportTasksRequest
+	16, // 55: todoService.TodoService.ImportTasks:input_type -> todoService.ImportTasksRequest
+	19, // 56: todoService.TodoService.GetCalendarFeed:input_type -> todoService.GetCalendarFeedRequest
+	22, // 57: todoService.TodoService.CreateWebhook:input_type -> todoService.CreateWebhookRequest
+	23, // 58: todoService.TodoService.ListWebhooks:input_type -> todoService.ListWebhooksRequest
+	25, // 59: todoService.TodoService.DeleteWebhook:input_type -> todoService.DeleteWebhookRequest
+	29, // 60: todoService.TodoService.ListWebhookDeliveries:input_type -> todoService.ListWebhookDeliveriesRequest
+	31, // 61: todoService.TodoService.RetryWebhookDelivery:input_type -> todoService.RetryWebhookDeliveryRequest
+	34, // 62: todoService.TodoService.GetTaskHistory:input_type -> todoService.GetTaskHistoryRequest
+	36, // 63: todoService.TodoService.ListTaskChanges:input_type -> todoService.ListTaskChangesRequest
+	39, // 64: todoService.TodoService.ListTaskRevisions:input_type -> todoService.ListTaskRevisionsRequest
+	41, // 65: todoService.TodoService.RevertTask:input_type -> todoService.RevertTaskRequest
+	42, // 66: todoService.TodoService.RestoreTask:input_type -> todoService.RestoreTaskRequest
+	44, // 67: todoService.TodoService.AddComment:input_type -> todoService.AddCommentRequest
+	45, // 68: todoService.TodoService.ListComments:input_type -> todoService.ListCommentsRequest
+	47, // 69: todoService.TodoService.EditComment:input_type -> todoService.EditCommentRequest
+	48, // 70: todoService.TodoService.DeleteComment:input_type -> todoService.DeleteCommentRequest
+	52, // 71: todoService.TodoService.UploadAttachment:input_type -> todoService.UploadAttachmentRequest
+	53, // 72: todoService.TodoService.DownloadAttachment:input_type -> todoService.DownloadAttachmentRequest
+	55, // 73: todoService.TodoService.ListAttachments:input_type -> todoService.ListAttachmentsRequest
+	57, // 74: todoService.TodoService.DeleteAttachment:input_type -> todoService.DeleteAttachmentRequest
+	60, // 75: todoService.TodoService.CreateWorkspace:input_type -> todoService.CreateWorkspaceRequest
+	61, // 76: todoService.TodoService.ListWorkspaces:input_type -> todoService.ListWorkspacesRequest
+	64, // 77: todoService.TodoService.ListWorkspaceMembers:input_type -> todoService.ListWorkspaceMembersRequest
+	66, // 78: todoService.TodoService.SetWorkspaceMember:input_type -> todoService.SetWorkspaceMemberRequest
+	67, // 79: todoService.TodoService.RemoveWorkspaceMember:input_type -> todoService.RemoveWorkspaceMemberRequest
+	69, // 80: todoService.TodoService.AssignTask:input_type -> todoService.AssignTaskRequest
+	70, // 81: todoService.TodoService.UnassignTask:input_type -> todoService.UnassignTaskRequest
+	72, // 82: todoService.TodoService.ShareTask:input_type -> todoService.ShareTaskRequest
+	73, // 83: todoService.TodoService.UnshareTask:input_type -> todoService.UnshareTaskRequest
+	75, // 84: todoService.TodoService.ListTaskShares:input_type -> todoService.ListTaskSharesRequest
+	78, // 85: todoService.TodoService.CreateShareLink:input_type -> todoService.CreateShareLinkRequest
+	79, // 86: todoService.TodoService.ListShareLinks:input_type -> todoService.ListShareLinksRequest
+	81, // 87: todoService.TodoService.RevokeShareLink:input_type -> todoService.RevokeShareLinkRequest
+	82, // 88: todoService.TodoService.GetSharedTask:input_type -> todoService.GetSharedTaskRequest
+	83, // 89: todoService.TodoService.Backup:input_type -> todoService.BackupRequest
+	5,  // 90: todoService.TodoService.CreateTask:output_type -> todoService.Task
+	5,  // 91: todoService.TodoService.GetTask:output_type -> todoService.Task
+	9,  // 92: todoService.TodoService.ListTasks:output_type -> todoService.ListTasksResponse
+	5,  // 93: todoService.TodoService.UpdateTask:output_type -> todoService.Task
+	12, // 94: todoService.TodoService.DeleteTask:output_type -> todoService.DeleteTaskResponse
+	14, // 95: todoService.TodoService.ExportTasks:output_type -> todoService.ExportTasksResponse
+	18, // 96: todoService.TodoService.ImportTasks:output_type -> todoService.ImportTasksResponse
+	20, // 97: todoService.TodoService.GetCalendarFeed:output_type -> todoService.CalendarFeed
+	21, // 98: todoService.TodoService.CreateWebhook:output_type -> todoService.Webhook
+	24, // 99: todoService.TodoService.ListWebhooks:output_type -> todoService.ListWebhooksResponse
+	26, // 100: todoService.TodoService.DeleteWebhook:output_type -> todoService.DeleteWebhookResponse
+	30, // 101: todoService.TodoService.ListWebhookDeliveries:output_type -> todoService.ListWebhookDeliveriesResponse
+	28, // 102: todoService.TodoService.RetryWebhookDelivery:output_type -> todoService.WebhookDelivery
+	35, // 103: todoService.TodoService.GetTaskHistory:output_type -> todoService.GetTaskHistoryResponse
+	37, // 104: todoService.TodoService.ListTaskChanges:output_type -> todoService.ListTaskChangesResponse
+	40, // 105: todoService.TodoService.ListTaskRevisions:output_type -> todoService.ListTaskRevisionsResponse
+	5,  // 106: todoService.TodoService.RevertTask:output_type -> todoService.Task
+	5,  // 107: todoService.TodoService.RestoreTask:output_type -> todoService.Task
+	43, // 108: todoService.TodoService.AddComment:output_type -> todoService.Comment
+	46, // 109: todoService.TodoService.ListComments:output_type -> todoService.ListCommentsResponse
+	43, // 110: todoService.TodoService.EditComment:output_type -> todoService.Comment
+	49, // 111: todoService.TodoService.DeleteComment:output_type -> todoService.DeleteCommentResponse
+	50, // 112: todoService.TodoService.UploadAttachment:output_type -> todoService.Attachment
+	54, // 113: todoService.TodoService.DownloadAttachment:output_type -> todoService.DownloadAttachmentResponse
+	56, // 114: todoService.TodoService.ListAttachments:output_type -> todoService.ListAttachmentsResponse
+	58, // 115: todoService.TodoService.DeleteAttachment:output_type -> todoService.DeleteAttachmentResponse
+	59, // 116: todoService.TodoService.CreateWorkspace:output_type -> todoService.Workspace
+	62, // 117: todoService.TodoService.ListWorkspaces:output_type -> todoService.ListWorkspacesResponse
+	65, // 118: todoService.TodoService.ListWorkspaceMembers:output_type -> todoService.ListWorkspaceMembersResponse
+	63, // 119: todoService.TodoService.SetWorkspaceMember:output_type -> todoService.WorkspaceMember
+	68, // 120: todoService.TodoService.RemoveWorkspaceMember:output_type -> todoService.RemoveWorkspaceMemberResponse
+	5,  // 121: todoService.TodoService.AssignTask:output_type -> todoService.Task
+	5,  // 122: todoService.TodoService.UnassignTask:output_type -> todoService.Task
+	71, // 123: todoService.TodoService.ShareTask:output_type -> todoService.TaskShare
+	74, // 124: todoService.TodoService.UnshareTask:output_type -> todoService.UnshareTaskResponse
+	76, // 125: todoService.TodoService.ListTaskShares:output_type -> todoService.ListTaskSharesResponse
+	77, // 126: todoService.TodoService.CreateShareLink:output_type -> todoService.ShareLink
+	80, // 127: todoService.TodoService.ListShareLinks:output_type -> todoService.ListShareLinksResponse
+	77, // 128: todoService.TodoService.RevokeShareLink:output_type -> todoService.ShareLink
+	5,  // 129: todoService.TodoService.GetSharedTask:output_type -> todoService.Task
+	84, // 130: todoService.TodoService.Backup:output_type -> todoService.BackupSnapshot
+	90, // [90:131] is the sub-list for method output_type
+	49, // [49:90] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
+}
+
+func init() { file_todoService_todo_proto_init() }
+func file_todoService_todo_proto_init() {
+	if File_todoService_todo_proto != nil {
+		return
+	}
+	file_todoService_todo_proto_msgTypes[5].OneofWrappers = []any{}
+	file_todoService_todo_proto_msgTypes[11].OneofWrappers = []any{
+		(*ImportTasksRequest_Options)(nil),
+		(*ImportTasksRequest_Data)(nil),
+	}
+	file_todoService_todo_proto_msgTypes[27].OneofWrappers = []any{}
+	file_todoService_todo_proto_msgTypes[47].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Info)(nil),
+		(*UploadAttachmentRequest_Data)(nil),
+	}
+	file_todoService_todo_proto_msgTypes[49].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Data)(nil),
 	}
-	file_todoService_todo_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_todoService_todo_proto_rawDesc), len(file_todoService_todo_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      5,
+			NumMessages:   81,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_todoService_todo_proto_goTypes,
 		DependencyIndexes: file_todoService_todo_proto_depIdxs,
+		EnumInfos:         file_todoService_todo_proto_enumTypes,
 		MessageInfos:      file_todoService_todo_proto_msgTypes,
 	}.Build()
 	File_todoService_todo_proto = out.File